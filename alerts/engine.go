@@ -0,0 +1,145 @@
+package alerts
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/auser/bitping/config"
+	"github.com/auser/bitping/filter"
+	"github.com/auser/bitping/types"
+)
+
+// AlertBuffer is how many alerts can wait to be received before raising
+// them holds up the pipeline
+const AlertBuffer = 1024
+
+// recentBlocks is how many block hashes are remembered to skip replayed
+// blocks
+const recentBlocks = 1024
+
+// Engine is the pipeline stage evaluating alert rules on the actions of
+// every block. Rules keep statistics across blocks, so blocks are
+// evaluated one at a time whatever the concurrency of the stage
+type Engine struct {
+	rules  []*Rule
+	alerts chan types.Alert
+
+	sync.Mutex
+	metrics map[string]*Metrics
+	// recent are the hashes of the last blocks evaluated, oldest first
+	recent    []string
+	evaluated map[string]bool
+}
+
+// Metrics count what a rule did with the actions it matched
+type Metrics struct {
+	Raised int64 `json:"raised"`
+	// Suppressed alerts were held back by the cooldown
+	Suppressed int64 `json:"suppressed"`
+	// Deduplicated alerts had already been raised, i.e. by a transaction
+	// that was mined again after a reorg
+	Deduplicated int64 `json:"deduplicated"`
+}
+
+// New returns the stage evaluating rules
+func New(rules ...*Rule) *Engine {
+	e := &Engine{
+		rules:     rules,
+		alerts:    make(chan types.Alert, AlertBuffer),
+		metrics:   make(map[string]*Metrics),
+		evaluated: make(map[string]bool),
+	}
+	for _, r := range rules {
+		e.metrics[r.Name] = &Metrics{}
+	}
+	return e
+}
+
+// FromConfig compiles the alert rules of the config file. It returns nil
+// when there are none
+func FromConfig(cfg *config.Config) (*Engine, error) {
+	if len(cfg.Alerts) == 0 {
+		return nil, nil
+	}
+
+	var rules []*Rule
+	for i, ac := range cfg.Alerts {
+		r, err := NewRule(ac)
+		if err != nil {
+			return nil, fmt.Errorf("alerts[%d]: %s: %v", i, ac.Name, err)
+		}
+		rules = append(rules, r)
+	}
+	return New(rules...), nil
+}
+
+// Alerts returns the channel alerts are raised on. It has to be received
+// from, once AlertBuffer alerts are waiting the pipeline stops
+func (e *Engine) Alerts() <-chan types.Alert {
+	return e.alerts
+}
+
+// Name returns the name of the pipeline stage
+func (e *Engine) Name() string {
+	return "alerts"
+}
+
+// Process evaluates the rules applying to block on each of its actions.
+// Blocks that were already evaluated, like the ones replayed after a
+// restart, are skipped so they don't count twice in the statistics
+func (e *Engine) Process(block *types.Block) error {
+	var out []types.Alert
+
+	e.Lock()
+	key := block.Network + "/" + block.Hash
+	if e.evaluated[key] {
+		e.Unlock()
+		return nil
+	}
+	e.evaluated[key] = true
+	e.recent = append(e.recent, key)
+	if len(e.recent) > recentBlocks {
+		delete(e.evaluated, e.recent[0])
+		e.recent = e.recent[1:]
+	}
+
+	for _, r := range e.rules {
+		if !r.AppliesTo(block.Network) {
+			continue
+		}
+		m := e.metrics[r.Name]
+		for i := range block.Transactions {
+			tx := &block.Transactions[i]
+			for j := range tx.Actions {
+				alert, v := r.evaluate(filter.Subject{Block: block, Transaction: tx, Action: &tx.Actions[j]}, j)
+				switch v {
+				case raised:
+					m.Raised++
+					out = append(out, *alert)
+				case suppressed:
+					m.Suppressed++
+				case deduplicated:
+					m.Deduplicated++
+				}
+			}
+		}
+		r.sweep(block.Time)
+	}
+	e.Unlock()
+
+	for _, alert := range out {
+		e.alerts <- alert
+	}
+	return nil
+}
+
+// Metrics returns a snapshot of the metrics of each rule by name
+func (e *Engine) Metrics() map[string]Metrics {
+	e.Lock()
+	defer e.Unlock()
+	out := make(map[string]Metrics, len(e.metrics))
+	for name, m := range e.metrics {
+		out[name] = *m
+	}
+	return out
+}
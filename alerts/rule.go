@@ -0,0 +1,413 @@
+package alerts
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/auser/bitping/config"
+	"github.com/auser/bitping/filter"
+	"github.com/auser/bitping/types"
+)
+
+// Stats a rule can compute
+const (
+	// StatValue is the field of each action, or every action matching when
+	// there's no field
+	StatValue = "value"
+	// StatCount is how many actions matched over the window
+	StatCount = "count"
+	// StatSum is the sum of the field over the window
+	StatSum = "sum"
+	// StatMean is the mean of the field over the window
+	StatMean = "mean"
+	// StatRate is the sum of the field, or the count without one, per
+	// second of the window
+	StatRate = "rate"
+	// StatZScore is how many standard deviations the field of an action is
+	// from its mean over the window
+	StatZScore = "zscore"
+)
+
+// Defaults of the rule options
+const (
+	DefaultMinSamples = 10
+	DefaultDedup      = time.Hour
+)
+
+// Rule raises alerts on the actions of some networks. Its state is only
+// safe to use from a single Engine
+type Rule struct {
+	Name     string
+	Severity string
+	// Networks the rule applies to, all of them when empty
+	Networks []string
+	// Where selects the actions the rule looks at, all of them when nil
+	Where *filter.Expr
+
+	Stat       string
+	Field      string
+	Above      *float64
+	Below      *float64
+	Window     time.Duration
+	GroupBy    string
+	MinSamples int
+	Cooldown   time.Duration
+	Dedup      time.Duration
+
+	value func(s filter.Subject) *big.Rat
+	group func(s filter.Subject) string
+
+	groups    map[string]*group
+	seen      map[string]int64
+	lastSweep int64
+}
+
+// group is the window of samples of a group of actions and when it last
+// raised an alert
+type group struct {
+	samples    []sample
+	sum        float64
+	sumSq      float64
+	last       int64
+	lastAlert  int64
+	alerted    bool
+	suppressed int
+}
+
+type sample struct {
+	at    int64
+	value float64
+}
+
+// NewRule checks and compiles a rule of the config file
+func NewRule(ac config.AlertConfig) (*Rule, error) {
+	r := &Rule{
+		Name:       ac.Name,
+		Severity:   ac.Severity,
+		Networks:   ac.Networks,
+		Stat:       ac.Stat,
+		Field:      ac.Field,
+		Above:      ac.Above,
+		Below:      ac.Below,
+		GroupBy:    ac.GroupBy,
+		MinSamples: ac.MinSamples,
+		Dedup:      DefaultDedup,
+		groups:     make(map[string]*group),
+		seen:       make(map[string]int64),
+	}
+
+	switch r.Severity {
+	case "":
+		r.Severity = types.SeverityWarning
+	case types.SeverityInfo, types.SeverityWarning, types.SeverityCritical:
+	default:
+		return nil, fmt.Errorf("unknown severity %q", r.Severity)
+	}
+
+	if ac.Where != "" {
+		where, err := filter.Compile(ac.Where)
+		if err != nil {
+			return nil, err
+		}
+		r.Where = where
+	}
+
+	if r.Stat == "" {
+		r.Stat = StatValue
+	}
+	switch r.Stat {
+	case StatValue, StatCount, StatRate:
+	case StatSum, StatMean, StatZScore:
+		if r.Field == "" {
+			return nil, fmt.Errorf("%s needs a field", r.Stat)
+		}
+	default:
+		return nil, fmt.Errorf("unknown stat %q", r.Stat)
+	}
+	if r.Field != "" {
+		if r.Stat == StatCount {
+			return nil, fmt.Errorf("count doesn't take a field")
+		}
+		value, err := filter.NumberField(r.Field)
+		if err != nil {
+			return nil, err
+		}
+		r.value = value
+	}
+	if r.GroupBy != "" {
+		group, err := filter.StringField(r.GroupBy)
+		if err != nil {
+			return nil, fmt.Errorf("group_by: %v", err)
+		}
+		r.group = group
+	}
+
+	if r.Above == nil && r.Below == nil && !(r.Stat == StatValue && r.Field == "") {
+		return nil, fmt.Errorf("%s needs a threshold, above or below", r.Stat)
+	}
+
+	var err error
+	if r.Window, err = parseDuration("window", ac.Window); err != nil {
+		return nil, err
+	}
+	if r.Stat != StatValue && r.Window <= 0 {
+		return nil, fmt.Errorf("%s needs a window", r.Stat)
+	}
+	if r.Stat == StatZScore && r.MinSamples <= 0 {
+		r.MinSamples = DefaultMinSamples
+	}
+
+	// A windowed stat stays over its threshold for a while, by default
+	// the group is held back for a window once it raised an alert
+	r.Cooldown = r.Window
+	if r.Stat == StatValue {
+		r.Cooldown = 0
+	}
+	if ac.Cooldown != "" {
+		if r.Cooldown, err = parseDuration("cooldown", ac.Cooldown); err != nil {
+			return nil, err
+		}
+	}
+	if ac.Dedup != "" {
+		if r.Dedup, err = parseDuration("dedup", ac.Dedup); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// AppliesTo returns true when the rule looks at the blocks of network
+func (r *Rule) AppliesTo(network string) bool {
+	if len(r.Networks) == 0 {
+		return true
+	}
+	for _, n := range r.Networks {
+		if n == network {
+			return true
+		}
+	}
+	return false
+}
+
+// verdict is what a rule made of an action
+type verdict int
+
+const (
+	ignored verdict = iota
+	raised
+	suppressed
+	deduplicated
+)
+
+// evaluate adds the action of s, the index-th of its transaction, to the
+// statistics and returns the alert it raises
+func (r *Rule) evaluate(s filter.Subject, index int) (*types.Alert, verdict) {
+	if r.Where != nil && !r.Where.Match(s) {
+		return nil, ignored
+	}
+
+	v := 1.0
+	if r.value != nil {
+		value := r.value(s)
+		if value == nil {
+			return nil, ignored
+		}
+		v, _ = value.Float64()
+	}
+
+	at := s.Block.Time
+	var name string
+	if r.group != nil {
+		name = r.group(s)
+	}
+	key := s.Block.Network + "/" + name
+	g, ok := r.groups[key]
+	if !ok {
+		g = &group{}
+		r.groups[key] = g
+	}
+	g.last = at
+
+	stat, ok := r.stat(g, at, v)
+	if !ok {
+		return nil, ignored
+	}
+
+	condition, threshold := "", 0.0
+	switch {
+	case r.Above != nil && stat > *r.Above:
+		condition, threshold = ">", *r.Above
+	case r.Below != nil && stat < *r.Below:
+		condition, threshold = "<", *r.Below
+	case r.Above != nil || r.Below != nil:
+		return nil, ignored
+	}
+
+	id := alertID(r.Name, key, s.Transaction.Hash, index)
+	if _, ok := r.seen[id]; ok {
+		return nil, deduplicated
+	}
+	r.seen[id] = at
+
+	if g.alerted && at < g.lastAlert+int64(r.Cooldown/time.Second) {
+		g.suppressed++
+		return nil, suppressed
+	}
+
+	tx := *s.Transaction
+	action := *s.Action
+	alert := &types.Alert{
+		ID:          id,
+		Rule:        r.Name,
+		Severity:    r.Severity,
+		Network:     s.Block.Network,
+		Group:       name,
+		Stat:        r.Stat,
+		Field:       r.Field,
+		Window:      int64(r.Window / time.Second),
+		Value:       stat,
+		Condition:   condition,
+		Threshold:   threshold,
+		BlockHash:   s.Block.Hash,
+		BlockNumber: s.Block.Number,
+		Time:        at,
+		Transaction: &tx,
+		Action:      &action,
+		Suppressed:  g.suppressed,
+	}
+	alert.Message = r.message(alert)
+
+	g.alerted = true
+	g.lastAlert = at
+	g.suppressed = 0
+	return alert, raised
+}
+
+// stat adds v to the window of g and computes the stat. It returns false
+// when there aren't enough samples yet
+func (r *Rule) stat(g *group, at int64, v float64) (float64, bool) {
+	if r.Stat == StatValue {
+		return v, true
+	}
+
+	// The window holds the samples of (at-window, at]
+	from := at - int64(r.Window/time.Second)
+	drop := 0
+	for drop < len(g.samples) && g.samples[drop].at <= from {
+		g.sum -= g.samples[drop].value
+		g.sumSq -= g.samples[drop].value * g.samples[drop].value
+		drop++
+	}
+	g.samples = g.samples[drop:]
+	if len(g.samples) == 0 {
+		// Start over so the rounding errors of the running sums don't pile up
+		g.sum, g.sumSq = 0, 0
+	}
+
+	var stat float64
+	ok := true
+	if r.Stat == StatZScore {
+		// The action is scored against the ones before it
+		n := float64(len(g.samples))
+		ok = len(g.samples) >= r.MinSamples
+		if ok {
+			mean := g.sum / n
+			variance := g.sumSq/n - mean*mean
+			ok = variance > 0
+			stat = (v - mean) / math.Sqrt(variance)
+		}
+	}
+
+	g.samples = append(g.samples, sample{at: at, value: v})
+	g.sum += v
+	g.sumSq += v * v
+
+	switch r.Stat {
+	case StatCount:
+		stat = float64(len(g.samples))
+	case StatSum:
+		stat = g.sum
+	case StatMean:
+		stat = g.sum / float64(len(g.samples))
+	case StatRate:
+		stat = g.sum / r.Window.Seconds()
+	}
+	return stat, ok
+}
+
+// sweep forgets the groups that have been idle for longer than the window
+// and the cooldown, and the alerts older than the dedup window
+func (r *Rule) sweep(at int64) {
+	idle := r.Window
+	if r.Cooldown > idle {
+		idle = r.Cooldown
+	}
+	every := idle
+	if r.Dedup > every {
+		every = r.Dedup
+	}
+	if every < time.Minute {
+		every = time.Minute
+	}
+	if at-r.lastSweep < int64(every/time.Second) {
+		return
+	}
+	r.lastSweep = at
+
+	for key, g := range r.groups {
+		if g.last <= at-int64(idle/time.Second) && g.lastAlert <= at-int64(r.Cooldown/time.Second) {
+			delete(r.groups, key)
+		}
+	}
+	for id, seen := range r.seen {
+		if seen <= at-int64(r.Dedup/time.Second) {
+			delete(r.seen, id)
+		}
+	}
+}
+
+func (r *Rule) message(a *types.Alert) string {
+	var msg string
+	switch {
+	case a.Condition == "":
+		msg = fmt.Sprintf("%s matched transaction %s", r.Name, a.Transaction.Hash)
+	case r.Stat == StatValue:
+		msg = fmt.Sprintf("%s %s %s %s", r.Field, formatFloat(a.Value), a.Condition, formatFloat(a.Threshold))
+	default:
+		of := r.Field
+		if of == "" {
+			of = "actions"
+		}
+		msg = fmt.Sprintf("%s of %s over %s %s %s %s", r.Stat, of, r.Window, formatFloat(a.Value), a.Condition, formatFloat(a.Threshold))
+	}
+	if a.Group != "" {
+		msg += fmt.Sprintf(" for %s %s", r.GroupBy, a.Group)
+	}
+	return msg
+}
+
+// alertID fingerprints the alert of a rule on an action
+func alertID(rule, key, tx string, index int) string {
+	sum := sha1.Sum([]byte(rule + "/" + key + "/" + tx + "/" + strconv.Itoa(index)))
+	return hex.EncodeToString(sum[:8])
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(math.Round(f*1e4)/1e4, 'f', -1, 64)
+}
+
+func parseDuration(option, s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %v", option, err)
+	}
+	return d, nil
+}
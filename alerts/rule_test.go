@@ -0,0 +1,205 @@
+package alerts
+
+import (
+	"math"
+	"testing"
+
+	"github.com/auser/bitping/config"
+	"github.com/auser/bitping/filter"
+	"github.com/auser/bitping/types"
+)
+
+// subject returns the index-th action of transaction tx, paying value to to
+// in a block of network at time at
+func subject(network string, at int64, tx, to string, value int64) filter.Subject {
+	return filter.Subject{
+		Block:       &types.Block{Network: network, Hash: tx + "-block", Time: at},
+		Transaction: &types.Transaction{Hash: tx},
+		Action:      &types.Action{TransactionHash: tx, To: to, Value: types.BigIntFromInt(value)},
+	}
+}
+
+func threshold(f float64) *float64 { return &f }
+
+func newTestRule(t *testing.T, ac config.AlertConfig) *Rule {
+	if ac.Name == "" {
+		ac.Name = "test"
+	}
+	r, err := NewRule(ac)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestRuleStat(t *testing.T) {
+	type step struct {
+		at, value int64
+		verdict   verdict
+		stat      float64
+	}
+	// Above -1 with no cooldown raises on every sample so each step shows
+	// the stat
+	always := threshold(-1)
+
+	tests := []struct {
+		name  string
+		rule  config.AlertConfig
+		steps []step
+	}{
+		{
+			name:  "value",
+			rule:  config.AlertConfig{Field: "value", Above: threshold(5)},
+			steps: []step{{0, 3, ignored, 0}, {1, 7, raised, 7}},
+		},
+		{
+			// The window holds the samples of (at-window, at]
+			name:  "count",
+			rule:  config.AlertConfig{Stat: StatCount, Window: "10s", Cooldown: "0s", Above: always},
+			steps: []step{{0, 1, raised, 1}, {5, 2, raised, 2}, {10, 4, raised, 2}, {16, 8, raised, 2}},
+		},
+		{
+			name:  "sum",
+			rule:  config.AlertConfig{Stat: StatSum, Field: "value", Window: "10s", Cooldown: "0s", Above: always},
+			steps: []step{{0, 1, raised, 1}, {5, 2, raised, 3}, {10, 4, raised, 6}, {16, 8, raised, 12}},
+		},
+		{
+			name:  "mean",
+			rule:  config.AlertConfig{Stat: StatMean, Field: "value", Window: "10s", Cooldown: "0s", Above: always},
+			steps: []step{{0, 1, raised, 1}, {5, 2, raised, 1.5}, {10, 4, raised, 3}, {16, 8, raised, 6}},
+		},
+		{
+			name:  "rate of actions",
+			rule:  config.AlertConfig{Stat: StatRate, Window: "10s", Cooldown: "0s", Above: always},
+			steps: []step{{0, 1, raised, 0.1}, {5, 2, raised, 0.2}, {10, 4, raised, 0.2}},
+		},
+		{
+			name:  "rate of a field",
+			rule:  config.AlertConfig{Stat: StatRate, Field: "value", Window: "10s", Cooldown: "0s", Above: always},
+			steps: []step{{0, 1, raised, 0.1}, {5, 2, raised, 0.3}, {10, 4, raised, 0.6}},
+		},
+		{
+			name:  "below",
+			rule:  config.AlertConfig{Stat: StatSum, Field: "value", Window: "10s", Cooldown: "0s", Below: threshold(5)},
+			steps: []step{{0, 1, raised, 1}, {5, 2, raised, 3}, {6, 4, ignored, 0}, {20, 3, raised, 3}},
+		},
+		{
+			// 10 is scored against the mean 2 and deviation 0.8165 of the
+			// samples before it
+			name: "zscore",
+			rule: config.AlertConfig{Stat: StatZScore, Field: "value", Window: "1m", MinSamples: 3, Cooldown: "0s", Above: threshold(3)},
+			steps: []step{
+				{0, 1, ignored, 0}, {1, 2, ignored, 0}, {2, 3, ignored, 0},
+				{3, 10, raised, 8 / math.Sqrt(2.0/3)},
+				{4, 5, ignored, 0},
+			},
+		},
+		{
+			name:  "zscore without deviation",
+			rule:  config.AlertConfig{Stat: StatZScore, Field: "value", Window: "1m", MinSamples: 2, Cooldown: "0s", Above: threshold(3)},
+			steps: []step{{0, 4, ignored, 0}, {1, 4, ignored, 0}, {2, 100, ignored, 0}},
+		},
+		{
+			name:  "zscore with samples out of the window",
+			rule:  config.AlertConfig{Stat: StatZScore, Field: "value", Window: "10s", MinSamples: 3, Cooldown: "0s", Above: threshold(3)},
+			steps: []step{{0, 1, ignored, 0}, {1, 2, ignored, 0}, {2, 3, ignored, 0}, {12, 10, ignored, 0}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRule(t, tt.rule)
+			for i, step := range tt.steps {
+				tx := string(rune('a' + i))
+				alert, v := r.evaluate(subject("ethereum", step.at, tx, "0xbob", step.value), 0)
+				if v != step.verdict {
+					t.Fatalf("step %d: verdict %d, want %d", i, v, step.verdict)
+				}
+				if v == raised && math.Abs(alert.Value-step.stat) > 1e-9 {
+					t.Errorf("step %d: %s = %v, want %v", i, tt.rule.Stat, alert.Value, step.stat)
+				}
+			}
+		})
+	}
+}
+
+func TestRuleCooldown(t *testing.T) {
+	r := newTestRule(t, config.AlertConfig{Stat: StatCount, Window: "1m", GroupBy: "to", Above: threshold(1)})
+
+	steps := []struct {
+		at         int64
+		to         string
+		verdict    verdict
+		suppressed int
+	}{
+		{0, "0xaa", ignored, 0},
+		{1, "0xaa", raised, 0},
+		// The window is the default cooldown
+		{2, "0xaa", suppressed, 0},
+		{30, "0xaa", suppressed, 0},
+		// Groups cool down on their own
+		{30, "0xbb", ignored, 0},
+		{31, "0xbb", raised, 0},
+		// The alert after the cooldown counts the ones held back
+		{61, "0xaa", raised, 2},
+		{62, "0xaa", suppressed, 0},
+	}
+
+	for i, step := range steps {
+		tx := string(rune('a' + i))
+		alert, v := r.evaluate(subject("ethereum", step.at, tx, step.to, 1), 0)
+		if v != step.verdict {
+			t.Fatalf("step %d: verdict %d, want %d", i, v, step.verdict)
+		}
+		if v != raised {
+			continue
+		}
+		if alert.Group != step.to || alert.Suppressed != step.suppressed {
+			t.Errorf("step %d: group %s with %d suppressed, want %s with %d", i, alert.Group, alert.Suppressed, step.to, step.suppressed)
+		}
+	}
+}
+
+func TestRuleDedup(t *testing.T) {
+	r := newTestRule(t, config.AlertConfig{Where: "value > 0", Dedup: "1m"})
+
+	steps := []struct {
+		network string
+		at      int64
+		tx      string
+		index   int
+		verdict verdict
+	}{
+		{"ethereum", 0, "0x01", 0, raised},
+		{"ethereum", 0, "0x01", 1, raised},
+		// The same transaction in another block, like after a reorg
+		{"ethereum", 10, "0x01", 0, deduplicated},
+		{"classic", 10, "0x01", 0, raised},
+		{"ethereum", 20, "0x02", 0, raised},
+		// Forgotten once the dedup window swept past it
+		{"ethereum", 100, "0x01", 0, raised},
+		{"ethereum", 100, "0x01", 0, deduplicated},
+	}
+
+	ids := make(map[string]bool)
+	for i, step := range steps {
+		if step.at == 100 {
+			r.sweep(step.at)
+		}
+		alert, v := r.evaluate(subject(step.network, step.at, step.tx, "0xbob", 1), step.index)
+		if v != step.verdict {
+			t.Fatalf("step %d: verdict %d, want %d", i, v, step.verdict)
+		}
+		if v == raised && step.at < 100 {
+			if ids[alert.ID] {
+				t.Errorf("step %d: id %s raised twice", i, alert.ID)
+			}
+			ids[alert.ID] = true
+		}
+	}
+
+	// Actions the where clause leaves out are ignored before dedup
+	if _, v := r.evaluate(subject("ethereum", 100, "0x03", "0xbob", 0), 0); v != ignored {
+		t.Errorf("verdict %d for a zero value, want ignored", v)
+	}
+}
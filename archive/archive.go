@@ -0,0 +1,217 @@
+package archive
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/auser/bitping/config"
+	"github.com/auser/bitping/types"
+	"github.com/codegangsta/cli"
+	"github.com/golang/snappy"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// ErrNotFound is returned when no block is archived at a height
+var ErrNotFound = errors.New("archive: block not found")
+
+// Entry is the index record of an archived block
+type Entry struct {
+	Network string `json:"network"`
+	Number  int64  `json:"number"`
+	Hash    string `json:"hash"`
+	// Format is the raw payload format, rlp or json
+	Format string `json:"format"`
+	// Digest is the sha256 of the uncompressed payload, which is also where
+	// it's stored
+	Digest string `json:"digest"`
+	Size   int    `json:"size"`
+}
+
+// Store keeps raw native blocks on disk. Payloads are snappy compressed and
+// stored by content digest under objects/, so a block seen twice is only
+// written once. A leveldb index maps network and height to the payload of
+// the canonical block at that height
+type Store struct {
+	dir   string
+	index *leveldb.DB
+}
+
+// AddCLIFlags adds the archive flags
+func AddCLIFlags(fs []cli.Flag) []cli.Flag {
+	return append(fs,
+		cli.StringFlag{
+			Name:   "archive",
+			Usage:  "directory to archive the raw blocks in",
+			EnvVar: "BITPING_ARCHIVE",
+		},
+	)
+}
+
+// Open opens the store in dir, creating it if needed
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Join(dir, "objects"), 0755); err != nil {
+		return nil, err
+	}
+
+	index, err := leveldb.OpenFile(filepath.Join(dir, "index"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{dir: dir, index: index}, nil
+}
+
+// Close closes the index
+func (s *Store) Close() error {
+	return s.index.Close()
+}
+
+// Put archives the raw payload of block. A block at a height that's already
+// archived replaces it in the index, which is what happens after a reorg
+func (s *Store) Put(block types.Block) error {
+	if block.Raw == nil {
+		return fmt.Errorf("archive: block %d on %s has no raw payload", block.Number, block.Network)
+	}
+
+	sum := sha256.Sum256(block.Raw.Data)
+	entry := Entry{
+		Network: block.Network,
+		Number:  block.Number,
+		Hash:    block.Hash,
+		Format:  block.Raw.Format,
+		Digest:  hex.EncodeToString(sum[:]),
+		Size:    len(block.Raw.Data),
+	}
+
+	if err := s.writeObject(entry.Digest, block.Raw.Data); err != nil {
+		return err
+	}
+
+	value, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return s.index.Put(indexKey(entry.Network, entry.Number), value, nil)
+}
+
+// Get returns the archived block at a height
+func (s *Store) Get(network string, number int64) (Entry, []byte, error) {
+	value, err := s.index.Get(indexKey(network, number), nil)
+	if err == leveldb.ErrNotFound {
+		return Entry{}, nil, ErrNotFound
+	}
+	if err != nil {
+		return Entry{}, nil, err
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(value, &entry); err != nil {
+		return Entry{}, nil, err
+	}
+
+	data, err := s.readObject(entry.Digest)
+	return entry, data, err
+}
+
+// Range calls fn with each archived block of network between from and to,
+// inclusive, lowest first. Missing heights are skipped
+func (s *Store) Range(network string, from, to int64, fn func(Entry, []byte) error) error {
+	iter := s.index.NewIterator(&util.Range{
+		Start: indexKey(network, from),
+		Limit: indexKey(network, to+1),
+	}, nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		var entry Entry
+		if err := json.Unmarshal(iter.Value(), &entry); err != nil {
+			return err
+		}
+
+		data, err := s.readObject(entry.Digest)
+		if err != nil {
+			return err
+		}
+		if err := fn(entry, data); err != nil {
+			return err
+		}
+	}
+
+	return iter.Error()
+}
+
+func (s *Store) objectPath(digest string) string {
+	return filepath.Join(s.dir, "objects", digest[:2], digest[2:])
+}
+
+// writeObject stores data compressed under its digest unless it's already
+// there
+func (s *Store) writeObject(digest string, data []byte) error {
+	path := s.objectPath(digest)
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so a crash never leaves a truncated
+	// object behind
+	tmp, err := ioutil.TempFile(filepath.Dir(path), "tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(snappy.Encode(nil, data)); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *Store) readObject(digest string) ([]byte, error) {
+	compressed, err := ioutil.ReadFile(s.objectPath(digest))
+	if err != nil {
+		return nil, err
+	}
+	return snappy.Decode(nil, compressed)
+}
+
+// indexKey is the network followed by the big endian height, so a network's
+// keys sort by height. The separator keeps one network name from being a
+// prefix of another's keys
+func indexKey(network string, number int64) []byte {
+	key := make([]byte, 0, len(network)+9)
+	key = append(key, network...)
+	key = append(key, 0)
+	var height [8]byte
+	binary.BigEndian.PutUint64(height[:], uint64(number))
+	return append(key, height[:]...)
+}
+
+// FromCLI opens the store set with --archive or in the config file. It
+// returns nil when archiving is off
+func FromCLI(c *cli.Context, cfg *config.Config) (*Store, error) {
+	dir := cfg.Archive
+	if c.IsSet("archive") {
+		dir = c.String("archive")
+	}
+	if dir == "" {
+		return nil, nil
+	}
+	return Open(dir)
+}
@@ -0,0 +1,252 @@
+package blockchains
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"strings"
+)
+
+// Script types, named the way bitcoind names them
+const (
+	scriptPubKey      = "pubkey"
+	scriptPubKeyHash  = "pubkeyhash"
+	scriptScriptHash  = "scripthash"
+	scriptMultisig    = "multisig"
+	scriptNullData    = "nulldata"
+	scriptWitnessV0PK = "witness_v0_keyhash"
+	scriptWitnessV0SH = "witness_v0_scripthash"
+	scriptTaproot     = "witness_v1_taproot"
+	scriptWitnessUnk  = "witness_unknown"
+	scriptNonStandard = "nonstandard"
+)
+
+// Script opcodes used to recognise standard scripts
+const (
+	opFalse       = 0x00
+	opPushData20  = 0x14
+	opPushData32  = 0x20
+	opPushData33  = 0x21
+	opPushData65  = 0x41
+	opOne         = 0x51
+	opSixteen     = 0x60
+	opReturn      = 0x6a
+	opDup         = 0x76
+	opEqual       = 0x87
+	opEqualVerify = 0x88
+	opHash160     = 0xa9
+	opCheckSig    = 0xac
+	opCheckMulti  = 0xae
+)
+
+// classifyScript returns the script type of an output script
+func classifyScript(script []byte) string {
+	n := len(script)
+	switch {
+	case n == 25 && script[0] == opDup && script[1] == opHash160 &&
+		script[2] == opPushData20 && script[23] == opEqualVerify && script[24] == opCheckSig:
+		return scriptPubKeyHash
+	case n == 23 && script[0] == opHash160 && script[1] == opPushData20 && script[22] == opEqual:
+		return scriptScriptHash
+	case n == 22 && script[0] == opFalse && script[1] == opPushData20:
+		return scriptWitnessV0PK
+	case n == 34 && script[0] == opFalse && script[1] == opPushData32:
+		return scriptWitnessV0SH
+	case n == 34 && script[0] == opOne && script[1] == opPushData32:
+		return scriptTaproot
+	case n >= 4 && n <= 42 && script[0] >= opOne && script[0] <= opSixteen && int(script[1]) == n-2:
+		return scriptWitnessUnk
+	case (n == 35 && script[0] == opPushData33 || n == 67 && script[0] == opPushData65) && script[n-1] == opCheckSig:
+		return scriptPubKey
+	case n > 0 && script[0] == opReturn:
+		return scriptNullData
+	case n > 3 && script[n-1] == opCheckMulti && script[0] >= opOne && script[0] <= opSixteen:
+		return scriptMultisig
+	}
+	return scriptNonStandard
+}
+
+// address returns the address an output script pays to, or an empty string
+// for scripts that don't have one
+func (p BitcoinProfile) address(script []byte) string {
+	switch classifyScript(script) {
+	case scriptPubKeyHash:
+		if p.CashAddrPrefix != "" {
+			return cashAddrEncode(p.CashAddrPrefix, cashAddrPubKeyHash, script[3:23])
+		}
+		return base58CheckEncode(p.PubKeyHashAddrID, script[3:23])
+	case scriptScriptHash:
+		if p.CashAddrPrefix != "" {
+			return cashAddrEncode(p.CashAddrPrefix, cashAddrScriptHash, script[2:22])
+		}
+		return base58CheckEncode(p.ScriptHashAddrID, script[2:22])
+	case scriptWitnessV0PK, scriptWitnessV0SH, scriptTaproot, scriptWitnessUnk:
+		if p.Bech32HRP == "" {
+			return ""
+		}
+		version := byte(0)
+		if script[0] != opFalse {
+			version = script[0] - opOne + 1
+		}
+		return segwitEncode(p.Bech32HRP, version, script[2:])
+	}
+	return ""
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// base58CheckEncode encodes payload with a version byte and checksum
+func base58CheckEncode(version byte, payload []byte) string {
+	data := append([]byte{version}, payload...)
+	first := sha256.Sum256(data)
+	second := sha256.Sum256(first[:])
+	data = append(data, second[:4]...)
+
+	var out []byte
+	x := new(big.Int).SetBytes(data)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+	for x.Sign() > 0 {
+		x.DivMod(x, radix, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+	for _, b := range data {
+		if b != 0 {
+			break
+		}
+		out = append(out, base58Alphabet[0])
+	}
+
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// Checksum constants for bech32 (BIP-173, segwit v0) and bech32m (BIP-350,
+// segwit v1+)
+const (
+	bech32Const  = 1
+	bech32mConst = 0x2bc830a3
+)
+
+func bech32Polymod(values []byte) uint32 {
+	gen := []uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+func bech32HRPExpand(hrp string) []byte {
+	out := make([]byte, 0, len(hrp)*2+1)
+	for _, c := range hrp {
+		out = append(out, byte(c>>5))
+	}
+	out = append(out, 0)
+	for _, c := range hrp {
+		out = append(out, byte(c&31))
+	}
+	return out
+}
+
+// convertBits regroups data from fromBits to toBits wide groups, padding
+// the last group with zeros
+func convertBits(data []byte, fromBits, toBits uint) []byte {
+	var (
+		acc  uint32
+		bits uint
+		out  []byte
+	)
+	maxv := uint32(1)<<toBits - 1
+	for _, b := range data {
+		acc = acc<<fromBits | uint32(b)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			out = append(out, byte(acc>>bits&maxv))
+		}
+	}
+	if bits > 0 {
+		out = append(out, byte(acc<<(toBits-bits)&maxv))
+	}
+	return out
+}
+
+// segwitEncode encodes a witness program as a bech32 (v0) or bech32m (v1+)
+// address
+func segwitEncode(hrp string, version byte, program []byte) string {
+	data := append([]byte{version}, convertBits(program, 8, 5)...)
+
+	constant := uint32(bech32Const)
+	if version > 0 {
+		constant = bech32mConst
+	}
+	values := append(bech32HRPExpand(hrp), data...)
+	polymod := bech32Polymod(append(values, 0, 0, 0, 0, 0, 0)) ^ constant
+
+	var sb strings.Builder
+	sb.WriteString(hrp)
+	sb.WriteByte('1')
+	for _, d := range data {
+		sb.WriteByte(bech32Charset[d])
+	}
+	for i := 0; i < 6; i++ {
+		sb.WriteByte(bech32Charset[(polymod>>uint(5*(5-i)))&31])
+	}
+	return sb.String()
+}
+
+// CashAddr version byte types
+const (
+	cashAddrPubKeyHash = 0
+	cashAddrScriptHash = 1
+)
+
+func cashAddrPolymod(values []byte) uint64 {
+	gen := []uint64{0x98f2bc8e61, 0x79b76d99e2, 0xf33e5fb3c4, 0xae2eabe2a8, 0x1e4f43e470}
+	chk := uint64(1)
+	for _, v := range values {
+		top := chk >> 35
+		chk = (chk&0x07ffffffff)<<5 ^ uint64(v)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk ^ 1
+}
+
+// cashAddrEncode encodes a 20 byte hash as a Bitcoin Cash address
+func cashAddrEncode(prefix string, addrType byte, hash []byte) string {
+	// The size bits are 0 for 160 bit hashes
+	data := convertBits(append([]byte{addrType << 3}, hash...), 8, 5)
+
+	values := make([]byte, 0, len(prefix)+1+len(data)+8)
+	for _, c := range prefix {
+		values = append(values, byte(c&31))
+	}
+	values = append(values, 0)
+	values = append(values, data...)
+	polymod := cashAddrPolymod(append(values, 0, 0, 0, 0, 0, 0, 0, 0))
+
+	var sb strings.Builder
+	sb.WriteString(prefix)
+	sb.WriteByte(':')
+	for _, d := range data {
+		sb.WriteByte(bech32Charset[d])
+	}
+	for i := 0; i < 8; i++ {
+		sb.WriteByte(bech32Charset[(polymod>>uint(5*(7-i)))&31])
+	}
+	return sb.String()
+}
@@ -0,0 +1,244 @@
+package blockchains
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	types "github.com/auser/bitping/types"
+)
+
+func TestBase58CheckEncode(t *testing.T) {
+	tests := []struct {
+		version byte
+		hash    string
+		address string
+	}{
+		// The genesis block coinbase
+		{0x00, "62e907b15cbf27d5425399ebf6f0fb50ebb88f18", "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"},
+		{0x05, "b472a266d0bd89c13706a4132ccfb16f7c3b9fcb", "3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy"},
+		// Leading zero bytes encode as 1s
+		{0x00, "0000000000000000000000000000000000000000", "1111111111111111111114oLvT2"},
+		{0x6f, "0000000000000000000000000000000000000000", "mfWxJ45yp2SFn7UciZyNpvDKrzbhyfKrY8"},
+	}
+
+	for _, tt := range tests {
+		hash, _ := hex.DecodeString(tt.hash)
+		if got := base58CheckEncode(tt.version, hash); got != tt.address {
+			t.Errorf("%#x %s: %s, want %s", tt.version, tt.hash, got, tt.address)
+		}
+	}
+}
+
+// TestSegwitEncode runs the valid address vectors of BIP-173 (bech32) and
+// BIP-350 (bech32m)
+func TestSegwitEncode(t *testing.T) {
+	tests := []struct {
+		hrp     string
+		script  string
+		address string
+	}{
+		{"bc", "0014751e76e8199196d454941c45d1b3a323f1433bd6", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"},
+		{"tb", "00201863143c14c5166804bd19203356da136c985678cd4d27a1b8c6329604903262", "tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7"},
+		{"tb", "0020000000c4a5cad46221b2a187905e5266362b99d5e91c6ce24d165dab93e86433", "tb1qqqqqp399et2xygdj5xreqhjjvcmzhxw4aywxecjdzew6hylgvsesrxh6hy"},
+		{"bc", "5128751e76e8199196d454941c45d1b3a323f1433bd6751e76e8199196d454941c45d1b3a323f1433bd6", "bc1pw508d6qejxtdg4y5r3zarvary0c5xw7kw508d6qejxtdg4y5r3zarvary0c5xw7kt5nd6y"},
+		{"bc", "6002751e", "bc1sw50qgdz25j"},
+		{"bc", "5210751e76e8199196d454941c45d1b3a323", "bc1zw508d6qejxtdg4y5r3zarvaryvaxxpcs"},
+		{"tb", "5120000000c4a5cad46221b2a187905e5266362b99d5e91c6ce24d165dab93e86433", "tb1pqqqqp399et2xygdj5xreqhjjvcmzhxw4aywxecjdzew6hylgvsesf3hn0c"},
+		{"bc", "512079be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798", "bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0"},
+	}
+
+	for _, tt := range tests {
+		script, _ := hex.DecodeString(tt.script)
+		version := byte(0)
+		if script[0] != opFalse {
+			version = script[0] - opOne + 1
+		}
+		if got := segwitEncode(tt.hrp, version, script[2:]); got != tt.address {
+			t.Errorf("%s: %s, want %s", tt.script, got, tt.address)
+		}
+	}
+}
+
+func TestClassifyScript(t *testing.T) {
+	tests := []struct {
+		script     string
+		scriptType string
+	}{
+		{"76a91462e907b15cbf27d5425399ebf6f0fb50ebb88f1888ac", scriptPubKeyHash},
+		{"a914b472a266d0bd89c13706a4132ccfb16f7c3b9fcb87", scriptScriptHash},
+		{"0014751e76e8199196d454941c45d1b3a323f1433bd6", scriptWitnessV0PK},
+		{"00201863143c14c5166804bd19203356da136c985678cd4d27a1b8c6329604903262", scriptWitnessV0SH},
+		{"512079be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798", scriptTaproot},
+		{"6002751e", scriptWitnessUnk},
+		{"4104678afdb0fe5548271967f1a67130b7105cd6a828e03909a67962e0ea1f61deb649f6bc3f4cef38c4f35504e51ec112de5c384df7ba0b8d578a4c702b6bf11d5fac", scriptPubKey},
+		{"6a0b68656c6c6f20776f726c64", scriptNullData},
+		{"5121031b84c5567b126440995d3ed5aaba0565d71e1834604819ff9c17f5e9d5dd078f51ae", scriptMultisig},
+		{"", scriptNonStandard},
+		{"51", scriptNonStandard},
+	}
+
+	for _, tt := range tests {
+		script, _ := hex.DecodeString(tt.script)
+		if got := classifyScript(script); got != tt.scriptType {
+			t.Errorf("%q: %s, want %s", tt.script, got, tt.scriptType)
+		}
+	}
+}
+
+func TestBtcToSatoshis(t *testing.T) {
+	tests := []struct {
+		value    string
+		satoshis uint64
+	}{
+		{"0", 0},
+		{"1", 100000000},
+		{"0.00000001", 1},
+		{"0.1", 10000000},
+		{"1.23456789", 123456789},
+		// Floats would round these off
+		{"0.29", 29000000},
+		{"20999999.97690000", 2099999997690000},
+		// bitcoind switches to exponents for tiny amounts
+		{"1e-08", 1},
+		{"5.46E-6", 546},
+	}
+
+	for _, tt := range tests {
+		if got := btcToSatoshis(json.Number(tt.value)); got != tt.satoshis {
+			t.Errorf("%s: %d, want %d", tt.value, got, tt.satoshis)
+		}
+	}
+}
+
+func TestBitcoinFee(t *testing.T) {
+	const p2pkh = "76a91462e907b15cbf27d5425399ebf6f0fb50ebb88f1888ac"
+	vout := func(n uint32, value string) btcVout {
+		return btcVout{Value: json.Number(value), N: n, ScriptPubKey: btcScriptPubKey{Hex: p2pkh}}
+	}
+	prevOut := func(value string) *btcPrevOut {
+		return &btcPrevOut{Value: json.Number(value), ScriptPubKey: btcScriptPubKey{Hex: p2pkh}}
+	}
+	fee := func(f uint64) *uint64 { return &f }
+
+	tests := []struct {
+		name string
+		vin  []btcVin
+		vout []btcVout
+		fee  *uint64
+	}{
+		{
+			name: "inputs with prevout",
+			vin:  []btcVin{{TxID: "a", PrevOut: prevOut("0.6")}, {TxID: "b", PrevOut: prevOut("0.4")}},
+			vout: []btcVout{vout(0, "0.7"), vout(1, "0.2999")},
+			fee:  fee(10000),
+		},
+		{
+			name: "input spending an output of the same block",
+			vin:  []btcVin{{TxID: "block", Vout: 1}},
+			vout: []btcVout{vout(0, "0.49999")},
+			fee:  fee(1000),
+		},
+		{
+			name: "input spending a cached output",
+			vin:  []btcVin{{TxID: "cached"}},
+			vout: []btcVout{vout(0, "1.99")},
+			fee:  fee(1000000),
+		},
+		{
+			name: "unresolved input",
+			vin:  []btcVin{{TxID: "a", PrevOut: prevOut("1")}, {TxID: "block", Vout: 7}},
+			vout: []btcVout{vout(0, "0.5")},
+		},
+		{
+			name: "coinbase",
+			vin:  []btcVin{{Coinbase: "03a0bb0d"}},
+			vout: []btcVout{vout(0, "6.25")},
+		},
+		{
+			name: "outputs above the inputs",
+			vin:  []btcVin{{TxID: "a", PrevOut: prevOut("1")}},
+			vout: []btcVout{vout(0, "1.00000001")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &BitcoinApp{
+				Options: BitcoinOptions{Chain: BitcoinProfiles["bitcoin"]},
+				outputs: &btcOutputCache{txs: map[string][]types.TxOutput{
+					"cached": {{Index: 0, Value: 200000000}},
+				}},
+			}
+			blockOutputs := map[string][]types.TxOutput{
+				"block": {{Index: 0, Value: 10}, {Index: 1, Value: 50000000}},
+			}
+
+			tx := app.unifyTransaction(btcTx{TxID: "tx", Vin: tt.vin, Vout: tt.vout}, blockOutputs)
+			got := tx.BitcoinTransaction.Fee
+			switch {
+			case tt.fee == nil && got != nil:
+				t.Errorf("fee %d, want none", *got)
+			case tt.fee != nil && got == nil:
+				t.Errorf("no fee, want %d", *tt.fee)
+			case tt.fee != nil && *got != *tt.fee:
+				t.Errorf("fee %d, want %d", *got, *tt.fee)
+			}
+			if len(tx.Actions) != len(tt.vout) || tx.Actions[0].To != "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa" {
+				t.Errorf("actions %+v", tx.Actions)
+			}
+		})
+	}
+}
+
+// TestCashAddrEncode runs vectors of the CashAddr spec for 160 bit hashes
+func TestCashAddrEncode(t *testing.T) {
+	tests := []struct {
+		prefix   string
+		addrType byte
+		hash     string
+		address  string
+	}{
+		{"bitcoincash", cashAddrPubKeyHash, "f5bf48b397dae70be82b3cca4793f8eb2b6cdac9", "bitcoincash:qr6m7j9njldwwzlg9v7v53unlr4jkmx6eylep8ekg2"},
+		{"bchtest", cashAddrScriptHash, "f5bf48b397dae70be82b3cca4793f8eb2b6cdac9", "bchtest:pr6m7j9njldwwzlg9v7v53unlr4jkmx6eyvwc0uz5t"},
+		// 1BpEi6DfDAUFd7GtittLSdBeYJvcoaVggu in legacy format
+		{"bitcoincash", cashAddrPubKeyHash, "76a04053bda0a88bda5177b86a15c3b29f559873", "bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a"},
+	}
+
+	for _, tt := range tests {
+		hash, _ := hex.DecodeString(tt.hash)
+		if got := cashAddrEncode(tt.prefix, tt.addrType, hash); got != tt.address {
+			t.Errorf("%s %d %s: %s, want %s", tt.prefix, tt.addrType, tt.hash, got, tt.address)
+		}
+	}
+}
+
+func TestBitcoinProfileAddress(t *testing.T) {
+	const (
+		p2pkh  = "76a91462e907b15cbf27d5425399ebf6f0fb50ebb88f1888ac"
+		p2wpkh = "0014751e76e8199196d454941c45d1b3a323f1433bd6"
+	)
+	tests := []struct {
+		profile string
+		script  string
+		address string
+	}{
+		{"bitcoin", p2pkh, "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"},
+		{"bitcoin", p2wpkh, "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"},
+		{"testnet3", p2pkh, "mpXwg4jMtRhuSpVq4xS3HFHmCmWp9NyGKt"},
+		{"litecoin", p2pkh, "LUEweDxDA4WhvWiNXXSxjM9CYzHPJv4QQF"},
+		{"dogecoin", p2pkh, "DEA5vGb2NpAwCiCp5yTE16F3DueQUVivQp"},
+		// Chains without segwit have no address for witness programs
+		{"dogecoin", p2wpkh, ""},
+		{"bitcoincash", "76a914f5bf48b397dae70be82b3cca4793f8eb2b6cdac988ac", "bitcoincash:qr6m7j9njldwwzlg9v7v53unlr4jkmx6eylep8ekg2"},
+		{"bitcoincash-testnet", "a914f5bf48b397dae70be82b3cca4793f8eb2b6cdac987", "bchtest:pr6m7j9njldwwzlg9v7v53unlr4jkmx6eyvwc0uz5t"},
+		{"bitcoin", "6a0b68656c6c6f20776f726c64", ""},
+	}
+
+	for _, tt := range tests {
+		script, _ := hex.DecodeString(tt.script)
+		if got := BitcoinProfiles[tt.profile].address(script); got != tt.address {
+			t.Errorf("%s %s: %q, want %q", tt.profile, tt.script, got, tt.address)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package blockchains
+
+import (
+	"fmt"
+
+	"github.com/auser/bitping/config"
+)
+
+// BitcoinProfile describes a bitcoin-family chain served by a bitcoind
+// compatible node, so the Bitcoin watcher can label its blocks and encode
+// its addresses
+type BitcoinProfile struct {
+	// Name is used as the network label on blocks
+	Name string
+	// Genesis is the hash of block 0, used to recognise the chain a node
+	// follows
+	Genesis string
+	// PubKeyHashAddrID and ScriptHashAddrID are the base58 address version
+	// bytes
+	PubKeyHashAddrID byte
+	ScriptHashAddrID byte
+	// Bech32HRP is the segwit address prefix. Chains without segwit leave it
+	// empty
+	Bech32HRP string
+	// CashAddrPrefix is set on Bitcoin Cash chains, whose addresses use the
+	// cashaddr format instead of base58
+	CashAddrPrefix string
+}
+
+// Genesis block hashes of the chains bitping knows about
+const (
+	bitcoinMainGenesis     = "000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f"
+	bitcoinTestnetGenesis  = "000000000933ea01ad0ee984209779baaec3ced90fa3f408719526f8d77f4943"
+	bitcoinSignetGenesis   = "00000008819873e925422c1ff0f99f7cc9bbb232af63a077a480a3633bee1ef6"
+	bitcoinRegtestGenesis  = "0f9188f13cb7b2c71f2a335e3a4fc328bf5beb436012afca590b1a11466e2206"
+	litecoinMainGenesis    = "12a765e31ffd4059bada1e25190f6e98c99d9714d334efa41a195a7e7e04bfe2"
+	litecoinTestnetGenesis = "4966625a4b2851d9fdee139e56211a0d88575f59ed816ff5e6a63deb4e3e29a0"
+	dogecoinMainGenesis    = "1a91e3dace36e2be3bf030a65679fe821aa1d6ef92e7c9902eb318182c355691"
+	dogecoinTestnetGenesis = "bb0a78264637406b6360aad926284d544d7049f45189db5664f3c4d07350559e"
+)
+
+// BitcoinProfiles are the bitcoin-family chains bitping knows about out of
+// the box
+var BitcoinProfiles = map[string]BitcoinProfile{
+	"bitcoin":  {Name: "bitcoin", Genesis: bitcoinMainGenesis, PubKeyHashAddrID: 0x00, ScriptHashAddrID: 0x05, Bech32HRP: "bc"},
+	"testnet3": {Name: "testnet3", Genesis: bitcoinTestnetGenesis, PubKeyHashAddrID: 0x6f, ScriptHashAddrID: 0xc4, Bech32HRP: "tb"},
+	"signet":   {Name: "signet", Genesis: bitcoinSignetGenesis, PubKeyHashAddrID: 0x6f, ScriptHashAddrID: 0xc4, Bech32HRP: "tb"},
+	"regtest":  {Name: "regtest", Genesis: bitcoinRegtestGenesis, PubKeyHashAddrID: 0x6f, ScriptHashAddrID: 0xc4, Bech32HRP: "bcrt"},
+
+	"litecoin":         {Name: "litecoin", Genesis: litecoinMainGenesis, PubKeyHashAddrID: 0x30, ScriptHashAddrID: 0x32, Bech32HRP: "ltc"},
+	"litecoin-testnet": {Name: "litecoin-testnet", Genesis: litecoinTestnetGenesis, PubKeyHashAddrID: 0x6f, ScriptHashAddrID: 0x3a, Bech32HRP: "tltc"},
+
+	// Bitcoin Cash forked off bitcoin, so it shares its genesis blocks and
+	// has to be picked explicitly
+	"bitcoincash":         {Name: "bitcoincash", Genesis: bitcoinMainGenesis, PubKeyHashAddrID: 0x00, ScriptHashAddrID: 0x05, CashAddrPrefix: "bitcoincash"},
+	"bitcoincash-testnet": {Name: "bitcoincash-testnet", Genesis: bitcoinTestnetGenesis, PubKeyHashAddrID: 0x6f, ScriptHashAddrID: 0xc4, CashAddrPrefix: "bchtest"},
+
+	"dogecoin":         {Name: "dogecoin", Genesis: dogecoinMainGenesis, PubKeyHashAddrID: 0x1e, ScriptHashAddrID: 0x16},
+	"dogecoin-testnet": {Name: "dogecoin-testnet", Genesis: dogecoinTestnetGenesis, PubKeyHashAddrID: 0x71, ScriptHashAddrID: 0xc4},
+}
+
+// bitcoinGenesisProfiles maps genesis hashes to the profile picked when the
+// node is left to tell which chain it follows
+var bitcoinGenesisProfiles = map[string]string{
+	bitcoinMainGenesis:     "bitcoin",
+	bitcoinTestnetGenesis:  "testnet3",
+	bitcoinSignetGenesis:   "signet",
+	bitcoinRegtestGenesis:  "regtest",
+	litecoinMainGenesis:    "litecoin",
+	litecoinTestnetGenesis: "litecoin-testnet",
+	dogecoinMainGenesis:    "dogecoin",
+	dogecoinTestnetGenesis: "dogecoin-testnet",
+}
+
+// bitcoinProfileByGenesis returns the known profile of the chain starting
+// at genesis
+func bitcoinProfileByGenesis(genesis string) (BitcoinProfile, bool) {
+	name, ok := bitcoinGenesisProfiles[genesis]
+	if !ok {
+		return BitcoinProfile{}, false
+	}
+	return BitcoinProfiles[name], true
+}
+
+// bitcoinProfileFromOptions looks up the profile named by the profile
+// option, or the one starting at genesis when there's no profile option, and
+// applies any address encoding overrides on top of it
+func bitcoinProfileFromOptions(opts config.Options, genesis string) (BitcoinProfile, error) {
+	custom := opts.Has("pubkeyhash_id") && opts.Has("scripthash_id")
+
+	var (
+		profile BitcoinProfile
+		ok      bool
+	)
+	if name := opts.String("profile", ""); name != "" {
+		if profile, ok = BitcoinProfiles[name]; !ok {
+			if !custom {
+				return BitcoinProfile{}, fmt.Errorf(
+					"unknown bitcoin profile %q, set pubkeyhash_id and scripthash_id for custom chains", name)
+			}
+			profile = BitcoinProfile{Name: name, Genesis: genesis}
+		}
+	} else if profile, ok = bitcoinProfileByGenesis(genesis); !ok {
+		return BitcoinProfile{}, fmt.Errorf(
+			"unknown chain with genesis %s, set a bitcoin profile", genesis)
+	}
+
+	if profile.Genesis != genesis {
+		return BitcoinProfile{}, fmt.Errorf(
+			"the node isn't following %s, its genesis is %s", profile.Name, genesis)
+	}
+
+	profile.PubKeyHashAddrID = byte(opts.Int64("pubkeyhash_id", int64(profile.PubKeyHashAddrID)))
+	profile.ScriptHashAddrID = byte(opts.Int64("scripthash_id", int64(profile.ScriptHashAddrID)))
+	profile.Bech32HRP = opts.String("bech32_hrp", profile.Bech32HRP)
+	profile.CashAddrPrefix = opts.String("cashaddr_prefix", profile.CashAddrPrefix)
+
+	return profile, nil
+}
@@ -0,0 +1,88 @@
+package blockchains
+
+import (
+	"fmt"
+
+	"github.com/auser/bitping/config"
+	"github.com/auser/bitping/iface"
+	"github.com/codegangsta/cli"
+)
+
+// Chains lists the chains New knows how to create
+var Chains = []string{"ethereum", "eos", "bitcoin"}
+
+// New returns an unconfigured watcher for the given chain. The instance
+// name tells apart several watchers of the same chain and is empty for the
+// default instance
+func New(chain, instance string) (iface.Watcher, error) {
+	switch chain {
+	case "ethereum", "evm":
+		return NewEthereumWatcher(instance), nil
+	case "eos":
+		return NewEosWatcher(instance), nil
+	case "bitcoin", "btc":
+		return NewBitcoinWatcher(instance), nil
+	}
+	return nil, fmt.Errorf("unknown chain: %s", chain)
+}
+
+// AddCLIFlags adds the cli flags of every known watcher
+func AddCLIFlags(fs []cli.Flag) []cli.Flag {
+	fs = EthereumApp{}.AddCLIFlags(fs)
+	fs = EosApp{}.AddCLIFlags(fs)
+	fs = BitcoinApp{}.AddCLIFlags(fs)
+	return fs
+}
+
+// FromConfig creates and configures the watcher declared in a config file
+// section. The cli flags only override the default instance of each chain
+func FromConfig(wc config.WatcherConfig, c *cli.Context) (iface.Watcher, error) {
+	instance := ""
+	if !wc.IsDefault() {
+		instance = wc.InstanceName()
+		c = nil
+	}
+
+	w, err := New(wc.Chain, instance)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := w.ConfigureFromOptions(wc.Options, c); err != nil {
+		return nil, fmt.Errorf("%s: %v", wc.InstanceName(), err)
+	}
+
+	return w, nil
+}
+
+// Watchers returns the configured watchers for the config file and cli
+// flags. Watchers declared in the config file are configured first, then any
+// chain that only has cli flags gets a default watcher
+func Watchers(cfg *config.Config, c *cli.Context) ([]iface.Watcher, error) {
+	var watchers []iface.Watcher
+	declared := make(map[string]bool)
+
+	for _, wc := range cfg.Watchers {
+		w, err := FromConfig(wc, c)
+		if err != nil {
+			return nil, err
+		}
+		watchers = append(watchers, w)
+		if wc.IsDefault() {
+			declared[wc.Chain] = true
+		}
+	}
+
+	for _, chain := range Chains {
+		w, _ := New(chain, "")
+		if declared[chain] || !w.CanConfigure(c) {
+			continue
+		}
+		if err := w.Configure(c); err != nil {
+			return nil, err
+		}
+		watchers = append(watchers, w)
+	}
+
+	return watchers, nil
+}
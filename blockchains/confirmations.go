@@ -0,0 +1,104 @@
+package blockchains
+
+import (
+	"sort"
+
+	types "github.com/auser/bitping/types"
+)
+
+// confirmationBuffer holds on to blocks until they have enough descendants
+// to be considered safe from reorgs
+type confirmationBuffer struct {
+	depth  int64
+	blocks map[int64]types.Block
+	// fetch looks up the canonical block at a height. It's used to fill in
+	// blocks replaced by a reorg or skipped by the head subscription
+	fetch func(num int64) (types.Block, error)
+}
+
+func newConfirmationBuffer(
+	depth uint64,
+	fetch func(num int64) (types.Block, error),
+) *confirmationBuffer {
+	return &confirmationBuffer{
+		depth:  int64(depth),
+		blocks: make(map[int64]types.Block),
+		fetch:  fetch,
+	}
+}
+
+// Add buffers a new head block and returns the buffered blocks that now have
+// enough descendants, lowest first. Buffered blocks the new head doesn't
+// descend from are replaced by the canonical ones
+func (b *confirmationBuffer) Add(head types.Block) ([]types.Block, error) {
+	// Walk back from the head until we reach a block we already have (or run
+	// out of buffered blocks), picking up whatever a reorg replaced
+	chain := []types.Block{head}
+	for {
+		tip := chain[len(chain)-1]
+		parent, ok := b.blocks[tip.Number-1]
+		if !ok || parent.Hash == tip.ParentHash {
+			break
+		}
+		canonical, err := b.fetch(tip.Number - 1)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, canonical)
+	}
+
+	lowest := chain[len(chain)-1].Number
+	for num := range b.blocks {
+		if num >= lowest {
+			delete(b.blocks, num)
+		}
+	}
+	for _, block := range chain {
+		b.blocks[block.Number] = block
+	}
+
+	// Fill in any heights the subscription skipped
+	if highest, ok := b.highestBelow(lowest); ok {
+		for num := highest + 1; num < lowest; num++ {
+			block, err := b.fetch(num)
+			if err != nil {
+				return nil, err
+			}
+			b.blocks[num] = block
+		}
+	}
+
+	var confirmed []types.Block
+	for _, num := range b.sortedNumbers() {
+		if num > head.Number-b.depth {
+			break
+		}
+		confirmed = append(confirmed, b.blocks[num])
+		delete(b.blocks, num)
+	}
+
+	return confirmed, nil
+}
+
+// highestBelow returns the highest buffered height below num
+func (b *confirmationBuffer) highestBelow(num int64) (int64, bool) {
+	var (
+		highest int64
+		found   bool
+	)
+	for n := range b.blocks {
+		if n < num && (!found || n > highest) {
+			highest, found = n, true
+		}
+	}
+	return highest, found
+}
+
+func (b *confirmationBuffer) sortedNumbers() []int64 {
+	nums := make([]int64, 0, len(b.blocks))
+	for num := range b.blocks {
+		nums = append(nums, num)
+	}
+	sort.Slice(nums, func(i, j int) bool { return nums[i] < nums[j] })
+	return nums
+}
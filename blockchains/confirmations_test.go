@@ -0,0 +1,176 @@
+package blockchains
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	types "github.com/auser/bitping/types"
+)
+
+// forkBlock returns block n of fork, a child of block n-1 of parent. Hashes
+// read like a3 for block 3 of fork a
+func forkBlock(fork string, n int64, parent string) types.Block {
+	return types.Block{
+		Number:     n,
+		Hash:       fmt.Sprintf("%s%d", fork, n),
+		ParentHash: fmt.Sprintf("%s%d", parent, n-1),
+	}
+}
+
+// chain parses heads like "a3" or "b4<a3", the parent of a block being of
+// the same fork unless given after <
+func chain(heads string) []types.Block {
+	var out []types.Block
+	for _, h := range strings.Fields(heads) {
+		parent := ""
+		if i := strings.Index(h, "<"); i >= 0 {
+			h, parent = h[:i], h[i+1:i+2]
+		}
+		var n int64
+		fmt.Sscanf(h[1:], "%d", &n)
+		if parent == "" {
+			parent = h[:1]
+		}
+		out = append(out, forkBlock(h[:1], n, parent))
+	}
+	return out
+}
+
+func TestConfirmationBufferAdd(t *testing.T) {
+	tests := []struct {
+		name  string
+		depth uint64
+		// canonical is the chain fetch answers with once the heads are in
+		canonical string
+		heads     string
+		// confirmed are the blocks returned by the Adds, in order, and
+		// fetched the heights looked up
+		confirmed string
+		fetched   []int64
+	}{
+		{
+			name:      "linear chain",
+			depth:     2,
+			canonical: "a1 a2 a3 a4 a5",
+			heads:     "a1 a2 a3 a4 a5",
+			confirmed: "a1 a2 a3",
+		},
+		{
+			name:      "no confirmations",
+			depth:     0,
+			canonical: "a1 a2 a3",
+			heads:     "a1 a2 a3",
+			confirmed: "a1 a2 a3",
+		},
+		{
+			name:      "one block reorg at the same height",
+			depth:     2,
+			canonical: "a1 a2 b3 b4 b5",
+			heads:     "a1 a2 a3 b3<a b4 b5",
+			confirmed: "a1 a2 b3",
+		},
+		{
+			name:      "one block reorg under a new head",
+			depth:     2,
+			canonical: "a1 a2 b3<a b4 b5",
+			heads:     "a1 a2 a3 b4 b5",
+			confirmed: "a1 a2 b3",
+			fetched:   []int64{3},
+		},
+		{
+			name:      "reorg deeper than one block",
+			depth:     3,
+			canonical: "a1 b2<a b3 b4 b5 b6",
+			heads:     "a1 a2 a3 a4 b5 b6",
+			confirmed: "a1 b2 b3",
+			fetched:   []int64{4, 3, 2},
+		},
+		{
+			name:      "reorg back to an earlier fork",
+			depth:     3,
+			canonical: "a1 a2 a3 a4 a5 a6",
+			heads:     "a1 a2 b3<a b4 a5 a6",
+			confirmed: "a1 a2 a3",
+			fetched:   []int64{4, 3},
+		},
+		{
+			name:      "skipped heights",
+			depth:     2,
+			canonical: "a1 a2 a3 a4 a5 a6",
+			heads:     "a1 a2 a5 a6",
+			confirmed: "a1 a2 a3 a4",
+			fetched:   []int64{3, 4},
+		},
+		{
+			name:      "skipped heights before the first head",
+			depth:     1,
+			canonical: "a7 a8 a9",
+			heads:     "a7 a9",
+			confirmed: "a7 a8",
+			fetched:   []int64{8},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			canonical := make(map[int64]types.Block)
+			for _, block := range chain(tt.canonical) {
+				canonical[block.Number] = block
+			}
+			var fetched []int64
+			buf := newConfirmationBuffer(tt.depth, func(num int64) (types.Block, error) {
+				fetched = append(fetched, num)
+				block, ok := canonical[num]
+				if !ok {
+					return types.Block{}, fmt.Errorf("no block %d", num)
+				}
+				return block, nil
+			})
+
+			var confirmed []string
+			for _, head := range chain(tt.heads) {
+				blocks, err := buf.Add(head)
+				if err != nil {
+					t.Fatalf("adding %s: %v", head.Hash, err)
+				}
+				for _, block := range blocks {
+					confirmed = append(confirmed, block.Hash)
+				}
+			}
+			if got := strings.Join(confirmed, " "); got != tt.confirmed {
+				t.Errorf("confirmed %q, want %q", got, tt.confirmed)
+			}
+			if !reflect.DeepEqual(fetched, tt.fetched) {
+				t.Errorf("fetched heights %v, want %v", fetched, tt.fetched)
+			}
+		})
+	}
+}
+
+func TestConfirmationBufferFetchError(t *testing.T) {
+	unavailable := errors.New("node unavailable")
+	buf := newConfirmationBuffer(2, func(num int64) (types.Block, error) {
+		return types.Block{}, unavailable
+	})
+
+	for _, head := range chain("a1 a2 a3") {
+		if _, err := buf.Add(head); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The parent of b4 isn't buffered and can't be fetched
+	if _, err := buf.Add(chain("b4")[0]); err != unavailable {
+		t.Fatalf("Add returned %v, want the fetch error", err)
+	}
+	// The buffer is untouched, a3 is still waiting for its descendants
+	blocks, err := buf.Add(chain("a4")[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks) != 1 || blocks[0].Hash != "a2" {
+		t.Fatalf("confirmed %v after the failed add, want a2", blocks)
+	}
+}
@@ -1,10 +1,17 @@
 package blockchains
 
 import (
+	"bytes"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"log"
+	"net/http"
+	"strings"
+	"time"
 
+	"github.com/auser/bitping/config"
 	"github.com/auser/bitping/types"
 	"github.com/codegangsta/cli"
 	"github.com/eoscanada/eos-go"
@@ -15,6 +22,8 @@ import (
 type EosOptions struct {
 	Node           string
 	NetworkVersion int64
+	// Network is the label put on every block, i.e. "eos" or "jungle"
+	Network string
 }
 
 // EosApp holds the EOS Client and configuration of an EOS App
@@ -24,6 +33,20 @@ type EosApp struct {
 	Client  *eos.API
 	Info    *eos.InfoResp
 	Options EosOptions
+	// Instance is the name of this watcher when several EOS watchers run
+	// side by side. It's empty for the default instance
+	Instance string
+
+	// http fetches blocks, eos-go doesn't hand out the response bodies
+	http *http.Client
+	// resume is the first block to fetch when it's below the last
+	// irreversible one
+	resume uint32
+}
+
+// NewEosWatcher creates an unconfigured EosApp named instance
+func NewEosWatcher(instance string) *EosApp {
+	return &EosApp{Instance: instance}
 }
 
 // NewEosClient creates a new EosClient
@@ -40,6 +63,7 @@ func NewEosClient(opts EosOptions) (*EosApp, error) {
 		Client:  api,
 		Info:    info,
 		Options: opts,
+		http:    &http.Client{Timeout: time.Minute},
 	}
 
 	return app, nil
@@ -47,6 +71,9 @@ func NewEosClient(opts EosOptions) (*EosApp, error) {
 
 // Name returns the app name
 func (app EosApp) Name() string {
+	if app.Instance != "" {
+		return fmt.Sprintf("EOS Watcher (%s)", app.Instance)
+	}
 	return "EOS Watcher"
 }
 
@@ -67,6 +94,11 @@ func (app EosApp) AddCLIFlags(fs []cli.Flag) []cli.Flag {
 			Usage: "eos network version",
 			Value: int64(1206),
 		},
+		cli.StringFlag{
+			Name:  "eos-network",
+			Usage: "network label put on eos blocks",
+			Value: "eos",
+		},
 	)
 }
 
@@ -77,25 +109,107 @@ func (app EosApp) CanConfigure(c *cli.Context) bool {
 
 // Configure reads CLI Flag settints and configures app
 func (app *EosApp) Configure(c *cli.Context) error {
-	nodePath := c.String("eos")
-	client := eos.New(nodePath)
+	app.Options = EosOptions{
+		Node:           c.String("eos"),
+		NetworkVersion: c.Int64("eos-version"),
+		Network:        c.String("eos-network"),
+	}
+
+	return app.connect()
+}
+
+// ConfigureFromOptions configures the app from a config file section. The
+// --eos flags override the file values when they are set
+func (app *EosApp) ConfigureFromOptions(opts config.Options, c *cli.Context) error {
+	app.Options = EosOptions{
+		Node:           opts.String("node", ""),
+		NetworkVersion: opts.Int64("version", 1206),
+		Network:        opts.String("network", app.Instance),
+	}
+
+	if c != nil {
+		if c.IsSet("eos-network") {
+			app.Options.Network = c.String("eos-network")
+		}
+		if c.IsSet("eos") {
+			app.Options.Node = c.String("eos")
+		}
+		if c.IsSet("eos-version") {
+			app.Options.NetworkVersion = c.Int64("eos-version")
+		}
+	}
+
+	if app.Options.Node == "" {
+		return fmt.Errorf("eos: no node configured")
+	}
+
+	return app.connect()
+}
+
+// connect creates the api client and fetches the current chain info
+func (app *EosApp) connect() error {
+	if app.Options.Network == "" {
+		app.Options.Network = "eos"
+	}
+
+	client := eos.New(app.Options.Node)
 
 	info, err := client.GetInfo()
 	if err != nil {
-		log.Fatalf("GetInfo Error, %v", err)
+		log.Printf("GetInfo Error, %v", err)
 		return err
 	}
 
 	app.Client = client
 	app.Info = info
-	app.Options = EosOptions{
-		Node:           nodePath,
-		NetworkVersion: c.Int64("eos-version"),
-	}
+	app.http = &http.Client{Timeout: time.Minute}
 
 	return nil
 }
 
+// getBlock fetches the block at num from the get_block endpoint. It returns
+// the block decoded by eos-go along with the response body, which keeps the
+// fields eos-go doesn't know about
+func (app *EosApp) getBlock(num uint32) (*eos.BlockResp, []byte, error) {
+	body, err := json.Marshal(map[string]interface{}{"block_num_or_id": num})
+	if err != nil {
+		return nil, nil, err
+	}
+	endpoint := strings.TrimSuffix(app.Options.Node, "/") + "/v1/chain/get_block"
+	resp, err := app.http.Post(endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		msg := raw
+		if len(msg) > 512 {
+			msg = msg[:512]
+		}
+		return nil, nil, fmt.Errorf("get_block %d: %s: %s", num, resp.Status, bytes.TrimSpace(msg))
+	}
+
+	var block eos.BlockResp
+	if err := json.Unmarshal(raw, &block); err != nil {
+		return nil, nil, fmt.Errorf("get_block %d: %v", num, err)
+	}
+	return &block, raw, nil
+}
+
+// ResumeFrom starts the watcher at the height of its network in heights
+// rather than at the last irreversible block
+func (app *EosApp) ResumeFrom(heights map[string]int64) {
+	if h, ok := heights[app.Options.Network]; ok && h > 0 {
+		log.Printf("EOS Resuming from block %v", h)
+		app.resume = uint32(h)
+	}
+}
+
 // Watch starts running the block watcher
 func (app *EosApp) Watch(
 	blockCh chan types.Block,
@@ -112,9 +226,15 @@ func (app *EosApp) Watch(
 		}
 		app.Info = latestInfo
 
-		for blockNum := info.LastIrreversibleBlockNum; blockNum < latestInfo.LastIrreversibleBlockNum; blockNum++ {
+		start := info.LastIrreversibleBlockNum
+		if app.resume > 0 && app.resume < start {
+			start = app.resume
+		}
+		app.resume = 0
+
+		for blockNum := start; blockNum < latestInfo.LastIrreversibleBlockNum; blockNum++ {
 			log.Printf("EOS Getting Block: %v", blockNum)
-			block, err := app.Client.GetBlockByNum(blockNum) //11819163
+			block, raw, err := app.getBlock(blockNum) //11819163
 			if err != nil {
 				log.Fatalf("GetBlockByNum Error: %v", err)
 				errCh <- err
@@ -308,7 +428,7 @@ func (app *EosApp) Watch(
 			blockObj := types.Block{
 				Hash:       hex.EncodeToString(block.ID),
 				HeaderHash: hex.EncodeToString(block.ID),
-				Network:    "eos",
+				Network:    app.Options.Network,
 				Number:     int64(block.BlockNum),
 				ParentHash: hex.EncodeToString(block.Previous),
 				Time:       block.Timestamp.Unix(),
@@ -324,6 +444,9 @@ func (app *EosApp) Watch(
 				},
 
 				Transactions: transactions,
+
+				// The get_block response as the node sent it
+				Raw: &types.RawBlock{Format: types.RawFormatJSON, Data: raw},
 			}
 
 			blockCh <- blockObj
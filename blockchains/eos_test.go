@@ -0,0 +1,67 @@
+package blockchains
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// getBlockResponse has a field eos-go doesn't decode and the node's own
+// formatting, both lost when the decoded block is encoded again
+const getBlockResponse = `{
+  "timestamp": "2018-10-09T22:11:17.500",
+  "producer": "eosnationftw",
+  "confirmed": 0,
+  "previous": "00b4599a4b9d4da7e2b3f1b8c4e61ae2a3cd5efa1c0a5ad1d0fe3ed8a3e1e1e1",
+  "transaction_mroot": "0000000000000000000000000000000000000000000000000000000000000000",
+  "action_mroot": "4e1e4ab4c25e5b7e5f4e8f3e2d8e4b6a7f1a2b3c4d5e6f708192a3b4c5d6e7f8",
+  "schedule_version": 402,
+  "new_producers": null,
+  "header_extensions": [],
+  "producer_signature": "SIG_K1_KZ3ptku7orAgcyMzd9FKW4jPC9PvjW9BGadFoyxdJFWM44VZdjW28DzPHAjHs8WMCMnAYcTZLyXYbU9ZTAbLtKeWGBAbqB",
+  "transactions": [],
+  "block_extensions": [],
+  "id": "00b4599b5b4e3d3c9d7f6a2c1e0f8d7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2918",
+  "block_num": 11819163,
+  "ref_block_prefix": 3341524892
+}`
+
+func TestEosGetBlock(t *testing.T) {
+	var req map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/v1/chain/get_block" {
+			http.NotFound(w, r)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(body, &req)
+		if req["block_num_or_id"] == float64(404) {
+			http.Error(w, `{"code": 500, "message": "Internal Service Error", "error": {"name": "unknown_block_exception"}}`, http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(getBlockResponse))
+	}))
+	defer srv.Close()
+
+	app := &EosApp{Options: EosOptions{Node: srv.URL + "/"}, http: &http.Client{Timeout: time.Second}}
+	block, raw, err := app.getBlock(11819163)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req["block_num_or_id"] != float64(11819163) {
+		t.Errorf("requested %v, want block 11819163", req)
+	}
+	if block.BlockNum != 11819163 || block.RefBlockPrefix != 3341524892 {
+		t.Errorf("decoded block %d, ref block prefix %d", block.BlockNum, block.RefBlockPrefix)
+	}
+	if string(raw) != getBlockResponse {
+		t.Errorf("raw block isn't the response body:\n%s", raw)
+	}
+
+	if _, _, err := app.getBlock(404); err == nil {
+		t.Error("expected the node's error")
+	}
+}
@@ -8,13 +8,21 @@ import (
 	"strings"
 	"time"
 
+	"github.com/auser/bitping/config"
 	types "github.com/auser/bitping/types"
 	"github.com/codegangsta/cli"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
 	backoff "github.com/jpillora/backoff"
 )
 
+// rpcMethodNotFound is the JSON-RPC error code of unsupported methods
+const rpcMethodNotFound = -32601
+
 // Sometimes it takes a minute for the blockchain to catch up
 // so we need to provide a small delay before we look up the block
 var blockLookupDelay int = 1000
@@ -25,6 +33,36 @@ var b = &backoff.Backoff{
 // EthereumOptions store the EthereumApp options
 type EthereumOptions struct {
 	Node string
+	// Network is the label put on every block, i.e. "ethereum" or "ropsten"
+	Network string
+	// Chain describes the EVM chain the node follows. When it's left empty
+	// it's looked up from the network id the node reports
+	Chain ChainProfile
+	// Confirmations is how many descendants a block needs before it's
+	// emitted. Zero emits blocks as soon as their head arrives
+	Confirmations uint64
+	// EmitPending also emits every block as pending as soon as its head
+	// arrives when Confirmations is set
+	EmitPending bool
+	// PendingTransactions turns on watching the node's transaction pool
+	PendingTransactions bool
+	// PendingTTL is how long a pending transaction is tracked before the
+	// node is asked whether it was dropped
+	PendingTTL time.Duration
+}
+
+// ethereumFlags maps the cli flags to the config file options they override
+var ethereumFlags = map[string]string{
+	"eth":          "node",
+	"eth-network":  "network",
+	"eth-profile":  "profile",
+	"eth-chain-id": "chain_id",
+
+	"eth-confirmations": "confirmations",
+	"eth-emit-pending":  "emit_pending",
+
+	"eth-pending":     "pending_transactions",
+	"eth-pending-ttl": "pending_ttl",
 }
 
 // EthereumApp holds the EOS Client and configuration of an EOS App
@@ -32,42 +70,89 @@ type EthereumOptions struct {
 // representation of both the original block as well as a unified block
 type EthereumApp struct {
 	Client    *ethclient.Client
+	RPC       *rpc.Client
 	Options   EthereumOptions
 	NetworkId big.Int
+	// Instance is the name of this watcher when several Ethereum watchers
+	// run side by side. It's empty for the default instance
+	Instance string
+
+	signer        types.GethSigner
+	confirmations *confirmationBuffer
+	pending       *pendingPool
+	// resume is the first block to catch up on before the next head
+	resume int64
+}
+
+// NewEthereumWatcher creates an unconfigured EthereumApp named instance
+func NewEthereumWatcher(instance string) *EthereumApp {
+	return &EthereumApp{Instance: instance}
 }
 
 // NewEthClient creates a new EthClient
 func NewEthClient(opts EthereumOptions) (*EthereumApp, error) {
 	nodePath := opts.Node
-	client, err := ethclient.Dial(nodePath)
+	rpcClient, err := rpc.Dial(nodePath)
 	if err != nil {
 		return nil, err
 	}
 
 	app := &EthereumApp{
-		Client:  client,
+		Client:  ethclient.NewClient(rpcClient),
+		RPC:     rpcClient,
 		Options: opts,
 	}
-
-	networkId := app.GetNetwork()
-	fmt.Printf("Network id: %v\n", networkId)
-	app.NetworkId = *networkId
+	app.setNetwork()
 
 	return app, nil
 }
 
 // Name returns the app name
 func (app EthereumApp) Name() string {
+	if app.Instance != "" {
+		return fmt.Sprintf("Ethereum Watcher (%s)", app.Instance)
+	}
 	return "Ethereum Watcher"
 }
 
 // AddCLIFlags configures the CLI Settings
 func (app EthereumApp) AddCLIFlags(fs []cli.Flag) []cli.Flag {
-	return append(fs, cli.StringFlag{
-		Name:   "eth",
-		Usage:  "ethereum address",
-		EnvVar: "ETH_PATH",
-	})
+	return append(fs,
+		cli.StringFlag{
+			Name:   "eth",
+			Usage:  "ethereum address",
+			EnvVar: "ETH_PATH",
+		},
+		cli.StringFlag{
+			Name:  "eth-network",
+			Usage: "network label put on ethereum blocks, defaults to the chain profile name",
+		},
+		cli.StringFlag{
+			Name:  "eth-profile",
+			Usage: "evm chain profile, i.e. ethereum, rinkeby, xdai or dev",
+		},
+		cli.Int64Flag{
+			Name:  "eth-chain-id",
+			Usage: "chain id, defaults to the profile's or the node's network id",
+		},
+		cli.Int64Flag{
+			Name:  "eth-confirmations",
+			Usage: "only emit ethereum blocks once they have this many descendants",
+		},
+		cli.BoolFlag{
+			Name:  "eth-emit-pending",
+			Usage: "with --eth-confirmations, also emit blocks as pending when they arrive",
+		},
+		cli.BoolFlag{
+			Name:  "eth-pending",
+			Usage: "watch pending ethereum transactions",
+		},
+		cli.DurationFlag{
+			Name:  "eth-pending-ttl",
+			Usage: "how long to track a pending transaction before checking if it was dropped",
+			Value: time.Hour,
+		},
+	)
 }
 
 // CanConfigure determines if enough CLI Flags are set to configure the app
@@ -77,24 +162,83 @@ func (app EthereumApp) CanConfigure(c *cli.Context) bool {
 
 // Configure reads CLI Flag settints and configures app
 func (app *EthereumApp) Configure(c *cli.Context) error {
-	nodePath := c.String("eth")
-	client, err := ethclient.Dial(nodePath)
+	return app.ConfigureFromOptions(config.Options{}, c)
+}
+
+// ConfigureFromOptions configures the app from a config file section. The
+// --eth flags override the file values when they are set
+func (app *EthereumApp) ConfigureFromOptions(opts config.Options, c *cli.Context) error {
+	opts = opts.WithFlags(c, ethereumFlags)
+
+	chain, err := chainProfileFromOptions(opts)
 	if err != nil {
 		return err
 	}
 
-	app.Client = client
 	app.Options = EthereumOptions{
-		Node: nodePath,
+		Node:          opts.String("node", ""),
+		Network:       opts.String("network", app.Instance),
+		Chain:         chain,
+		Confirmations: uint64(opts.Int64("confirmations", 0)),
+		EmitPending:   opts.Bool("emit_pending", false),
+
+		PendingTransactions: opts.Bool("pending_transactions", false),
+		PendingTTL:          opts.Duration("pending_ttl", time.Hour),
+	}
+
+	if app.Options.Node == "" {
+		return fmt.Errorf("ethereum: no node configured")
+	}
+
+	if app.Options.PendingTransactions {
+		app.pending = newPendingPool(app.Options.PendingTTL)
+	}
+
+	return app.connect()
+}
+
+// connect dials the configured node and looks up its network id
+func (app *EthereumApp) connect() error {
+	rpcClient, err := rpc.Dial(app.Options.Node)
+	if err != nil {
+		return err
 	}
 
-	networkId := app.GetNetwork()
-	fmt.Printf("Network id: %v\n", networkId)
-	app.NetworkId = *networkId
+	app.RPC = rpcClient
+	app.Client = ethclient.NewClient(rpcClient)
+	app.setNetwork()
 
 	return nil
 }
 
+// setNetwork fills in the chain profile, network label and id for this
+// instance. Anything not configured is derived from the chain id the node
+// reports
+func (app *EthereumApp) setNetwork() {
+	chain := app.Options.Chain
+
+	if chain.ChainID == 0 {
+		chainID := app.GetChainID()
+		fmt.Printf("Chain id: %v\n", chainID)
+		chain.ChainID = chainID.Int64()
+	}
+	if chain.Name == "" {
+		if known, ok := chainProfileByID(chain.ChainID); ok {
+			chain = chain.withDefaults(known)
+		} else {
+			chain.Name = "ethereum"
+		}
+	}
+
+	if app.Options.Network == "" {
+		app.Options.Network = chain.Name
+	}
+
+	app.Options.Chain = chain
+	app.NetworkId = *new(big.Int).SetInt64(chain.ChainID)
+	app.signer = types.NewGethEIP155Signer(big.NewInt(chain.ChainID))
+}
+
 // Watch starts running the block watcher
 func (app *EthereumApp) Watch(
 	blockChan chan types.Block,
@@ -115,12 +259,16 @@ func (app *EthereumApp) Watch(
 			// TODO: Reconnect here
 			// go app.SubscribeToNews(headsCh, errCh)
 		case head := <-headsCh:
+			if err := app.catchUp(head.Number.Int64(), blockChan, errChan); err != nil {
+				errChan <- err
+				continue
+			}
 			block, err := app.GetFromHeader(head)
 			if err != nil {
 				fmt.Printf("Error happened: %s\n", err.Error())
 				errChan <- err
 			} else {
-				blockChan <- block
+				app.emit(block, blockChan, errChan)
 			}
 			// transactions, err := app.makeTransactionsFrom(block)
 			// if err != nil {
@@ -132,6 +280,71 @@ func (app *EthereumApp) Watch(
 	}
 }
 
+// ResumeFrom makes the watcher catch up from the height of its network in
+// heights before the first head
+func (app *EthereumApp) ResumeFrom(heights map[string]int64) {
+	if h, ok := heights[app.Options.Network]; ok && h > 0 {
+		log.Printf("ETH Resuming from block %v", h)
+		app.resume = h
+	}
+}
+
+// catchUp emits the blocks from the resume height up to the one before
+// head, stopping at the first it can't fetch to retry on the next head
+func (app *EthereumApp) catchUp(head int64, blockChan chan types.Block, errChan chan error) error {
+	for app.resume > 0 && app.resume < head {
+		block, err := app.GetByNumber(app.resume)
+		if err != nil {
+			return err
+		}
+		app.emit(block, blockChan, errChan)
+		app.resume++
+	}
+	app.resume = 0
+	return nil
+}
+
+// emit sends a new block on, through the confirmation buffer when blocks
+// wait for confirmations
+func (app *EthereumApp) emit(block types.Block, blockChan chan types.Block, errChan chan error) {
+	if app.pending != nil {
+		app.pending.Mined(block)
+	}
+	if app.Options.Confirmations == 0 {
+		blockChan <- block
+	} else {
+		app.emitConfirmed(block, blockChan, errChan)
+	}
+}
+
+// emitConfirmed buffers block until it has enough confirmations and emits
+// every block that is now deep enough, optionally emitting block as pending
+// straight away
+func (app *EthereumApp) emitConfirmed(
+	block types.Block,
+	blockChan chan types.Block,
+	errChan chan error,
+) {
+	if app.confirmations == nil {
+		app.confirmations = newConfirmationBuffer(app.Options.Confirmations, app.GetByNumber)
+	}
+
+	if app.Options.EmitPending {
+		pending := block
+		pending.Status = types.BlockStatusPending
+		blockChan <- pending
+	}
+
+	confirmed, err := app.confirmations.Add(block)
+	if err != nil {
+		errChan <- err
+	}
+	for _, b := range confirmed {
+		b.Status = types.BlockStatusConfirmed
+		blockChan <- b
+	}
+}
+
 // GetNetwork returns the Ethereum Network Id of th Ethereum node that the
 // EthereumApp is watching
 func (app *EthereumApp) GetNetwork() *big.Int {
@@ -143,6 +356,21 @@ func (app *EthereumApp) GetNetwork() *big.Int {
 	return networkId
 }
 
+// GetChainID returns the EIP-155 chain id of the node. It differs from the
+// network id on some chains, like classic, so the network id is only used
+// by nodes that don't support eth_chainId
+func (app *EthereumApp) GetChainID() *big.Int {
+	var chainID hexutil.Big
+	err := app.RPC.CallContext(context.Background(), &chainID, "eth_chainId")
+	if rpcErr, ok := err.(rpc.Error); ok && rpcErr.ErrorCode() == rpcMethodNotFound {
+		return app.GetNetwork()
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	return (*big.Int)(&chainID)
+}
+
 // SubscribeToNews subscribes to node events
 func (app *EthereumApp) SubscribeToNews(
 	heads chan *types.GethHeader,
@@ -243,54 +471,92 @@ func (app *EthereumApp) GetFromHeader(
 		return types.Block{}, err
 	}
 
-	// difficulty := types.BigNumber(block.Difficulty().String())
-	// totalDifficulty := types.BigNumber(head.Difficulty.String())
-	// cancel()
+	return app.unifyBlock(head, block)
+}
 
-	var transactions []types.Transaction
-	for i, tx := range block.Transactions() {
-		var txFromStr string = "unknown"
-		var txToStr string = "unknown"
-		if msg, err := tx.AsMessage(types.GethHomesteadSigner{}); err != nil {
+// unifyTransaction builds the unified transaction from a geth transaction.
+// The block fields are left empty for the caller to fill in
+func (app *EthereumApp) unifyTransaction(tx *types.GethTransaction) types.Transaction {
+	var txFromStr string = "unknown"
+	var txToStr string = "unknown"
+	if msg, err := tx.AsMessage(app.signer); err == nil {
 
-			txFromStr = msg.From().Hex()
-			if msg.To() != nil {
-				txToStr = msg.To().Hex()
-			}
+		txFromStr = msg.From().Hex()
+		if msg.To() != nil {
+			txToStr = msg.To().Hex()
 		}
+	}
 
-		transaction := types.Transaction{
-			BlockHash:   block.Hash().Hex(),
-			BlockNumber: block.Number().Int64(),
-			Hash:        tx.Hash().String(),
-			Nonce:       int64(tx.Nonce()),
+	return types.Transaction{
+		Hash:  tx.Hash().String(),
+		Nonce: int64(tx.Nonce()),
 
-			EthereumTransaction: &types.EthereumTransaction{
-				GasPrice:         types.NewBigInt(tx.Cost()),
-				Gas:              tx.Gas(),
-				TransactionIndex: int64(i),
-			},
+		EthereumTransaction: &types.EthereumTransaction{
+			GasPrice: types.NewBigInt(tx.Cost()),
+			Gas:      tx.Gas(),
+		},
 
-			Actions: []types.Action{
-				types.Action{
-					EthereumCall: &types.EthereumCall{
-						Input: tx.Data(),
-					},
-					From:  strings.ToLower(txFromStr),
-					To:    strings.ToLower(txToStr),
-					Value: types.NewBigInt(tx.Value()),
-					Data:  tx.Data(),
+		Actions: []types.Action{
+			types.Action{
+				EthereumCall: &types.EthereumCall{
+					Input: tx.Data(),
 				},
+				From:  strings.ToLower(txFromStr),
+				To:    strings.ToLower(txToStr),
+				Value: types.NewBigInt(tx.Value()),
+				Data:  tx.Data(),
 			},
-		}
+		},
+	}
+}
+
+// GetByNumber returns the unified block at the given height
+func (app *EthereumApp) GetByNumber(num int64) (types.Block, error) {
+	log.Printf("ETH Getting Block: %v", num)
+	block, err := app.getByNumWithBackoff(big.NewInt(num))
+	if err != nil {
+		return types.Block{}, err
+	}
+
+	return app.unifyBlock(block.Header(), block)
+}
+
+// unifyBlock builds the unified block from a geth block and its header
+func (app *EthereumApp) unifyBlock(
+	head *types.GethHeader,
+	block *types.GethBlock,
+) (types.Block, error) {
+	// difficulty := types.BigNumber(block.Difficulty().String())
+	// totalDifficulty := types.BigNumber(head.Difficulty.String())
+	// cancel()
+
+	var transactions []types.Transaction
+	for i, tx := range block.Transactions() {
+		transaction := app.unifyTransaction(tx)
+		transaction.BlockHash = block.Hash().Hex()
+		transaction.BlockNumber = block.Number().Int64()
+		transaction.TransactionIndex = int64(i)
 		transactions = append(transactions, transaction)
 	}
 
+	extraData := block.Extra()
+	miner := block.Coinbase().Hex()
+	if app.Options.Chain.Clique {
+		// PoA blocks are sealed by a signer rather than mined, and the
+		// extra-data holds the seal rather than free-form data
+		signer, err := cliqueSigner(block.Header())
+		if err != nil {
+			return types.Block{}, err
+		}
+		miner = signer.Hex()
+		extraData = cliqueVanity(extraData)
+	}
+
 	blockObj := types.Block{
 		Difficulty: types.NewBigInt(block.Difficulty()),
 		Hash:       block.Hash().Hex(),
 		HeaderHash: head.Hash().Hex(),
-		Network:    "ethereum",
+		Network:    app.Options.Network,
 		NetworkID:  app.NetworkId.Int64(),
 		Nonce:      fmt.Sprint(block.Nonce()),
 		Number:     block.Number().Int64(),
@@ -302,9 +568,10 @@ func (app *EthereumApp) GetFromHeader(
 			TotalDifficulty:  types.NewBigInt(block.Difficulty()), // make sense?
 			GasUsed:          block.GasUsed(),
 			GasLimit:         block.GasLimit(),
-			ExtraData:        fmt.Sprint(block.Extra()),
+			ExtraData:        hexutil.Encode(extraData),
 			Sha3Uncles:       head.UncleHash.String(),
-			Miner:            block.Coinbase().Hex(),
+			Miner:            miner,
+			Coinbase:         block.Coinbase().Hex(),
 			TransactionsRoot: head.TxHash.String(),
 			StateRoot:        head.Root.String(),
 		},
@@ -312,5 +579,61 @@ func (app *EthereumApp) GetFromHeader(
 		Transactions: transactions,
 	}
 
+	blockObj.Raw = app.rawBlock(block)
+
 	return blockObj, nil
 }
+
+// rawBlock returns the consensus encoding of block as the node has it, from
+// debug_getRawBlock. Nodes without it get block encoded again, which only
+// matches what the node has when the header hashes to the hash it reports.
+// Blocks whose raw header doesn't hash to the hash of block aren't archived
+func (app *EthereumApp) rawBlock(block *types.GethBlock) *types.RawBlock {
+	ctx := context.Background()
+	number := hexutil.EncodeBig(block.Number())
+
+	var raw hexutil.Bytes
+	err := app.RPC.CallContext(ctx, &raw, "debug_getRawBlock", number)
+	if rpcErr, ok := err.(rpc.Error); ok && rpcErr.ErrorCode() == rpcMethodNotFound {
+		var reported struct {
+			Hash common.Hash `json:"hash"`
+		}
+		if err := app.RPC.CallContext(ctx, &reported, "eth_getBlockByNumber", number, false); err != nil {
+			log.Printf("ETH Block %v not archived: %v", block.Number(), err)
+			return nil
+		}
+		if reported.Hash != block.Hash() {
+			log.Printf("ETH Block %v not archived: it encodes to %s, the node has %s", block.Number(), block.Hash().Hex(), reported.Hash.Hex())
+			return nil
+		}
+		if raw, err = rlp.EncodeToBytes(block); err != nil {
+			log.Printf("ETH Block %v not archived: %v", block.Number(), err)
+			return nil
+		}
+		return &types.RawBlock{Format: types.RawFormatRLP, Data: raw}
+	}
+	if err != nil {
+		log.Printf("ETH Block %v not archived: %v", block.Number(), err)
+		return nil
+	}
+
+	if hash, err := rawHeaderHash(raw); err != nil || hash != block.Hash() {
+		log.Printf("ETH Block %v not archived: the raw block is %s, not %s (%v)", block.Number(), hash.Hex(), block.Hash().Hex(), err)
+		return nil
+	}
+	return &types.RawBlock{Format: types.RawFormatRLP, Data: raw}
+}
+
+// rawHeaderHash returns the hash of the header of an rlp encoded block, the
+// first item of its list
+func rawHeaderHash(raw []byte) (common.Hash, error) {
+	content, _, err := rlp.SplitList(raw)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	_, _, rest, err := rlp.Split(content)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(content[:len(content)-len(rest)]), nil
+}
@@ -0,0 +1,276 @@
+package blockchains
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	types "github.com/auser/bitping/types"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// pendingFetchers is how many pending transactions are looked up on the
+// node at once
+const pendingFetchers = 8
+
+// pendingTx is a transaction seen in the pool that hasn't been mined yet
+type pendingTx struct {
+	tx   types.Transaction
+	from string
+	seen time.Time
+}
+
+// pendingPool tracks pending transactions so they can be linked to the
+// block they get mined in, or marked as dropped or replaced
+type pendingPool struct {
+	sync.Mutex
+
+	ttl     time.Duration
+	byHash  map[string]*pendingTx
+	byNonce map[string]string // from/nonce -> hash
+	out     chan types.Transaction
+}
+
+func newPendingPool(ttl time.Duration) *pendingPool {
+	return &pendingPool{
+		ttl:     ttl,
+		byHash:  make(map[string]*pendingTx),
+		byNonce: make(map[string]string),
+	}
+}
+
+func nonceKey(from string, nonce int64) string {
+	return fmt.Sprintf("%s/%d", from, nonce)
+}
+
+// knownSender returns false for transactions whose sender couldn't be
+// recovered. Their nonces aren't indexed, they'd collide with each other
+func knownSender(from string) bool {
+	return from != "" && from != "unknown"
+}
+
+// Add starts tracking a pending transaction. It returns false when the
+// transaction is already tracked
+func (p *pendingPool) Add(tx types.Transaction) bool {
+	p.Lock()
+	defer p.Unlock()
+
+	if _, ok := p.byHash[tx.Hash]; ok {
+		return false
+	}
+
+	from := ""
+	if len(tx.Actions) > 0 {
+		from = tx.Actions[0].From
+	}
+
+	p.byHash[tx.Hash] = &pendingTx{tx: tx, from: from, seen: time.Now()}
+	if knownSender(from) {
+		p.byNonce[nonceKey(from, tx.Nonce)] = tx.Hash
+	}
+	return true
+}
+
+// Mined links the tracked transactions to a newly mined block. Pending
+// transactions included in the block are emitted as mined and the ones
+// sharing a sender and nonce with an included transaction as replaced
+func (p *pendingPool) Mined(block types.Block) {
+	var updates []types.Transaction
+
+	p.Lock()
+	for _, tx := range block.Transactions {
+		if pending, ok := p.byHash[tx.Hash]; ok {
+			mined := tx
+			mined.MempoolStatus = types.MempoolStatusMined
+			updates = append(updates, mined)
+			p.remove(pending)
+			continue
+		}
+
+		if len(tx.Actions) == 0 || !knownSender(tx.Actions[0].From) {
+			continue
+		}
+		hash, ok := p.byNonce[nonceKey(tx.Actions[0].From, tx.Nonce)]
+		if !ok {
+			continue
+		}
+		pending := p.byHash[hash]
+		replaced := pending.tx
+		replaced.MempoolStatus = types.MempoolStatusReplaced
+		replaced.ReplacedBy = tx.Hash
+		updates = append(updates, replaced)
+		p.remove(pending)
+	}
+	out := p.out
+	p.Unlock()
+
+	p.send(out, updates)
+}
+
+// Expired returns the transactions that have been pending for longer than
+// the ttl
+func (p *pendingPool) Expired() []types.Transaction {
+	p.Lock()
+	defer p.Unlock()
+
+	var expired []types.Transaction
+	for _, pending := range p.byHash {
+		if time.Since(pending.seen) > p.ttl {
+			expired = append(expired, pending.tx)
+		}
+	}
+	return expired
+}
+
+// Drop stops tracking a transaction and emits it as dropped
+func (p *pendingPool) Drop(hash string) {
+	p.Lock()
+	pending, ok := p.byHash[hash]
+	if !ok {
+		p.Unlock()
+		return
+	}
+	p.remove(pending)
+	dropped := pending.tx
+	dropped.MempoolStatus = types.MempoolStatusDropped
+	out := p.out
+	p.Unlock()
+
+	p.send(out, []types.Transaction{dropped})
+}
+
+// Forget stops tracking a transaction without emitting anything
+func (p *pendingPool) Forget(hash string) {
+	p.Lock()
+	defer p.Unlock()
+
+	if pending, ok := p.byHash[hash]; ok {
+		p.remove(pending)
+	}
+}
+
+// Refresh restarts the ttl of a transaction the node still has pending
+func (p *pendingPool) Refresh(hash string) {
+	p.Lock()
+	defer p.Unlock()
+
+	if pending, ok := p.byHash[hash]; ok {
+		pending.seen = time.Now()
+	}
+}
+
+func (p *pendingPool) remove(pending *pendingTx) {
+	delete(p.byHash, pending.tx.Hash)
+	key := nonceKey(pending.from, pending.tx.Nonce)
+	if p.byNonce[key] == pending.tx.Hash {
+		delete(p.byNonce, key)
+	}
+}
+
+func (p *pendingPool) send(out chan types.Transaction, txs []types.Transaction) {
+	if out == nil {
+		return
+	}
+	for _, tx := range txs {
+		out <- tx
+	}
+}
+
+// PendingEnabled returns true when pending transactions are turned on
+func (app EthereumApp) PendingEnabled() bool {
+	return app.Options.PendingTransactions
+}
+
+// WatchPending subscribes to the node's pending transactions and pipes each
+// one back as a unified transaction without block fields. Updates are sent
+// on the same channel once a transaction is mined, replaced or dropped
+func (app *EthereumApp) WatchPending(
+	txChan chan types.Transaction,
+	errChan chan error,
+) {
+	if app.pending == nil {
+		app.pending = newPendingPool(app.Options.PendingTTL)
+	}
+	app.pending.Lock()
+	app.pending.out = txChan
+	app.pending.Unlock()
+
+	fmt.Printf("Running Ethereum pending transactions\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	hashes := make(chan common.Hash, pendingFetchers)
+	sub, err := app.RPC.EthSubscribe(ctx, hashes, "newPendingTransactions")
+	if err != nil {
+		fmt.Printf("Error Subscribing to pending transactions: %s\n", err.Error())
+		errChan <- err
+		return
+	}
+
+	// The hashes are looked up by a few fetchers, one at a time falls
+	// behind a busy pool
+	for i := 0; i < pendingFetchers; i++ {
+		go app.fetchPending(ctx, hashes, txChan)
+	}
+
+	sweep := time.NewTicker(time.Minute)
+	defer sweep.Stop()
+
+	for {
+		select {
+		case err := <-sub.Err():
+			fmt.Printf("Err: %v\n", err)
+			errChan <- err
+			return
+		case <-sweep.C:
+			app.sweepPending(ctx)
+		}
+	}
+}
+
+// fetchPending looks up the transactions of hashes and emits the ones still
+// pending, until ctx is done
+func (app *EthereumApp) fetchPending(ctx context.Context, hashes chan common.Hash, txChan chan types.Transaction) {
+	for {
+		var hash common.Hash
+		select {
+		case <-ctx.Done():
+			return
+		case hash = <-hashes:
+		}
+
+		tx, isPending, err := app.Client.TransactionByHash(ctx, hash)
+		if err != nil || !isPending {
+			// It was mined or evicted before we got to it
+			continue
+		}
+
+		transaction := app.unifyTransaction(tx)
+		transaction.MempoolStatus = types.MempoolStatusPending
+		if app.pending.Add(transaction) {
+			txChan <- transaction
+		}
+	}
+}
+
+// sweepPending asks the node about transactions that have been pending for
+// longer than the ttl and drops the ones it no longer knows about
+func (app *EthereumApp) sweepPending(ctx context.Context) {
+	for _, tx := range app.pending.Expired() {
+		_, isPending, err := app.Client.TransactionByHash(ctx, common.HexToHash(tx.Hash))
+		switch {
+		case err == ethereum.NotFound:
+			app.pending.Drop(tx.Hash)
+		case err != nil:
+			log.Printf("ETH pending lookup %s: %v", tx.Hash, err)
+		case isPending:
+			app.pending.Refresh(tx.Hash)
+		default:
+			// Mined in a block we didn't link it to, stop tracking it
+			app.pending.Forget(tx.Hash)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package blockchains
+
+import (
+	"testing"
+	"time"
+
+	types "github.com/auser/bitping/types"
+)
+
+// pendingTransfer returns a pending transaction of from with the given nonce
+func pendingTransfer(hash, from string, nonce int64) types.Transaction {
+	return types.Transaction{
+		Hash:    hash,
+		Nonce:   nonce,
+		Actions: []types.Action{{From: from}},
+	}
+}
+
+func TestPendingPoolMined(t *testing.T) {
+	pool := newPendingPool(time.Hour)
+	out := make(chan types.Transaction, 10)
+	pool.out = out
+
+	for _, tx := range []types.Transaction{
+		pendingTransfer("0x01", "0xaa", 7),
+		pendingTransfer("0x02", "0xbb", 7),
+		// Senders that couldn't be recovered share a nonce index
+		pendingTransfer("0x03", "unknown", 1),
+		pendingTransfer("0x04", "unknown", 1),
+	} {
+		if !pool.Add(tx) {
+			t.Fatalf("%s already tracked", tx.Hash)
+		}
+	}
+	if pool.Add(pendingTransfer("0x01", "0xaa", 7)) {
+		t.Fatal("0x01 added twice")
+	}
+
+	pool.Mined(types.Block{Transactions: []types.Transaction{
+		pendingTransfer("0x01", "0xaa", 7),
+		// Replaces 0x02
+		pendingTransfer("0x05", "0xbb", 7),
+		pendingTransfer("0x03", "unknown", 1),
+		// Another unknown sender reusing nonce 1 doesn't replace 0x04
+		pendingTransfer("0x06", "unknown", 1),
+	}})
+	close(out)
+
+	got := make(map[string]types.Transaction)
+	for tx := range out {
+		got[tx.Hash] = tx
+	}
+	if len(got) != 3 {
+		t.Fatalf("emitted %d updates, want 3: %v", len(got), got)
+	}
+	if got["0x01"].MempoolStatus != types.MempoolStatusMined || got["0x03"].MempoolStatus != types.MempoolStatusMined {
+		t.Errorf("mined updates %+v %+v", got["0x01"], got["0x03"])
+	}
+	if tx := got["0x02"]; tx.MempoolStatus != types.MempoolStatusReplaced || tx.ReplacedBy != "0x05" {
+		t.Errorf("0x02 = %s by %q, want replaced by 0x05", tx.MempoolStatus, tx.ReplacedBy)
+	}
+	if _, ok := pool.byHash["0x04"]; !ok {
+		t.Error("0x04 is no longer pending")
+	}
+}
@@ -0,0 +1,206 @@
+package blockchains
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/auser/bitping/config"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// rpcNode answers JSON-RPC calls with the results of its methods, and
+// method not found for the rest
+type rpcNode map[string]func(params []json.RawMessage) (interface{}, error)
+
+func (n rpcNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+	method, ok := n[req.Method]
+	if !ok {
+		resp["error"] = map[string]interface{}{"code": rpcMethodNotFound, "message": "the method " + req.Method + " does not exist/is not available"}
+	} else if result, err := method(req.Params); err != nil {
+		resp["error"] = map[string]interface{}{"code": -32000, "message": err.Error()}
+	} else {
+		resp["result"] = result
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// result returns a method answering v
+func result(v interface{}) func([]json.RawMessage) (interface{}, error) {
+	return func([]json.RawMessage) (interface{}, error) { return v, nil }
+}
+
+// newTestEthereumApp returns a watcher connected to node
+func newTestEthereumApp(t *testing.T, node rpcNode, opts EthereumOptions) *EthereumApp {
+	srv := httptest.NewServer(node)
+	t.Cleanup(srv.Close)
+
+	client, err := rpc.Dial(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts.Node = srv.URL
+	return &EthereumApp{Client: ethclient.NewClient(client), RPC: client, Options: opts}
+}
+
+func TestEthereumSetNetwork(t *testing.T) {
+	tests := []struct {
+		name    string
+		node    rpcNode
+		chain   ChainProfile
+		network string
+		chainID int64
+	}{
+		{
+			// Classic reports mainnet's network id
+			name:    "chain id differs from the network id",
+			node:    rpcNode{"eth_chainId": result("0x3d"), "net_version": result("1")},
+			network: "classic",
+			chainID: 61,
+		},
+		{
+			name:    "node without eth_chainId",
+			node:    rpcNode{"net_version": result("5")},
+			network: "goerli",
+			chainID: 5,
+		},
+		{
+			name:    "unknown chain",
+			node:    rpcNode{"eth_chainId": result("0x2323")},
+			network: "ethereum",
+			chainID: 8995,
+		},
+		{
+			name:    "configured chain id",
+			node:    rpcNode{},
+			chain:   ChainProfile{ChainID: 61},
+			network: "classic",
+			chainID: 61,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestEthereumApp(t, tt.node, EthereumOptions{Chain: tt.chain})
+			app.setNetwork()
+			if app.Options.Network != tt.network || app.Options.Chain.ChainID != tt.chainID {
+				t.Errorf("network %s with chain id %d, want %s with %d", app.Options.Network, app.Options.Chain.ChainID, tt.network, tt.chainID)
+			}
+			if app.NetworkId.Int64() != tt.chainID {
+				t.Errorf("NetworkId = %v, want %d", &app.NetworkId, tt.chainID)
+			}
+		})
+	}
+}
+
+func TestChainProfileClique(t *testing.T) {
+	tests := []struct {
+		opts   config.Options
+		known  string
+		clique bool
+	}{
+		{config.Options{}, "goerli", true},
+		{config.Options{"clique": true}, "ethereum", true},
+		// Turning clique off sticks even on a known clique chain
+		{config.Options{"clique": false}, "goerli", false},
+		{config.Options{"profile": "goerli"}, "", true},
+		{config.Options{"profile": "goerli", "clique": false}, "", false},
+	}
+
+	for _, tt := range tests {
+		profile, err := chainProfileFromOptions(tt.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tt.known != "" {
+			profile = profile.withDefaults(ChainProfiles[tt.known])
+		}
+		if profile.Clique != tt.clique {
+			t.Errorf("%v on %s: clique = %v, want %v", tt.opts, tt.known, profile.Clique, tt.clique)
+		}
+	}
+}
+
+func TestEthereumRawBlock(t *testing.T) {
+	gethBlock := func(extra string) *gethtypes.Block {
+		return gethtypes.NewBlock(&gethtypes.Header{
+			Number:     big.NewInt(100),
+			Difficulty: big.NewInt(2000000000),
+			GasLimit:   30000000,
+			Extra:      []byte(extra),
+		}, nil, nil, nil)
+	}
+	encode := func(b *gethtypes.Block) hexutil.Bytes {
+		data, err := rlp.EncodeToBytes(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+	block, other := gethBlock("bitping"), gethBlock("reorged")
+
+	tests := []struct {
+		name string
+		node rpcNode
+		raw  []byte
+	}{
+		{
+			name: "raw block from the node",
+			node: rpcNode{"debug_getRawBlock": result(encode(block))},
+			raw:  encode(block),
+		},
+		{
+			// The block at the height changed between the two calls
+			name: "raw block of another block",
+			node: rpcNode{"debug_getRawBlock": result(encode(other))},
+		},
+		{
+			name: "not a block",
+			node: rpcNode{"debug_getRawBlock": result(hexutil.Bytes{0x01})},
+		},
+		{
+			name: "encoded again with the hash the node reports",
+			node: rpcNode{"eth_getBlockByNumber": result(map[string]interface{}{"hash": block.Hash()})},
+			raw:  encode(block),
+		},
+		{
+			// Header fields the decoded block lost change the hash
+			name: "encoded again with another hash",
+			node: rpcNode{"eth_getBlockByNumber": result(map[string]interface{}{"hash": other.Hash()})},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestEthereumApp(t, tt.node, EthereumOptions{})
+			raw := app.rawBlock(block)
+			switch {
+			case tt.raw == nil && raw != nil:
+				t.Fatalf("archived %d bytes, want nothing", len(raw.Data))
+			case tt.raw != nil && raw == nil:
+				t.Fatal("not archived")
+			case tt.raw != nil && (raw.Format != "rlp" || !bytes.Equal(raw.Data, tt.raw)):
+				t.Fatalf("raw block %s %x, want rlp %x", raw.Format, raw.Data, tt.raw)
+			}
+		})
+	}
+}
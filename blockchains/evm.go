@@ -0,0 +1,159 @@
+package blockchains
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/auser/bitping/config"
+	types "github.com/auser/bitping/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Clique keeps a 32 byte vanity prefix and a 65 byte signer seal in the
+// header extra-data
+const (
+	cliqueExtraVanity = 32
+	cliqueExtraSeal   = 65
+)
+
+// ChainProfile describes an EVM compatible chain so the Ethereum watcher can
+// follow it without assuming mainnet semantics
+type ChainProfile struct {
+	// Name is used as the network label on blocks
+	Name string
+	// ChainID is the EIP-155 chain id used to recover transaction senders
+	ChainID int64
+	// BlockTime is the expected time between blocks
+	BlockTime time.Duration
+	// FinalityDepth is how many blocks on top of a block make it safe from
+	// reorgs in practice
+	FinalityDepth uint64
+	// Clique is true for proof-of-authority chains that seal blocks with the
+	// signer's signature in the header extra-data
+	Clique bool
+
+	// cliqueSet is true when the clique option was set, even to false
+	cliqueSet bool
+}
+
+// ChainProfiles are the EVM chains bitping knows about out of the box
+var ChainProfiles = map[string]ChainProfile{
+	"ethereum": {Name: "ethereum", ChainID: 1, BlockTime: 15 * time.Second, FinalityDepth: 12},
+	"classic":  {Name: "classic", ChainID: 61, BlockTime: 15 * time.Second, FinalityDepth: 12},
+	"ropsten":  {Name: "ropsten", ChainID: 3, BlockTime: 15 * time.Second, FinalityDepth: 12},
+	"rinkeby":  {Name: "rinkeby", ChainID: 4, BlockTime: 15 * time.Second, FinalityDepth: 6, Clique: true},
+	"goerli":   {Name: "goerli", ChainID: 5, BlockTime: 15 * time.Second, FinalityDepth: 6, Clique: true},
+	"kovan":    {Name: "kovan", ChainID: 42, BlockTime: 4 * time.Second, FinalityDepth: 6},
+	"xdai":     {Name: "xdai", ChainID: 100, BlockTime: 5 * time.Second, FinalityDepth: 6},
+	"poa":      {Name: "poa", ChainID: 99, BlockTime: 5 * time.Second, FinalityDepth: 6},
+	// geth --dev runs a single signer clique chain
+	"dev": {Name: "dev", ChainID: 1337, BlockTime: time.Second, Clique: true},
+}
+
+// chainProfileByID returns the known profile with the given chain id
+func chainProfileByID(id int64) (ChainProfile, bool) {
+	for _, profile := range ChainProfiles {
+		if profile.ChainID == id {
+			return profile, true
+		}
+	}
+	return ChainProfile{}, false
+}
+
+// withDefaults fills in the unset fields of p from d
+func (p ChainProfile) withDefaults(d ChainProfile) ChainProfile {
+	if p.Name == "" {
+		p.Name = d.Name
+	}
+	if p.ChainID == 0 {
+		p.ChainID = d.ChainID
+	}
+	if p.BlockTime == 0 {
+		p.BlockTime = d.BlockTime
+	}
+	if p.FinalityDepth == 0 {
+		p.FinalityDepth = d.FinalityDepth
+	}
+	if !p.cliqueSet {
+		p.Clique = d.Clique
+	}
+	return p
+}
+
+// chainProfileFromOptions looks up the profile named by the profile option
+// and applies any per-field overrides on top of it. Without a profile
+// option only the overrides are set and the rest is derived from the node
+func chainProfileFromOptions(opts config.Options) (ChainProfile, error) {
+	var profile ChainProfile
+	if name := opts.String("profile", ""); name != "" {
+		var ok bool
+		if profile, ok = ChainProfiles[name]; !ok {
+			if !opts.Has("chain_id") {
+				return ChainProfile{}, fmt.Errorf("unknown chain profile %q, set chain_id for custom chains", name)
+			}
+			profile = ChainProfile{Name: name}
+		}
+	}
+
+	profile.ChainID = opts.Int64("chain_id", profile.ChainID)
+	profile.BlockTime = opts.Duration("block_time", profile.BlockTime)
+	profile.FinalityDepth = uint64(opts.Int64("finality_depth", int64(profile.FinalityDepth)))
+	if opts.Has("clique") {
+		profile.Clique = opts.Bool("clique", profile.Clique)
+		profile.cliqueSet = true
+	}
+
+	return profile, nil
+}
+
+// cliqueSigner recovers the address that sealed a clique header
+func cliqueSigner(head *types.GethHeader) (common.Address, error) {
+	if len(head.Extra) < cliqueExtraVanity+cliqueExtraSeal {
+		return common.Address{}, errors.New("clique: extra-data too short for a seal")
+	}
+	seal := head.Extra[len(head.Extra)-cliqueExtraSeal:]
+
+	pubkey, err := crypto.Ecrecover(cliqueSigHash(head).Bytes(), seal)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	var signer common.Address
+	copy(signer[:], crypto.Keccak256(pubkey[1:])[12:])
+	return signer, nil
+}
+
+// cliqueSigHash is the hash a clique signer signs: the rlp encoded header
+// without the seal
+func cliqueSigHash(head *types.GethHeader) common.Hash {
+	enc, _ := rlp.EncodeToBytes([]interface{}{
+		head.ParentHash,
+		head.UncleHash,
+		head.Coinbase,
+		head.Root,
+		head.TxHash,
+		head.ReceiptHash,
+		head.Bloom,
+		head.Difficulty,
+		head.Number,
+		head.GasLimit,
+		head.GasUsed,
+		head.Time,
+		head.Extra[:len(head.Extra)-cliqueExtraSeal],
+		head.MixDigest,
+		head.Nonce,
+	})
+	return crypto.Keccak256Hash(enc)
+}
+
+// cliqueVanity returns the vanity part of clique extra-data, dropping the
+// seal and any checkpoint signer list
+func cliqueVanity(extra []byte) []byte {
+	if len(extra) < cliqueExtraVanity {
+		return extra
+	}
+	return extra[:cliqueExtraVanity]
+}
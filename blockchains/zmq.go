@@ -0,0 +1,181 @@
+package blockchains
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"time"
+)
+
+// ZMTP 3.0 frame flags
+const (
+	zmqFlagMore    = 0x01
+	zmqFlagLong    = 0x02
+	zmqFlagCommand = 0x04
+)
+
+// zmqSub is a minimal ZMTP 3.0 SUB socket. It's just enough to read the
+// notifications bitcoind publishes with -zmqpub*, without cgo or libzmq
+type zmqSub struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// dialZMQSub connects to a ZMQ PUB endpoint such as tcp://127.0.0.1:28332
+// and subscribes to the given topics
+func dialZMQSub(endpoint string, topics ...string) (*zmqSub, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "tcp" {
+		return nil, fmt.Errorf("zmq: unsupported transport %q", u.Scheme)
+	}
+
+	conn, err := net.DialTimeout("tcp", u.Host, 10*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &zmqSub{conn: conn, r: bufio.NewReader(conn)}
+	if err := s.handshake(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	for _, topic := range topics {
+		// ZMTP 3.0 subscriptions are messages starting with 0x01
+		if err := s.writeFrame(0, append([]byte{0x01}, topic...)); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// handshake exchanges greetings and READY commands using the NULL mechanism
+func (s *zmqSub) handshake() error {
+	greeting := make([]byte, 64)
+	greeting[0] = 0xff
+	greeting[9] = 0x7f
+	greeting[10] = 3 // major version
+	greeting[11] = 0 // minor version
+	copy(greeting[12:32], "NULL")
+
+	s.conn.SetDeadline(time.Now().Add(10 * time.Second))
+	defer s.conn.SetDeadline(time.Time{})
+
+	if _, err := s.conn.Write(greeting); err != nil {
+		return err
+	}
+
+	peer := make([]byte, 64)
+	if _, err := io.ReadFull(s.r, peer); err != nil {
+		return err
+	}
+	if peer[0] != 0xff || peer[9] != 0x7f {
+		return errors.New("zmq: bad greeting signature")
+	}
+	if peer[10] < 3 {
+		return fmt.Errorf("zmq: unsupported peer version %d", peer[10])
+	}
+	if mech := string(bytes.TrimRight(peer[12:32], "\x00")); mech != "NULL" {
+		return fmt.Errorf("zmq: unsupported security mechanism %q", mech)
+	}
+
+	var ready bytes.Buffer
+	ready.WriteByte(5)
+	ready.WriteString("READY")
+	ready.WriteByte(11)
+	ready.WriteString("Socket-Type")
+	binary.Write(&ready, binary.BigEndian, uint32(3))
+	ready.WriteString("SUB")
+	if err := s.writeFrame(zmqFlagCommand, ready.Bytes()); err != nil {
+		return err
+	}
+
+	flags, body, err := s.readFrame()
+	if err != nil {
+		return err
+	}
+	if flags&zmqFlagCommand == 0 || len(body) < 6 || string(body[1:6]) != "READY" {
+		return errors.New("zmq: expected READY command")
+	}
+
+	return nil
+}
+
+// Recv returns the frames of the next message, skipping any commands
+func (s *zmqSub) Recv() ([][]byte, error) {
+	var msg [][]byte
+	for {
+		flags, body, err := s.readFrame()
+		if err != nil {
+			return nil, err
+		}
+		if flags&zmqFlagCommand != 0 {
+			continue
+		}
+		msg = append(msg, body)
+		if flags&zmqFlagMore == 0 {
+			return msg, nil
+		}
+	}
+}
+
+// Close closes the connection
+func (s *zmqSub) Close() error {
+	return s.conn.Close()
+}
+
+func (s *zmqSub) readFrame() (byte, []byte, error) {
+	flags, err := s.r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var size uint64
+	if flags&zmqFlagLong != 0 {
+		if err := binary.Read(s.r, binary.BigEndian, &size); err != nil {
+			return 0, nil, err
+		}
+	} else {
+		b, err := s.r.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		size = uint64(b)
+	}
+
+	// bitcoind messages are at most a block, anything bigger is garbage
+	if size > 64<<20 {
+		return 0, nil, fmt.Errorf("zmq: frame too large (%d bytes)", size)
+	}
+
+	body := make([]byte, size)
+	if _, err := io.ReadFull(s.r, body); err != nil {
+		return 0, nil, err
+	}
+	return flags, body, nil
+}
+
+func (s *zmqSub) writeFrame(flags byte, body []byte) error {
+	var frame bytes.Buffer
+	if len(body) > 255 {
+		frame.WriteByte(flags | zmqFlagLong)
+		binary.Write(&frame, binary.BigEndian, uint64(len(body)))
+	} else {
+		frame.WriteByte(flags)
+		frame.WriteByte(byte(len(body)))
+	}
+	frame.Write(body)
+
+	_, err := s.conn.Write(frame.Bytes())
+	return err
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/auser/bitping/alerts"
+	"github.com/auser/bitping/notify"
+	"github.com/auser/bitping/pipeline"
+)
+
+// alertReceiver logs the alerts raised by the alerts stage and dispatches
+// them to the notifiers, in the background
+type alertReceiver struct {
+	engine    *alerts.Engine
+	notifiers *notify.Dispatcher
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+// receiveAlerts starts receiving the alerts of the alerts stage of pipe.
+// notifiers may be nil. It returns nil when the stage isn't in the pipeline
+func receiveAlerts(pipe *pipeline.Pipeline, notifiers *notify.Dispatcher) *alertReceiver {
+	engine, ok := pipe.Stage("alerts").(*alerts.Engine)
+	if !ok {
+		return nil
+	}
+
+	r := &alertReceiver{
+		engine:    engine,
+		notifiers: notifiers,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	go r.run()
+	return r
+}
+
+func (r *alertReceiver) run() {
+	defer close(r.done)
+	for {
+		select {
+		case alert := <-r.engine.Alerts():
+			log.Printf("Alert %s [%s] on %s block %d: %s", alert.Rule, alert.Severity, alert.Network, alert.BlockNumber, alert.Message)
+			if r.notifiers != nil {
+				r.notifiers.Dispatch(alert)
+			}
+		case <-r.stop:
+			return
+		}
+	}
+}
+
+// Stop stops dispatching alerts and waits for the one being dispatched. It
+// has to be called before the notifiers are closed
+func (r *alertReceiver) Stop() {
+	close(r.stop)
+	<-r.done
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/codegangsta/cli"
+)
+
+// Build information, set with -ldflags by the Makefile
+var (
+	AppName   = "bitping"
+	Branch    string
+	Version   string
+	Commit    string
+	BuildTime string
+)
+
+// NewApp returns the bitping cli app with all its commands
+func NewApp() *cli.App {
+	app := cli.NewApp()
+	app.Name = AppName
+	app.Usage = "watch blockchains and deliver unified blocks"
+	app.Version = fmt.Sprintf("%s (%s@%s, built %s)", Version, Branch, Commit, BuildTime)
+	app.Commands = []cli.Command{
+		watchCommand,
+		exportCommand,
+		serveCommand,
+		schemaCommand,
+	}
+	return app
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"os"
+
+	"github.com/auser/bitping/archive"
+	"github.com/auser/bitping/types"
+	"github.com/codegangsta/cli"
+)
+
+var exportCommand = cli.Command{
+	Name:  "export",
+	Usage: "write a range of archived raw blocks to a file",
+	Description: `Blocks are written lowest first. RLP blocks are concatenated the way
+   geth import reads them, JSON blocks are written one per line.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:   "archive",
+			Usage:  "directory the raw blocks were archived in",
+			EnvVar: "BITPING_ARCHIVE",
+		},
+		cli.StringFlag{
+			Name:  "network",
+			Usage: "network label of the blocks to export",
+		},
+		cli.Int64Flag{
+			Name:  "from",
+			Usage: "first block number",
+		},
+		cli.Int64Flag{
+			Name:  "to",
+			Usage: "last block number",
+		},
+		cli.StringFlag{
+			Name:  "out",
+			Usage: "file to write to, defaults to stdout",
+		},
+	},
+	Action: export,
+}
+
+func export(c *cli.Context) error {
+	if c.String("archive") == "" || c.String("network") == "" {
+		return errors.New("export needs --archive and --network")
+	}
+	if c.Int64("to") < c.Int64("from") {
+		return errors.New("--to is lower than --from")
+	}
+
+	store, err := archive.Open(c.String("archive"))
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	out := os.Stdout
+	if path := c.String("out"); path != "" {
+		if out, err = os.Create(path); err != nil {
+			return err
+		}
+		defer out.Close()
+	}
+	w := bufio.NewWriter(out)
+
+	err = store.Range(c.String("network"), c.Int64("from"), c.Int64("to"), func(entry archive.Entry, data []byte) error {
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+		if entry.Format == types.RawFormatJSON {
+			return w.WriteByte('\n')
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
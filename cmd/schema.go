@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/auser/bitping/schema"
+	"github.com/auser/bitping/types"
+	"github.com/codegangsta/cli"
+)
+
+var schemaCommand = cli.Command{
+	Name:  "schema",
+	Usage: "write or check the JSON schema of the unified types",
+	Description: `The schema is generated from the Go types and written to
+   <out>/v<version>/. It refuses to overwrite a published version the types
+   broke, that takes a bump of types.SchemaVersion.
+   With --check nothing is written, the command fails
+   when the published schema is missing or out of date, or when the types
+   changed in a way that breaks existing consumers without a bump of
+   types.SchemaVersion.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "out",
+			Value: "schema",
+			Usage: "directory the schema is published in",
+		},
+		cli.StringFlag{
+			Name:  "types",
+			Value: "types",
+			Usage: "source directory of the types package, for descriptions",
+		},
+		cli.BoolFlag{
+			Name:  "check",
+			Usage: "check the published schema instead of writing it",
+		},
+	},
+	Action: generateSchema,
+}
+
+func generateSchema(c *cli.Context) error {
+	docs, err := schema.LoadDocs(c.String("types"))
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(c.String("out"), fmt.Sprintf("v%d", types.SchemaVersion))
+	generated := schema.Generate(docs)
+
+	names := make([]string, 0, len(generated))
+	for name := range generated {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if c.Bool("check") {
+		return checkSchema(dir, names, generated)
+	}
+
+	// The published schema of a version only grows, breaking it takes a
+	// new version
+	breaking, _, err := compareSchema(dir, names, generated)
+	if err != nil {
+		return err
+	}
+	if len(breaking) > 0 {
+		return fmt.Errorf("refusing to overwrite %s:\n%s", dir, strings.Join(breaking, "\n"))
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for _, name := range names {
+		data, err := schema.Marshal(generated[name])
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(schemaPath(dir, name), data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkSchema(dir string, names []string, generated map[string]*schema.Schema) error {
+	breaking, stale, err := compareSchema(dir, names, generated)
+	if err != nil {
+		return err
+	}
+	if problems := append(breaking, stale...); len(problems) > 0 {
+		return errors.New(strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+// compareSchema compares the generated schema with the one published in
+// dir. breaking describes the files the types broke, stale the ones that
+// are missing or out of date
+func compareSchema(dir string, names []string, generated map[string]*schema.Schema) (breaking, stale []string, err error) {
+	for _, name := range names {
+		path := schemaPath(dir, name)
+		published, err := ioutil.ReadFile(path)
+		if os.IsNotExist(err) {
+			stale = append(stale, fmt.Sprintf("%s is missing, run make schema", path))
+			continue
+		} else if err != nil {
+			return nil, nil, err
+		}
+
+		var old schema.Schema
+		if err := json.Unmarshal(published, &old); err != nil {
+			return nil, nil, fmt.Errorf("%s: %v", path, err)
+		}
+		if changes := schema.Breaking(&old, generated[name]); len(changes) > 0 {
+			breaking = append(breaking, fmt.Sprintf(
+				"%s: breaking changes, bump types.SchemaVersion:\n    %s",
+				path, strings.Join(changes, "\n    ")))
+			continue
+		}
+
+		data, err := schema.Marshal(generated[name])
+		if err != nil {
+			return nil, nil, err
+		}
+		if !bytes.Equal(published, data) {
+			stale = append(stale, fmt.Sprintf("%s is out of date, run make schema", path))
+		}
+	}
+	return breaking, stale, nil
+}
+
+func schemaPath(dir, name string) string {
+	return filepath.Join(dir, name+".schema.json")
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/auser/bitping/blockchains"
+	"github.com/auser/bitping/config"
+	"github.com/auser/bitping/grpcserver"
+	"github.com/auser/bitping/notify"
+	"github.com/auser/bitping/pipeline"
+	"github.com/auser/bitping/server"
+	"github.com/auser/bitping/stream"
+	"github.com/codegangsta/cli"
+)
+
+var serveCommand = cli.Command{
+	Name:  "serve",
+	Usage: "serve the stored blocks over http and grpc, indexing new blocks from the configured watchers",
+	Description: `The store is required. Without watchers the api only serves what's
+   already stored. The grpc service is only started with --grpc-listen.`,
+	Flags:  grpcserver.AddCLIFlags(stream.AddCLIFlags(server.AddCLIFlags(watchFlags()))),
+	Action: serve,
+}
+
+func serve(c *cli.Context) error {
+	cfg, err := config.FromCLI(c)
+	if err != nil {
+		return err
+	}
+
+	watchers, err := blockchains.Watchers(cfg, c)
+	if err != nil {
+		return err
+	}
+
+	out, err := openOutputs(c, cfg)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	if out.index == nil {
+		return errors.New("serve needs a store, set --store")
+	}
+
+	pipe, err := pipeline.FromCLI(c, cfg)
+	if err != nil {
+		return err
+	}
+	metrics := map[string]func() interface{}{
+		"pipeline": func() interface{} { return pipe.Metrics() },
+	}
+	notifiers, err := notify.Notifiers(cfg)
+	if err != nil {
+		return err
+	}
+	if notifiers != nil {
+		defer notifiers.Close()
+	}
+	// Deferred after the notifiers so it stops before they are closed
+	if receiver := receiveAlerts(pipe, notifiers); receiver != nil {
+		defer receiver.Stop()
+		metrics["alerts"] = func() interface{} { return receiver.engine.Metrics() }
+	}
+
+	hub := stream.NewHub(c.Int("stream-buffer"))
+	srv := server.New(out.index, server.OptionsFromCLI(c))
+	srv.Handle("/v1/stream", http.HandlerFunc(hub.ServeSSE))
+	srv.Handle("/v1/ws", hub.ServeWebSocket(srv.Options.CORSOrigins))
+	srv.HandleMetrics(metrics)
+	serveErr := make(chan error, 2)
+	go func() { serveErr <- srv.ListenAndServe() }()
+
+	if listen := c.String("grpc-listen"); listen != "" {
+		rpc := grpcserver.New(out.index, hub, listen)
+		go func() { serveErr <- rpc.ListenAndServe() }()
+	}
+
+	matching := matchingBlocks(pipe)
+	resumeWatchers(watchers, out)
+	blockCh, txCh, errCh := startWatchers(watchers)
+	blocks := pipe.Run(blockCh)
+	for {
+		select {
+		case block := <-blocks:
+			out.Block(block)
+			hub.PublishBlock(matching(block))
+		case <-txCh:
+			// Pending transactions aren't stored
+		case err := <-errCh:
+			log.Printf("Watcher error: %v", err)
+		case err := <-serveErr:
+			return err
+		}
+	}
+}
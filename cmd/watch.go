@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+
+	"github.com/auser/bitping/archive"
+	"github.com/auser/bitping/blockchains"
+	"github.com/auser/bitping/config"
+	"github.com/auser/bitping/filter"
+	"github.com/auser/bitping/iface"
+	"github.com/auser/bitping/labels"
+	"github.com/auser/bitping/metadata"
+	"github.com/auser/bitping/notify"
+	"github.com/auser/bitping/pipeline"
+	"github.com/auser/bitping/prices"
+	"github.com/auser/bitping/sinks"
+	"github.com/auser/bitping/storage"
+	"github.com/auser/bitping/types"
+	"github.com/codegangsta/cli"
+)
+
+var watchCommand = cli.Command{
+	Name:   "watch",
+	Usage:  "watch the configured blockchains and print unified blocks as json",
+	Flags:  watchFlags(),
+	Action: watch,
+}
+
+func watchFlags() []cli.Flag {
+	fs := config.AddCLIFlags(nil)
+	fs = blockchains.AddCLIFlags(fs)
+	fs = archive.AddCLIFlags(fs)
+	fs = storage.AddCLIFlags(fs)
+	fs = prices.AddCLIFlags(fs)
+	fs = labels.AddCLIFlags(fs)
+	fs = metadata.AddCLIFlags(fs)
+	return fs
+}
+
+func watch(c *cli.Context) error {
+	cfg, err := config.FromCLI(c)
+	if err != nil {
+		return err
+	}
+
+	watchers, err := blockchains.Watchers(cfg, c)
+	if err != nil {
+		return err
+	}
+	if len(watchers) == 0 {
+		return errors.New("no watchers configured")
+	}
+
+	out, err := openOutputs(c, cfg)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	pipe, err := pipeline.FromCLI(c, cfg)
+	if err != nil {
+		return err
+	}
+	notifiers, err := notify.Notifiers(cfg)
+	if err != nil {
+		return err
+	}
+	if notifiers != nil {
+		defer notifiers.Close()
+	}
+	// Deferred after the notifiers so it stops before they are closed
+	if receiver := receiveAlerts(pipe, notifiers); receiver != nil {
+		defer receiver.Stop()
+	}
+	matching := matchingBlocks(pipe)
+	resumeWatchers(watchers, out)
+	blockCh, txCh, errCh := startWatchers(watchers)
+	blocks := pipe.Run(blockCh)
+
+	enc := json.NewEncoder(os.Stdout)
+	for {
+		select {
+		case block := <-blocks:
+			out.Block(block)
+			if err := enc.Encode(matching(block)); err != nil {
+				return err
+			}
+		case tx := <-txCh:
+			if err := enc.Encode(tx.Versioned()); err != nil {
+				return err
+			}
+		case err := <-errCh:
+			log.Printf("Watcher error: %v", err)
+		}
+	}
+}
+
+// matchingBlocks returns what keeps the transactions of a block the filters
+// stage of pipe matched, for the outputs that only want the matches. Blocks
+// are kept whole when the stage isn't in the pipeline
+func matchingBlocks(pipe *pipeline.Pipeline) func(types.Block) types.Block {
+	filters, ok := pipe.Stage("filters").(*filter.Filters)
+	if !ok {
+		return func(block types.Block) types.Block { return block }
+	}
+	return filters.Matching
+}
+
+// resumeWatchers starts the watchers that can resume at the lowest height
+// one of the sinks is missing of their network
+func resumeWatchers(watchers []iface.Watcher, out *outputs) {
+	heights := make(map[string]int64)
+	for _, q := range out.sinks {
+		cs, ok := q.sink.(iface.CheckpointedSink)
+		if !ok {
+			continue
+		}
+		for network, h := range cs.Resume() {
+			if low, ok := heights[network]; !ok || h < low {
+				heights[network] = h
+			}
+		}
+	}
+	if len(heights) == 0 {
+		return
+	}
+
+	for _, w := range watchers {
+		if rw, ok := w.(iface.ResumableWatcher); ok {
+			rw.ResumeFrom(heights)
+		}
+	}
+}
+
+// startWatchers runs each watcher, and its pending transaction watcher when
+// it's turned on, piping everything into the returned channels
+func startWatchers(watchers []iface.Watcher) (chan types.Block, chan types.Transaction, chan error) {
+	blockCh := make(chan types.Block)
+	txCh := make(chan types.Transaction)
+	errCh := make(chan error)
+
+	for _, w := range watchers {
+		log.Printf("Starting %s", w.Name())
+		go w.Watch(blockCh, errCh)
+
+		if pw, ok := w.(iface.PendingWatcher); ok && pw.PendingEnabled() {
+			go pw.WatchPending(txCh, errCh)
+		}
+	}
+
+	return blockCh, txCh, errCh
+}
+
+// sinkBuffer is how many blocks can wait for a sink before delivering
+// blocks holds up the watchers
+const sinkBuffer = 1024
+
+// outputs are the local stores and sinks delivered blocks are written to
+type outputs struct {
+	archive *archive.Store
+	index   *storage.Store
+	sinks   []*sinkQueue
+}
+
+// sinkQueue publishes blocks to a sink in the background, in the order they
+// were delivered
+type sinkQueue struct {
+	sink   iface.Sink
+	blocks chan types.Block
+	done   chan struct{}
+}
+
+func newSinkQueue(sink iface.Sink) *sinkQueue {
+	q := &sinkQueue{
+		sink:   sink,
+		blocks: make(chan types.Block, sinkBuffer),
+		done:   make(chan struct{}),
+	}
+	go q.run()
+	return q
+}
+
+func (q *sinkQueue) run() {
+	defer close(q.done)
+	for block := range q.blocks {
+		if err := q.sink.Publish(block); err != nil {
+			log.Printf("Sink error: %v", err)
+		}
+	}
+}
+
+// Close publishes what's queued and closes the sink
+func (q *sinkQueue) Close() {
+	close(q.blocks)
+	<-q.done
+	q.sink.Close()
+}
+
+func openOutputs(c *cli.Context, cfg *config.Config) (*outputs, error) {
+	var (
+		out outputs
+		err error
+	)
+	if out.archive, err = archive.FromCLI(c, cfg); err != nil {
+		return nil, err
+	}
+	if out.index, err = storage.FromCLI(c, cfg); err != nil {
+		out.Close()
+		return nil, err
+	}
+
+	configured, err := sinks.Sinks(cfg)
+	if err != nil {
+		out.Close()
+		return nil, err
+	}
+	for _, sink := range configured {
+		log.Printf("Publishing to %s", sink.Name())
+		out.sinks = append(out.sinks, newSinkQueue(sink))
+	}
+	return &out, nil
+}
+
+// Block writes block to the stores that are turned on and queues it for the
+// sinks. Errors are logged so a full disk doesn't stop the watchers
+func (out *outputs) Block(block types.Block) {
+	if out.archive != nil {
+		if err := out.archive.Put(block); err != nil {
+			log.Printf("Archive error: %v", err)
+		}
+	}
+	if out.index != nil {
+		if err := out.index.Put(block); err != nil {
+			log.Printf("Storage error: %v", err)
+		}
+	}
+	for _, q := range out.sinks {
+		q.blocks <- block
+	}
+}
+
+func (out *outputs) Close() {
+	if out.archive != nil {
+		out.archive.Close()
+	}
+	if out.index != nil {
+		out.index.Close()
+	}
+	for _, q := range out.sinks {
+		q.Close()
+	}
+}
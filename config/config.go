@@ -0,0 +1,325 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/codegangsta/cli"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Config holds everything that can be declared in a bitping config file,
+// for example:
+//
+//	watchers:
+//	  - chain: ethereum
+//	    options:
+//	      node: wss://mainnet.infura.io/ws
+//	  - chain: eos
+//	    options:
+//	      node: https://api.eosnewyork.io
+//	sinks:
+//	  - type: pubsub
+//	    options:
+//	      project: my-project
+//	      topic: blocks
+type Config struct {
+	Watchers []WatcherConfig `yaml:"watchers"`
+	Sinks    []SinkConfig    `yaml:"sinks"`
+	Filters  []FilterConfig  `yaml:"filters"`
+	Tokens   []TokenConfig   `yaml:"tokens"`
+	Pipeline []StageConfig   `yaml:"pipeline"`
+	Alerts   []AlertConfig   `yaml:"alerts"`
+
+	// Notifiers deliver alerts to people
+	Notifiers []NotifierConfig `yaml:"notifiers"`
+	// Explorers link the transactions, addresses and blocks of networks in
+	// notifications, on top of the known ones
+	Explorers map[string]ExplorerConfig `yaml:"explorers"`
+
+	// Archive is the directory raw blocks are archived in. The --archive
+	// flag overrides it
+	Archive string `yaml:"archive"`
+
+	// Store holds the options of the embedded block index. The --store
+	// flags override them
+	Store Options `yaml:"store"`
+
+	// Prices holds the options of the fiat valuation of actions. The
+	// --prices flags override them
+	Prices Options `yaml:"prices"`
+
+	// Labels holds the options of address labelling. The --labels flag
+	// overrides them
+	Labels Options `yaml:"labels"`
+
+	// Metadata holds the options of ENS and EOS account resolution. The
+	// --ens-node and --eos-accounts-node flags override them
+	Metadata Options `yaml:"metadata"`
+}
+
+// WatcherConfig declares a single watcher instance
+type WatcherConfig struct {
+	// Name of this instance. Leaving it empty (or setting it to the chain
+	// name) makes this the default instance of the chain
+	Name string `yaml:"name"`
+	// Chain is the blockchain implementation to use, i.e. ethereum or eos
+	Chain   string  `yaml:"chain"`
+	Options Options `yaml:"options"`
+}
+
+// InstanceName returns the name of this instance, defaulting to the chain
+func (wc WatcherConfig) InstanceName() string {
+	if wc.Name == "" {
+		return wc.Chain
+	}
+	return wc.Name
+}
+
+// IsDefault returns true when this is the default (unnamed) instance of its
+// chain, which is the one that the plain cli flags apply to
+func (wc WatcherConfig) IsDefault() bool {
+	return wc.Name == "" || wc.Name == wc.Chain
+}
+
+// SinkConfig declares a destination blocks are delivered to
+type SinkConfig struct {
+	Name    string  `yaml:"name"`
+	Type    string  `yaml:"type"`
+	Options Options `yaml:"options"`
+}
+
+// FilterConfig declares a named filter that applies to the blocks of the
+// networks listed in Networks (or all of them when empty). Networks are the
+// labels watchers put on their blocks, which is the instance name unless
+// the network option is set
+type FilterConfig struct {
+	Name     string   `yaml:"name"`
+	Networks []string `yaml:"networks"`
+	Expr     string   `yaml:"expr"`
+}
+
+// TokenConfig declares a token, or overrides a known one, so amounts of it
+// can be normalized
+type TokenConfig struct {
+	Network string `yaml:"network"`
+	// Address of the token contract, the token account on EOS. Leave it
+	// empty for the native coin of the network
+	Address  string `yaml:"address"`
+	Symbol   string `yaml:"symbol"`
+	Decimals uint64 `yaml:"decimals"`
+	Name     string `yaml:"name"`
+	// PriceID is the symbol the token is priced by. Tokens without one have
+	// no fiat value
+	PriceID string `yaml:"price_id"`
+}
+
+// StageConfig places an enrichment stage in the pipeline between the
+// watchers and the outputs
+type StageConfig struct {
+	Stage string `yaml:"stage"`
+	// Concurrency is how many blocks the stage processes at the same time,
+	// one by default. Blocks still leave the stage in order
+	Concurrency int `yaml:"concurrency"`
+}
+
+// AlertConfig declares an alert rule. A rule looks at the actions matching
+// Where and raises an alert when Stat, computed over Window for each group
+// of actions, goes above Above or below Below. Durations are strings like
+// 10m or 1h
+type AlertConfig struct {
+	Name     string `yaml:"name"`
+	Severity string `yaml:"severity"`
+	// Networks are the network labels of the blocks the rule looks at,
+	// all of them when empty
+	Networks []string `yaml:"networks"`
+	Where    string   `yaml:"where"`
+	// Stat is value, count, sum, mean, rate or zscore
+	Stat  string   `yaml:"stat"`
+	Field string   `yaml:"field"`
+	Above *float64 `yaml:"above"`
+	Below *float64 `yaml:"below"`
+	// Window, in block time, that count, sum, mean, rate and zscore are
+	// computed over
+	Window string `yaml:"window"`
+	// GroupBy is a string field keeping separate statistics per value,
+	// i.e. to or actor
+	GroupBy string `yaml:"group_by"`
+	// MinSamples is how many earlier samples a zscore needs
+	MinSamples int `yaml:"min_samples"`
+	// Cooldown holds back the alerts of a group after one was raised
+	Cooldown string `yaml:"cooldown"`
+	// Dedup is how long an alert is remembered so replayed blocks don't
+	// raise it again
+	Dedup string `yaml:"dedup"`
+}
+
+// NotifierConfig declares a channel alerts are sent to
+type NotifierConfig struct {
+	Name string `yaml:"name"`
+	Type string `yaml:"type"`
+	// Alerts are the names of the rules sent to the channel, all of them
+	// when empty
+	Alerts []string `yaml:"alerts"`
+	// Severity is the lowest severity sent to the channel
+	Severity string  `yaml:"severity"`
+	Options  Options `yaml:"options"`
+}
+
+// ExplorerConfig holds the urls of a block explorer, with a %s where the
+// transaction hash, address or block number goes
+type ExplorerConfig struct {
+	Transaction string `yaml:"transaction"`
+	Address     string `yaml:"address"`
+	Block       string `yaml:"block"`
+}
+
+// AddCLIFlags adds the config file flags
+func AddCLIFlags(fs []cli.Flag) []cli.Flag {
+	return append(fs,
+		cli.StringFlag{
+			Name:   "config",
+			Usage:  "path to a bitping config file",
+			EnvVar: "BITPING_CONFIG",
+		},
+		cli.StringSliceFlag{
+			Name:  "set",
+			Usage: "set a watcher option as instance.key=value, i.e. ropsten.chain=ethereum",
+		},
+	)
+}
+
+// FromCLI loads the config file given with --config and applies any --set
+// options on top of it. When no file is given the Config only holds the
+// watchers declared with --set
+func FromCLI(c *cli.Context) (*Config, error) {
+	cfg := &Config{}
+	if path := c.String("config"); path != "" {
+		var err error
+		if cfg, err = Load(path); err != nil {
+			return nil, err
+		}
+	}
+
+	sets := c.StringSlice("set")
+	for _, set := range sets {
+		if err := cfg.Set(set); err != nil {
+			return nil, err
+		}
+	}
+	if len(sets) > 0 {
+		if err := cfg.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	return cfg, nil
+}
+
+// Set applies an instance.key=value option to the watcher with that
+// instance name. Setting the chain of an unknown instance declares a new
+// watcher
+func (cfg *Config) Set(set string) error {
+	kv := strings.SplitN(set, "=", 2)
+	path := strings.SplitN(kv[0], ".", 2)
+	if len(kv) != 2 || len(path) != 2 {
+		return fmt.Errorf("invalid option %q, expected instance.key=value", set)
+	}
+	instance, key, value := path[0], path[1], kv[1]
+
+	for i, wc := range cfg.Watchers {
+		if wc.InstanceName() != instance {
+			continue
+		}
+		if key == "chain" {
+			cfg.Watchers[i].Chain = value
+			return nil
+		}
+		if wc.Options == nil {
+			cfg.Watchers[i].Options = Options{}
+		}
+		cfg.Watchers[i].Options[key] = value
+		return nil
+	}
+
+	if key != "chain" {
+		return fmt.Errorf("unknown watcher %q, set %s.chain first", instance, instance)
+	}
+
+	cfg.Watchers = append(cfg.Watchers, WatcherConfig{
+		Name:    instance,
+		Chain:   value,
+		Options: Options{},
+	})
+	return nil
+}
+
+// Load reads and parses the config file at path
+func Load(path string) (*Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse parses the contents of a config file
+func Parse(data []byte) (*Config, error) {
+	var cfg Config
+	if err := yaml.UnmarshalStrict(data, &cfg); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// Validate checks that every entry has its required fields and that
+// watcher and alert names are unique. Watcher names are unique across
+// chains, not just within one
+func (cfg *Config) Validate() error {
+	seen := make(map[string]bool)
+	for i, wc := range cfg.Watchers {
+		if wc.Chain == "" {
+			return fmt.Errorf("watchers[%d]: chain is required", i)
+		}
+		if seen[wc.InstanceName()] {
+			return fmt.Errorf("watchers[%d]: duplicate watcher %q", i, wc.InstanceName())
+		}
+		seen[wc.InstanceName()] = true
+	}
+	for i, sc := range cfg.Sinks {
+		if sc.Type == "" {
+			return fmt.Errorf("sinks[%d]: type is required", i)
+		}
+	}
+	for i, sc := range cfg.Pipeline {
+		if sc.Stage == "" {
+			return fmt.Errorf("pipeline[%d]: stage is required", i)
+		}
+	}
+	seenAlerts := make(map[string]bool)
+	for i, ac := range cfg.Alerts {
+		if ac.Name == "" {
+			return fmt.Errorf("alerts[%d]: name is required", i)
+		}
+		if seenAlerts[ac.Name] {
+			return fmt.Errorf("alerts[%d]: duplicate alert %q", i, ac.Name)
+		}
+		seenAlerts[ac.Name] = true
+	}
+	for i, nc := range cfg.Notifiers {
+		if nc.Type == "" {
+			return fmt.Errorf("notifiers[%d]: type is required", i)
+		}
+	}
+	for i, tc := range cfg.Tokens {
+		if tc.Network == "" || tc.Symbol == "" {
+			return fmt.Errorf("tokens[%d]: network and symbol are required", i)
+		}
+	}
+
+	return nil
+}
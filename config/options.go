@@ -0,0 +1,114 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/codegangsta/cli"
+)
+
+// Options holds the free-form options of a config file section. Values are
+// whatever the yaml decoder produced, so the getters convert leniently
+type Options map[string]interface{}
+
+// Has returns true when key is set
+func (o Options) Has(key string) bool {
+	_, ok := o[key]
+	return ok
+}
+
+// String returns the option as a string or def when it isn't set
+func (o Options) String(key, def string) string {
+	v, ok := o[key]
+	if !ok || v == nil {
+		return def
+	}
+	return fmt.Sprint(v)
+}
+
+// Int64 returns the option as an int64 or def when it isn't set or isn't a
+// number
+func (o Options) Int64(key string, def int64) int64 {
+	switch v := o[key].(type) {
+	case int:
+		return int64(v)
+	case int64:
+		return v
+	case uint64:
+		return int64(v)
+	case float64:
+		return int64(v)
+	case string:
+		if i, err := strconv.ParseInt(v, 0, 64); err == nil {
+			return i
+		}
+	}
+	return def
+}
+
+// Bool returns the option as a bool or def when it isn't set
+func (o Options) Bool(key string, def bool) bool {
+	switch v := o[key].(type) {
+	case bool:
+		return v
+	case string:
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return def
+}
+
+// Duration returns the option as a time.Duration. Strings are parsed with
+// time.ParseDuration and plain numbers are taken as seconds
+func (o Options) Duration(key string, def time.Duration) time.Duration {
+	switch v := o[key].(type) {
+	case string:
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+	case int, int64, uint64, float64:
+		return time.Duration(o.Int64(key, 0)) * time.Second
+	}
+	return def
+}
+
+// Strings returns the option as a slice of strings. A single value is
+// returned as a slice of one
+func (o Options) Strings(key string) []string {
+	switch v := o[key].(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		out := make([]string, len(v))
+		for i, s := range v {
+			out[i] = fmt.Sprint(s)
+		}
+		return out
+	case []string:
+		return v
+	default:
+		return []string{fmt.Sprint(v)}
+	}
+}
+
+// WithFlags returns a copy of the options with the value of every cli flag
+// in flags that is set copied over the option it maps to. The cli.Context
+// may be nil
+func (o Options) WithFlags(c *cli.Context, flags map[string]string) Options {
+	out := make(Options, len(o))
+	for k, v := range o {
+		out[k] = v
+	}
+
+	if c == nil {
+		return out
+	}
+	for flag, key := range flags {
+		if c.IsSet(flag) {
+			out[key] = c.String(flag)
+		}
+	}
+	return out
+}
@@ -0,0 +1,337 @@
+package filter
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Expr is a compiled filter expression. Expressions compare the fields of
+// an action with literals and combine the comparisons:
+//
+//	to.label == "exchange" && symbol == "ETH" && amount >= 100
+//	!(from.category == "exchange") || fiat.value > 1000000
+//	eos.account == "eosio.token" && eos.name matches "^(transfer|issue)$"
+//
+// Strings compare case insensitively and support ==, != and matches, which
+// takes a regular expression. Numbers support ==, !=, <, <=, > and >=. A
+// comparison of a number the action doesn't have, like the fiat value of a
+// token without a price, is false
+type Expr struct {
+	src  string
+	root node
+}
+
+// Compile parses and type checks an expression
+func Compile(src string) (*Expr, error) {
+	p := &parser{lex: lexer{src: src}}
+	p.next()
+	root, err := p.parseOr()
+	if p.err != nil {
+		err = p.err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("filter %q: %v", src, err)
+	}
+	if p.tok.kind != tokEOF {
+		return nil, fmt.Errorf("filter %q: unexpected %s", src, p.tok)
+	}
+	return &Expr{src: src, root: root}, nil
+}
+
+// Match returns true when the action of s matches the expression
+func (e *Expr) Match(s Subject) bool {
+	return e.root.eval(s)
+}
+
+// String returns the source of the expression
+func (e *Expr) String() string {
+	return e.src
+}
+
+type node interface {
+	eval(s Subject) bool
+}
+
+type andNode struct{ l, r node }
+
+func (n andNode) eval(s Subject) bool { return n.l.eval(s) && n.r.eval(s) }
+
+type orNode struct{ l, r node }
+
+func (n orNode) eval(s Subject) bool { return n.l.eval(s) || n.r.eval(s) }
+
+type notNode struct{ x node }
+
+func (n notNode) eval(s Subject) bool { return !n.x.eval(s) }
+
+type stringNode struct {
+	field field
+	op    string
+	value string
+	re    *regexp.Regexp
+}
+
+func (n stringNode) eval(s Subject) bool {
+	v := n.field.str(s)
+	switch n.op {
+	case "==":
+		return strings.EqualFold(v, n.value)
+	case "!=":
+		return !strings.EqualFold(v, n.value)
+	}
+	return n.re.MatchString(v)
+}
+
+type numberNode struct {
+	field field
+	op    string
+	value *big.Rat
+}
+
+func (n numberNode) eval(s Subject) bool {
+	v := n.field.num(s)
+	if v == nil {
+		return false
+	}
+	c := v.Cmp(n.value)
+	switch n.op {
+	case "==":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	}
+	return c >= 0
+}
+
+type parser struct {
+	lex lexer
+	tok token
+	err error
+}
+
+func (p *parser) next() {
+	if p.err == nil {
+		p.tok, p.err = p.lex.next()
+	}
+}
+
+func (p *parser) parseOr() (node, error) {
+	l, err := p.parseAnd()
+	for err == nil && p.tok.is(tokOp, "||") {
+		p.next()
+		var r node
+		if r, err = p.parseAnd(); err == nil {
+			l = orNode{l, r}
+		}
+	}
+	return l, err
+}
+
+func (p *parser) parseAnd() (node, error) {
+	l, err := p.parseUnary()
+	for err == nil && p.tok.is(tokOp, "&&") {
+		p.next()
+		var r node
+		if r, err = p.parseUnary(); err == nil {
+			l = andNode{l, r}
+		}
+	}
+	return l, err
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	switch {
+	case p.tok.is(tokOp, "!"):
+		p.next()
+		x, err := p.parseUnary()
+		return notNode{x}, err
+
+	case p.tok.kind == tokLParen:
+		p.next()
+		x, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokRParen {
+			return nil, fmt.Errorf("expected ) instead of %s", p.tok)
+		}
+		p.next()
+		return x, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	if p.tok.kind != tokIdent {
+		return nil, fmt.Errorf("expected a field instead of %s", p.tok)
+	}
+	f, ok := fields[p.tok.text]
+	if !ok {
+		return nil, fmt.Errorf("unknown field %s", p.tok.text)
+	}
+	name := p.tok.text
+
+	p.next()
+	op := p.tok
+	if op.kind != tokOp || op.text == "&&" || op.text == "||" || op.text == "!" {
+		return nil, fmt.Errorf("expected a comparison after %s instead of %s", name, op)
+	}
+	p.next()
+	lit := p.tok
+	if p.err != nil {
+		return nil, p.err
+	}
+	p.next()
+
+	if f.num != nil {
+		if op.text == "matches" {
+			return nil, fmt.Errorf("%s is a number, it can't be matched", name)
+		}
+		if lit.kind != tokNumber {
+			return nil, fmt.Errorf("%s is compared with a number, not %s", name, lit)
+		}
+		value, _ := new(big.Rat).SetString(lit.text)
+		return numberNode{field: f, op: op.text, value: value}, nil
+	}
+
+	if lit.kind != tokString {
+		return nil, fmt.Errorf("%s is compared with a string, not %s", name, lit)
+	}
+	n := stringNode{field: f, op: op.text, value: lit.text}
+	switch op.text {
+	case "==", "!=":
+	case "matches":
+		re, err := regexp.Compile(lit.text)
+		if err != nil {
+			return nil, err
+		}
+		n.re = re
+	default:
+		return nil, fmt.Errorf("%s is a string, it only supports ==, != and matches", name)
+	}
+	return n, nil
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokOp
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func (t token) is(kind tokenKind, text string) bool {
+	return t.kind == kind && t.text == text
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of filter"
+	case tokString:
+		return strconv.Quote(t.text)
+	}
+	return t.text
+}
+
+// ops are the operators, longest first so <= isn't read as <
+var ops = []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!"}
+
+type lexer struct {
+	src string
+	pos int
+}
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.src) && unicode.IsSpace(rune(l.src[l.pos])) {
+		l.pos++
+	}
+	if l.pos >= len(l.src) {
+		return token{kind: tokEOF}, nil
+	}
+
+	rest := l.src[l.pos:]
+	c := rest[0]
+	switch {
+	case c == '(':
+		l.pos++
+		return token{kind: tokLParen, text: "("}, nil
+	case c == ')':
+		l.pos++
+		return token{kind: tokRParen, text: ")"}, nil
+
+	case c == '"':
+		// Find the closing quote, skipping escaped ones
+		end := 1
+		for end < len(rest) && rest[end] != '"' {
+			if rest[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end >= len(rest) {
+			return token{}, fmt.Errorf("unterminated string at %d", l.pos)
+		}
+		s, err := strconv.Unquote(rest[:end+1])
+		if err != nil {
+			return token{}, fmt.Errorf("invalid string at %d: %v", l.pos, err)
+		}
+		l.pos += end + 1
+		return token{kind: tokString, text: s}, nil
+
+	case c == '-' || c == '.' || (c >= '0' && c <= '9'):
+		end := 1
+		for end < len(rest) && (rest[end] == '.' || (rest[end] >= '0' && rest[end] <= '9')) {
+			end++
+		}
+		if _, ok := new(big.Rat).SetString(rest[:end]); !ok {
+			return token{}, fmt.Errorf("invalid number %q at %d", rest[:end], l.pos)
+		}
+		l.pos += end
+		return token{kind: tokNumber, text: rest[:end]}, nil
+
+	case c == '_' || unicode.IsLetter(rune(c)):
+		end := 1
+		for end < len(rest) && (rest[end] == '_' || rest[end] == '.' ||
+			unicode.IsLetter(rune(rest[end])) || unicode.IsDigit(rune(rest[end]))) {
+			end++
+		}
+		l.pos += end
+		if rest[:end] == "matches" {
+			return token{kind: tokOp, text: "matches"}, nil
+		}
+		return token{kind: tokIdent, text: rest[:end]}, nil
+	}
+
+	for _, op := range ops {
+		if strings.HasPrefix(rest, op) {
+			l.pos += len(op)
+			return token{kind: tokOp, text: op}, nil
+		}
+	}
+	return token{}, fmt.Errorf("unexpected %q at %d", c, l.pos)
+}
@@ -0,0 +1,188 @@
+package filter
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/auser/bitping/types"
+)
+
+// Subject is an action and what it belongs to, which is what expressions
+// are evaluated against
+type Subject struct {
+	Block       *types.Block
+	Transaction *types.Transaction
+	Action      *types.Action
+}
+
+// field reads a string or, when num is set, a number of a subject. Numbers
+// are nil when the subject doesn't have them
+type field struct {
+	str func(s Subject) string
+	num func(s Subject) *big.Rat
+}
+
+func stringField(f func(s Subject) string) field {
+	return field{str: f}
+}
+
+func numberField(f func(s Subject) *big.Rat) field {
+	return field{num: f}
+}
+
+// fields are the fields expressions can use. label is short for category
+var fields = map[string]field{
+	"network":      stringField(func(s Subject) string { return s.Block.Network }),
+	"block.hash":   stringField(func(s Subject) string { return s.Block.Hash }),
+	"block.number": numberField(func(s Subject) *big.Rat { return new(big.Rat).SetInt64(s.Block.Number) }),
+	"block.time":   numberField(func(s Subject) *big.Rat { return new(big.Rat).SetInt64(s.Block.Time) }),
+	"tx.hash":      stringField(func(s Subject) string { return s.Transaction.Hash }),
+
+	"address":   stringField(func(s Subject) string { return s.Action.Address }),
+	"from":      stringField(func(s Subject) string { return s.Action.From }),
+	"to":        stringField(func(s Subject) string { return s.Action.To }),
+	"symbol":    stringField(func(s Subject) string { return s.Action.Symbol }),
+	"value":     numberField(func(s Subject) *big.Rat { return bigRat(s.Action.Value) }),
+	"amount":    numberField(func(s Subject) *big.Rat { return decimal(s.Action.Amount) }),
+	"precision": numberField(func(s Subject) *big.Rat { return new(big.Rat).SetInt64(int64(s.Action.Precision)) }),
+
+	"fiat.currency": stringField(func(s Subject) string { return fiat(s).Currency }),
+	"fiat.price":    numberField(func(s Subject) *big.Rat { return decimal(fiat(s).Price) }),
+	"fiat.value":    numberField(func(s Subject) *big.Rat { return decimal(fiat(s).Value) }),
+
+	"from.label":    stringField(func(s Subject) string { return label(s.Action.FromLabel).Category }),
+	"from.category": stringField(func(s Subject) string { return label(s.Action.FromLabel).Category }),
+	"from.entity":   stringField(func(s Subject) string { return label(s.Action.FromLabel).Entity }),
+	"to.label":      stringField(func(s Subject) string { return label(s.Action.ToLabel).Category }),
+	"to.category":   stringField(func(s Subject) string { return label(s.Action.ToLabel).Category }),
+	"to.entity":     stringField(func(s Subject) string { return label(s.Action.ToLabel).Entity }),
+	"from.ens":      stringField(func(s Subject) string { return account(s.Action.FromAccount).ENS }),
+	"to.ens":        stringField(func(s Subject) string { return account(s.Action.ToAccount).ENS }),
+
+	"eos.account":    stringField(func(s Subject) string { return eos(s).Account }),
+	"eos.name":       stringField(func(s Subject) string { return eos(s).Name }),
+	"actor":          stringField(func(s Subject) string { return actor(s).Actor }),
+	"actor.label":    stringField(func(s Subject) string { return label(actor(s).ActorLabel).Category }),
+	"actor.category": stringField(func(s Subject) string { return label(actor(s).ActorLabel).Category }),
+	"actor.entity":   stringField(func(s Subject) string { return label(actor(s).ActorLabel).Entity }),
+	"actor.creator":  stringField(func(s Subject) string { return eosAccount(actor(s).ActorAccount).Creator }),
+	"actor.cpu.used": numberField(func(s Subject) *big.Rat { return resource(actor(s).ActorAccount, cpuUsed) }),
+	"actor.cpu.max":  numberField(func(s Subject) *big.Rat { return resource(actor(s).ActorAccount, cpuMax) }),
+	"actor.net.used": numberField(func(s Subject) *big.Rat { return resource(actor(s).ActorAccount, netUsed) }),
+	"actor.net.max":  numberField(func(s Subject) *big.Rat { return resource(actor(s).ActorAccount, netMax) }),
+	"actor.ram.used": numberField(func(s Subject) *big.Rat { return resource(actor(s).ActorAccount, ramUsed) }),
+	"actor.ram.max":  numberField(func(s Subject) *big.Rat { return resource(actor(s).ActorAccount, ramMax) }),
+}
+
+// Fields returns the names of the fields expressions can use, sorted
+func Fields() []string {
+	out := make([]string, 0, len(fields))
+	for name := range fields {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// NumberField returns the reader of the number field name
+func NumberField(name string) (func(s Subject) *big.Rat, error) {
+	f, ok := fields[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown field %s", name)
+	}
+	if f.num == nil {
+		return nil, fmt.Errorf("%s is not a number", name)
+	}
+	return f.num, nil
+}
+
+// StringField returns the reader of the string field name
+func StringField(name string) (func(s Subject) string, error) {
+	f, ok := fields[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown field %s", name)
+	}
+	if f.str == nil {
+		return nil, fmt.Errorf("%s is not a string", name)
+	}
+	return f.str, nil
+}
+
+func bigRat(i *types.BigInt) *big.Rat {
+	if i == nil {
+		return nil
+	}
+	b := big.Int(*i)
+	return new(big.Rat).SetInt(&b)
+}
+
+func decimal(s string) *big.Rat {
+	if s == "" {
+		return nil
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil
+	}
+	return r
+}
+
+func fiat(s Subject) types.FiatValue {
+	if s.Action.Fiat == nil {
+		return types.FiatValue{}
+	}
+	return *s.Action.Fiat
+}
+
+func label(l *types.Label) types.Label {
+	if l == nil {
+		return types.Label{}
+	}
+	return *l
+}
+
+func account(a *types.Account) types.Account {
+	if a == nil {
+		return types.Account{}
+	}
+	return *a
+}
+
+func eosAccount(a *types.EOSAccount) types.EOSAccount {
+	if a == nil {
+		return types.EOSAccount{}
+	}
+	return *a
+}
+
+func cpuUsed(a *types.EOSAccount) int64 { return a.CPU.Used }
+func cpuMax(a *types.EOSAccount) int64  { return a.CPU.Max }
+func netUsed(a *types.EOSAccount) int64 { return a.Net.Used }
+func netMax(a *types.EOSAccount) int64  { return a.Net.Max }
+func ramUsed(a *types.EOSAccount) int64 { return a.RAMUsage }
+func ramMax(a *types.EOSAccount) int64  { return a.RAMQuota }
+
+// resource reads a resource of an account, which is missing when the
+// account has no metadata
+func resource(a *types.EOSAccount, f func(a *types.EOSAccount) int64) *big.Rat {
+	if a == nil {
+		return nil
+	}
+	return new(big.Rat).SetInt64(f(a))
+}
+
+func eos(s Subject) types.EOSAction {
+	if s.Action.EOSAction == nil {
+		return types.EOSAction{}
+	}
+	return *s.Action.EOSAction
+}
+
+// actor is the first authorization of an EOS action
+func actor(s Subject) types.EOSPermissionLevel {
+	if s.Action.EOSAction == nil || len(s.Action.EOSAction.Authorization) == 0 {
+		return types.EOSPermissionLevel{}
+	}
+	return s.Action.EOSAction.Authorization[0]
+}
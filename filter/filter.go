@@ -0,0 +1,131 @@
+package filter
+
+import (
+	"fmt"
+
+	"github.com/auser/bitping/config"
+	"github.com/auser/bitping/types"
+)
+
+// Filter is a named expression applied to the blocks of some networks
+type Filter struct {
+	Name string
+	// Networks the filter applies to, all of them when empty
+	Networks []string
+	Expr     *Expr
+}
+
+// AppliesTo returns true when the filter applies to blocks of network
+func (f Filter) AppliesTo(network string) bool {
+	if len(f.Networks) == 0 {
+		return true
+	}
+	for _, n := range f.Networks {
+		if n == network {
+			return true
+		}
+	}
+	return false
+}
+
+// Filters is the pipeline stage marking the transactions with an action
+// that matches one of the filters applying to their block. It doesn't drop
+// anything so the stores and sinks still get whole blocks, Matching does
+// that for the outputs that only want the matches
+type Filters struct {
+	filters []Filter
+}
+
+// New returns the stage applying filters
+func New(filters ...Filter) *Filters {
+	return &Filters{filters: filters}
+}
+
+// FromConfig compiles the filters of the config file. It returns nil when
+// there are none
+func FromConfig(cfg *config.Config) (*Filters, error) {
+	if len(cfg.Filters) == 0 {
+		return nil, nil
+	}
+
+	var filters []Filter
+	for i, fc := range cfg.Filters {
+		expr, err := Compile(fc.Expr)
+		if err != nil {
+			return nil, fmt.Errorf("filters[%d]: %v", i, err)
+		}
+		name := fc.Name
+		if name == "" {
+			name = fmt.Sprintf("filters[%d]", i)
+		}
+		filters = append(filters, Filter{Name: name, Networks: fc.Networks, Expr: expr})
+	}
+	return New(filters...), nil
+}
+
+// Name returns the name of the pipeline stage
+func (f *Filters) Name() string {
+	return "filters"
+}
+
+// Process sets the Matches of the transactions of block. Blocks of
+// networks no filter applies to are left alone
+func (f *Filters) Process(block *types.Block) error {
+	applying := f.applying(block.Network)
+	if len(applying) == 0 {
+		return nil
+	}
+
+	// The watchers may still hold the transactions, don't mark them in place
+	txs := make([]types.Transaction, len(block.Transactions))
+	copy(txs, block.Transactions)
+	for i := range txs {
+		txs[i].Matches = matches(applying, block, &txs[i])
+	}
+	block.Transactions = txs
+	return nil
+}
+
+// Matching returns block with only the transactions a filter matched.
+// Blocks are returned even when no transaction is left, so the outputs
+// still see every height, and blocks of networks no filter applies to are
+// returned whole
+func (f *Filters) Matching(block types.Block) types.Block {
+	if len(f.applying(block.Network)) == 0 {
+		return block
+	}
+
+	kept := make([]types.Transaction, 0, len(block.Transactions))
+	for _, tx := range block.Transactions {
+		if len(tx.Matches) > 0 {
+			kept = append(kept, tx)
+		}
+	}
+	block.Transactions = kept
+	return block
+}
+
+// applying returns the filters applying to the blocks of network
+func (f *Filters) applying(network string) []Filter {
+	var out []Filter
+	for _, filter := range f.filters {
+		if filter.AppliesTo(network) {
+			out = append(out, filter)
+		}
+	}
+	return out
+}
+
+// matches returns the names of the filters an action of tx matches
+func matches(filters []Filter, block *types.Block, tx *types.Transaction) []string {
+	var out []string
+	for _, filter := range filters {
+		for j := range tx.Actions {
+			if filter.Expr.Match(Subject{Block: block, Transaction: tx, Action: &tx.Actions[j]}) {
+				out = append(out, filter.Name)
+				break
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,123 @@
+package grpcserver
+
+import (
+	"context"
+	"log"
+	"net"
+
+	"github.com/auser/bitping/pb"
+	"github.com/auser/bitping/storage"
+	"github.com/auser/bitping/stream"
+	"github.com/auser/bitping/types"
+	"github.com/codegangsta/cli"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server implements the Bitping gRPC service defined in proto/bitping.proto.
+// Subscribe streams the blocks published to a stream.Hub and GetBlock reads
+// them from a storage.Store
+type Server struct {
+	pb.UnimplementedBitpingServer
+
+	Listen string
+	store  *storage.Store
+	hub    *stream.Hub
+}
+
+// AddCLIFlags adds the gRPC flags
+func AddCLIFlags(fs []cli.Flag) []cli.Flag {
+	return append(fs,
+		cli.StringFlag{
+			Name:   "grpc-listen",
+			Usage:  "address the grpc service listens on, off when empty",
+			EnvVar: "BITPING_GRPC_LISTEN",
+		},
+	)
+}
+
+// New creates a Server listening on listen
+func New(store *storage.Store, hub *stream.Hub, listen string) *Server {
+	return &Server{
+		Listen: listen,
+		store:  store,
+		hub:    hub,
+	}
+}
+
+// ListenAndServe serves the service on Listen
+func (s *Server) ListenAndServe() error {
+	lis, err := net.Listen("tcp", s.Listen)
+	if err != nil {
+		return err
+	}
+
+	srv := grpc.NewServer()
+	pb.RegisterBitpingServer(srv, s)
+
+	log.Printf("gRPC listening on %s", s.Listen)
+	return srv.Serve(lis)
+}
+
+// Subscribe streams the delivered blocks matching filter until the client
+// goes away or falls behind
+func (s *Server) Subscribe(filter *pb.Filter, out pb.Bitping_SubscribeServer) error {
+	sub := stream.Subscription{
+		Networks:  make(map[string]bool),
+		Kind:      stream.KindBlock,
+		Addresses: make(map[string]bool),
+		Raw:       true,
+	}
+	for _, network := range filter.Networks {
+		sub.Networks[network] = true
+	}
+	for _, address := range filter.Addresses {
+		sub.Addresses[types.NormalizeAddress(address)] = true
+	}
+
+	client := s.hub.Subscribe(sub)
+	defer s.hub.Unsubscribe(client)
+
+	for {
+		select {
+		case m := <-client.Messages():
+			if err := out.Send(pb.FromBlock(*m.Block)); err != nil {
+				return err
+			}
+		case <-client.Done():
+			if err := client.Err(); err != nil {
+				return status.Error(codes.ResourceExhausted, err.Error())
+			}
+			return nil
+		case <-out.Context().Done():
+			return nil
+		}
+	}
+}
+
+// GetBlock returns a stored block by number or hash
+func (s *Server) GetBlock(ctx context.Context, req *pb.GetBlockRequest) (*pb.Block, error) {
+	var (
+		block types.Block
+		err   error
+	)
+
+	switch id := req.Id.(type) {
+	case *pb.GetBlockRequest_Number:
+		block, err = s.store.Block(req.Network, id.Number)
+	case *pb.GetBlockRequest_Hash:
+		block, err = s.store.BlockByHash(req.Network, id.Hash)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "number or hash is required")
+	}
+
+	if err == storage.ErrNotFound {
+		return nil, status.Error(codes.NotFound, "block not found")
+	}
+	if err != nil {
+		log.Printf("gRPC storage error: %v", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	return pb.FromBlock(block), nil
+}
@@ -1,6 +1,7 @@
 package iface
 
 import (
+	"github.com/auser/bitping/config"
 	"github.com/codegangsta/cli"
 )
 
@@ -16,3 +17,12 @@ type Configurable interface {
 	// Configure this object using the cli.Context
 	Configure(c *cli.Context) error
 }
+
+// FileConfigurable is an interface for configuring using the options of a
+// config file section
+type FileConfigurable interface {
+	// Configure this object using options read from a config file. Any
+	// cli.Flags set in the cli.Context override the file values. The
+	// cli.Context may be nil
+	ConfigureFromOptions(opts config.Options, c *cli.Context) error
+}
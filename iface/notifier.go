@@ -0,0 +1,19 @@
+package iface
+
+import (
+	"github.com/auser/bitping/types"
+)
+
+// Notifier sends alerts to people, like a chat channel or an on-call
+// service
+type Notifier interface {
+	// Notifiers are declared in the config file, the cli.Context may be nil
+	FileConfigurable
+
+	// Name returns the name of the notifier
+	Name() string
+
+	// Notify sends a batch of alerts and only returns once the channel
+	// accepted them
+	Notify(alerts []types.Alert) error
+}
@@ -0,0 +1,20 @@
+package iface
+
+import (
+	"math/big"
+	"time"
+)
+
+// PriceProvider prices tokens in fiat currencies
+type PriceProvider interface {
+	// Providers are configured from the prices section of the config file,
+	// the cli.Context may be nil
+	FileConfigurable
+
+	// Name returns the name of the provider
+	Name() string
+
+	// Price returns the price of a whole token of symbol in currency at the
+	// given time. Providers return prices.ErrNoPrice when they have none
+	Price(symbol, currency string, at time.Time) (*big.Rat, error)
+}
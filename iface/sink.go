@@ -0,0 +1,31 @@
+package iface
+
+import (
+	"github.com/auser/bitping/types"
+)
+
+// Sink delivers unified blocks to a destination outside of bitping, like a
+// message queue
+type Sink interface {
+	// Sinks are declared in the config file, the cli.Context may be nil
+	FileConfigurable
+
+	// Name returns the name of the sink
+	Name() string
+
+	// Publish delivers block and only returns once the destination
+	// acknowledged it
+	Publish(block types.Block) error
+
+	// Close flushes and disconnects the sink
+	Close() error
+}
+
+// CheckpointedSink is implemented by sinks that record what they delivered
+type CheckpointedSink interface {
+	Sink
+
+	// Resume returns the height each network needs blocks from to catch up
+	// on what the sink missed
+	Resume() map[string]int64
+}
@@ -0,0 +1,19 @@
+package iface
+
+import (
+	"github.com/auser/bitping/types"
+)
+
+// Stage enriches blocks on their way from the watchers to the outputs.
+// Stages run in the order of the pipeline and every block goes through
+// them in the order it was delivered
+type Stage interface {
+	// Name returns the name of the stage, used in the config file and in
+	// metrics
+	Name() string
+
+	// Process mutates or annotates block. An error is logged and counted,
+	// the block still goes on to the next stage. Stages that run with a
+	// concurrency above one get several blocks at the same time
+	Process(block *types.Block) error
+}
@@ -6,8 +6,9 @@ import (
 
 // Watchers watch a blockchain and pipes blockchain blocks and errors
 type Watcher interface {
-	// Has to be configured
+	// Has to be configured, either from cli flags or a config file
 	Configurable
+	FileConfigurable
 
 	// Name returns the name of the watcher
 	Name() string
@@ -16,3 +17,25 @@ type Watcher interface {
 	// It pipes back the unified block type or errors
 	Watch(blockCh chan types.Block, errCh chan error)
 }
+
+// PendingWatcher is implemented by watchers that can also pipe back
+// transactions before they are included in a block
+type PendingWatcher interface {
+	// WatchPending should start watching pending transactions. It pipes back
+	// the unified transaction without block fields, and again once it's
+	// mined, dropped or replaced
+	WatchPending(txCh chan types.Transaction, errCh chan error)
+
+	// PendingEnabled returns true when the watcher was configured to watch
+	// pending transactions
+	PendingEnabled() bool
+}
+
+// ResumableWatcher is implemented by watchers that can start from a past
+// height, so blocks a sink is missing are delivered again
+type ResumableWatcher interface {
+	// ResumeFrom makes Watch start at the height of the watcher's network
+	// in heights, when it's below the one it would start at. It's called
+	// before Watch
+	ResumeFrom(heights map[string]int64)
+}
@@ -0,0 +1,210 @@
+package labels
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/auser/bitping/config"
+	"github.com/auser/bitping/types"
+	"github.com/codegangsta/cli"
+)
+
+// Entry is a labelled address as it's written in a labels file. An empty
+// Network labels the address on every network
+type Entry struct {
+	Network  string `json:"network"`
+	Address  string `json:"address"`
+	Entity   string `json:"entity"`
+	Category string `json:"category"`
+}
+
+// csvColumns is the header of a csv labels file
+var csvColumns = []string{"network", "address", "entity", "category"}
+
+// labelFlags maps the cli flags to the config file options they override
+var labelFlags = map[string]string{
+	"labels": "files",
+}
+
+// Labels tags the addresses and accounts of actions with the entity they
+// belong to. It's safe for concurrent use
+type Labels struct {
+	sync.RWMutex
+	labels map[string]types.Label
+}
+
+// New returns an empty set of labels
+func New() *Labels {
+	return &Labels{labels: make(map[string]types.Label)}
+}
+
+// AddCLIFlags adds the labels flags
+func AddCLIFlags(fs []cli.Flag) []cli.Flag {
+	return append(fs,
+		cli.StringFlag{
+			Name:   "labels",
+			Usage:  "csv or json file of network,address,entity,category labels",
+			EnvVar: "BITPING_LABELS",
+		},
+	)
+}
+
+// FromCLI loads the labels files given with --labels or in the labels
+// section of the config file. It returns nil when there are none
+func FromCLI(c *cli.Context, cfg *config.Config) (*Labels, error) {
+	files := cfg.Labels.WithFlags(c, labelFlags).Strings("files")
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	l := New()
+	for _, path := range files {
+		if err := l.Load(path); err != nil {
+			return nil, fmt.Errorf("labels %s: %v", path, err)
+		}
+	}
+	log.Printf("Loaded %d labels", l.Len())
+	return l, nil
+}
+
+// Add labels address on network, or on every network when it's empty.
+// Addresses are compared case insensitively
+func (l *Labels) Add(e Entry) {
+	l.Lock()
+	defer l.Unlock()
+	l.labels[key(e.Network, e.Address)] = types.Label{Entity: e.Entity, Category: e.Category}
+}
+
+// Len returns how many addresses are labelled
+func (l *Labels) Len() int {
+	l.RLock()
+	defer l.RUnlock()
+	return len(l.labels)
+}
+
+// Lookup returns the label of address on network, falling back on its
+// label for every network
+func (l *Labels) Lookup(network, address string) (types.Label, bool) {
+	if address == "" {
+		return types.Label{}, false
+	}
+
+	l.RLock()
+	defer l.RUnlock()
+	if label, ok := l.labels[key(network, address)]; ok {
+		return label, true
+	}
+	label, ok := l.labels[key("", address)]
+	return label, ok
+}
+
+// Load adds the labels of a .csv or .json file
+func (l *Labels) Load(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return l.LoadCSV(f)
+	case ".json":
+		return l.LoadJSON(f)
+	}
+	return fmt.Errorf("labels files must be .csv or .json")
+}
+
+// LoadCSV adds the labels of a csv file with a
+// network,address,entity,category header
+func (l *Labels) LoadCSV(r io.Reader) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(csvColumns)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return fmt.Errorf("header: %v", err)
+	}
+	for i, column := range csvColumns {
+		if strings.ToLower(header[i]) != column {
+			return fmt.Errorf("header must be %s", strings.Join(csvColumns, ","))
+		}
+	}
+
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		e := Entry{Network: record[0], Address: record[1], Entity: record[2], Category: record[3]}
+		if e.Address == "" {
+			return fmt.Errorf("line %d: address is required", line)
+		}
+		l.Add(e)
+	}
+}
+
+// LoadJSON adds the labels of a json array of entries
+func (l *Labels) LoadJSON(r io.Reader) error {
+	var entries []Entry
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return err
+	}
+	for i, e := range entries {
+		if e.Address == "" {
+			return fmt.Errorf("entry %d: address is required", i)
+		}
+		l.Add(e)
+	}
+	return nil
+}
+
+// Name returns the name of the pipeline stage
+func (l *Labels) Name() string {
+	return "labels"
+}
+
+// Process tags the senders and recipients of the actions of block, and the
+// actors authorizing EOS actions
+func (l *Labels) Process(block *types.Block) error {
+	for i := range block.Transactions {
+		actions := block.Transactions[i].Actions
+		for j := range actions {
+			a := &actions[j]
+			a.FromLabel = l.label(block.Network, a.From)
+			a.ToLabel = l.label(block.Network, a.To)
+
+			if a.EOSAction == nil {
+				continue
+			}
+			for k := range a.EOSAction.Authorization {
+				auth := &a.EOSAction.Authorization[k]
+				auth.ActorLabel = l.label(block.Network, auth.Actor)
+			}
+		}
+	}
+	return nil
+}
+
+func (l *Labels) label(network, address string) *types.Label {
+	label, ok := l.Lookup(network, address)
+	if !ok {
+		return nil
+	}
+	return &label
+}
+
+func key(network, address string) string {
+	return network + "/" + strings.ToLower(address)
+}
@@ -0,0 +1,14 @@
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/auser/bitping/cmd"
+)
+
+func main() {
+	if err := cmd.NewApp().Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,86 @@
+package metadata
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Cache remembers lookups for TTL, keeping the Size most recently used.
+// Lookups that found nothing are remembered too, so addresses without a
+// name don't hit the node for every action. It's safe for concurrent use
+type Cache struct {
+	TTL  time.Duration
+	Size int
+
+	sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	now     func() time.Time
+}
+
+type cacheEntry struct {
+	key     string
+	value   interface{}
+	expires time.Time
+}
+
+// NewCache returns a cache keeping size entries for ttl
+func NewCache(size int, ttl time.Duration) *Cache {
+	if size <= 0 {
+		size = DefaultCacheSize
+	}
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Cache{
+		TTL:     ttl,
+		Size:    size,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+		now:     time.Now,
+	}
+}
+
+// Get returns the cached value of key, calling lookup when it's missing or
+// expired. Errors of lookup aren't cached
+func (c *Cache) Get(key string, lookup func() (interface{}, error)) (interface{}, error) {
+	c.Lock()
+	if el, ok := c.entries[key]; ok {
+		entry := el.Value.(*cacheEntry)
+		if c.now().Before(entry.expires) {
+			c.lru.MoveToFront(el)
+			c.Unlock()
+			return entry.value, nil
+		}
+		c.lru.Remove(el)
+		delete(c.entries, key)
+	}
+	c.Unlock()
+
+	value, err := lookup()
+	if err != nil {
+		return nil, err
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	if el, ok := c.entries[key]; ok {
+		// Looked up concurrently, the latest lookup wins
+		c.lru.Remove(el)
+	}
+	c.entries[key] = c.lru.PushFront(&cacheEntry{key: key, value: value, expires: c.now().Add(c.TTL)})
+	for c.lru.Len() > c.Size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+	return value, nil
+}
+
+// Len returns how many entries are cached, expired ones included
+func (c *Cache) Len() int {
+	c.Lock()
+	defer c.Unlock()
+	return c.lru.Len()
+}
@@ -0,0 +1,146 @@
+package metadata
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// DefaultENSRegistry is the address of the ENS registry on mainnet and the
+// public testnets
+const DefaultENSRegistry = "0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e"
+
+// Selectors of the ENS registry and resolver functions used
+var (
+	selectorResolver = []byte{0x01, 0x78, 0xb8, 0xbf} // resolver(bytes32)
+	selectorName     = []byte{0x69, 0x1f, 0x34, 0x31} // name(bytes32)
+	selectorAddr     = []byte{0x3b, 0x3b, 0x57, 0xde} // addr(bytes32)
+)
+
+// ENS reverse resolves ethereum addresses to their primary ENS name with
+// eth_call on a node
+type ENS struct {
+	Registry common.Address
+	Timeout  time.Duration
+
+	rpc *rpc.Client
+}
+
+// NewENS returns a resolver calling the registry through node
+func NewENS(node, registry string, timeout time.Duration) (*ENS, error) {
+	if !common.IsHexAddress(registry) {
+		return nil, fmt.Errorf("invalid ENS registry %q", registry)
+	}
+	client, err := rpc.Dial(node)
+	if err != nil {
+		return nil, err
+	}
+	return &ENS{Registry: common.HexToAddress(registry), Timeout: timeout, rpc: client}, nil
+}
+
+// Name returns the primary name of address, or "" when it has none. Names
+// are checked against the forward record, a reverse record claiming a name
+// that doesn't resolve back to the address is ignored
+func (e *ENS) Name(address string) (string, error) {
+	if !common.IsHexAddress(address) {
+		return "", nil
+	}
+	addr := common.HexToAddress(address)
+
+	reverse := Namehash(strings.ToLower(addr.Hex()[2:]) + ".addr.reverse")
+	resolver, err := e.resolver(reverse)
+	if err != nil || resolver == (common.Address{}) {
+		return "", err
+	}
+	out, err := e.call(resolver, selectorName, reverse)
+	if err != nil {
+		return "", err
+	}
+	name, err := decodeString(out)
+	if err != nil || name == "" {
+		return "", err
+	}
+
+	node := Namehash(name)
+	forward, err := e.resolver(node)
+	if err != nil || forward == (common.Address{}) {
+		return "", err
+	}
+	out, err = e.call(forward, selectorAddr, node)
+	if err != nil {
+		return "", err
+	}
+	if len(out) < 32 || common.BytesToAddress(out[:32]) != addr {
+		return "", nil
+	}
+	return name, nil
+}
+
+// Close disconnects from the node
+func (e *ENS) Close() {
+	e.rpc.Close()
+}
+
+func (e *ENS) resolver(node common.Hash) (common.Address, error) {
+	out, err := e.call(e.Registry, selectorResolver, node)
+	if err != nil || len(out) < 32 {
+		return common.Address{}, err
+	}
+	return common.BytesToAddress(out[:32]), nil
+}
+
+// call calls the function of contract with selector and a single bytes32
+// argument
+func (e *ENS) call(contract common.Address, selector []byte, node common.Hash) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), e.Timeout)
+	defer cancel()
+
+	msg := map[string]interface{}{
+		"to":   contract,
+		"data": hexutil.Bytes(append(append([]byte{}, selector...), node[:]...)),
+	}
+	var out hexutil.Bytes
+	if err := e.rpc.CallContext(ctx, &out, "eth_call", msg, "latest"); err != nil {
+		return nil, fmt.Errorf("ens: eth_call %s: %v", contract.Hex(), err)
+	}
+	return out, nil
+}
+
+// Namehash returns the ENS node of name
+func Namehash(name string) common.Hash {
+	var node common.Hash
+	if name == "" {
+		return node
+	}
+	labels := strings.Split(strings.ToLower(name), ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		node = crypto.Keccak256Hash(node[:], crypto.Keccak256([]byte(labels[i])))
+	}
+	return node
+}
+
+// decodeString decodes an abi encoded string return value
+func decodeString(out []byte) (string, error) {
+	if len(out) == 0 {
+		return "", nil
+	}
+	if len(out) < 64 {
+		return "", fmt.Errorf("ens: short string of %d bytes", len(out))
+	}
+	offset := binary.BigEndian.Uint64(out[24:32])
+	if offset > uint64(len(out))-32 {
+		return "", fmt.Errorf("ens: string offset %d out of range", offset)
+	}
+	size := binary.BigEndian.Uint64(out[offset+24 : offset+32])
+	if size > uint64(len(out))-offset-32 {
+		return "", fmt.Errorf("ens: string of %d bytes out of range", size)
+	}
+	return string(out[offset+32 : offset+32+size]), nil
+}
@@ -0,0 +1,220 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/auser/bitping/types"
+)
+
+// eosName matches valid EOS account names
+var eosName = regexp.MustCompile(`^[a-z1-5.]{1,12}$`)
+
+// EOSAccounts looks up the metadata of EOS accounts with the get_account
+// api of a node. get_account doesn't return the creator of an account, it's
+// looked up with the get_creator api of a Hyperion history node when one is
+// set
+type EOSAccounts struct {
+	Node    string
+	History string
+
+	client *http.Client
+}
+
+// NewEOSAccounts returns a client of the chain api of node and, unless it's
+// empty, the history api of history
+func NewEOSAccounts(node, history string, timeout time.Duration) *EOSAccounts {
+	return &EOSAccounts{
+		Node:    strings.TrimRight(node, "/"),
+		History: strings.TrimRight(history, "/"),
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+// getAccountResp is the part of the get_account response kept
+type getAccountResp struct {
+	Created     string       `json:"created"`
+	Privileged  bool         `json:"privileged"`
+	RAMQuota    flexInt      `json:"ram_quota"`
+	RAMUsage    flexInt      `json:"ram_usage"`
+	CPUWeight   flexInt      `json:"cpu_weight"`
+	NetWeight   flexInt      `json:"net_weight"`
+	CPULimit    resourceResp `json:"cpu_limit"`
+	NetLimit    resourceResp `json:"net_limit"`
+	Permissions []struct {
+		PermName     string `json:"perm_name"`
+		Parent       string `json:"parent"`
+		RequiredAuth struct {
+			Threshold uint32 `json:"threshold"`
+			Keys      []struct {
+				Key    string `json:"key"`
+				Weight uint32 `json:"weight"`
+			} `json:"keys"`
+			Accounts []struct {
+				Permission struct {
+					Actor      string `json:"actor"`
+					Permission string `json:"permission"`
+				} `json:"permission"`
+				Weight uint32 `json:"weight"`
+			} `json:"accounts"`
+		} `json:"required_auth"`
+	} `json:"permissions"`
+}
+
+type resourceResp struct {
+	Used      flexInt `json:"used"`
+	Available flexInt `json:"available"`
+	Max       flexInt `json:"max"`
+}
+
+// eosErrorResp is the body of a failed api call
+type eosErrorResp struct {
+	Message string `json:"message"`
+	Error   struct {
+		Name    string `json:"name"`
+		What    string `json:"what"`
+		Details []struct {
+			Message string `json:"message"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+// unknown returns true when the call failed because the account doesn't
+// exist
+func (e eosErrorResp) unknown() bool {
+	if e.Error.Name == "unknown_key_exception" {
+		return true
+	}
+	for _, d := range e.Error.Details {
+		if strings.Contains(d.Message, "unknown key") {
+			return true
+		}
+	}
+	return false
+}
+
+// Account returns the metadata of the account name, or nil when there's no
+// such account
+func (e *EOSAccounts) Account(name string) (*types.EOSAccount, error) {
+	if !eosName.MatchString(name) {
+		return nil, nil
+	}
+
+	body, _ := json.Marshal(map[string]string{"account_name": name})
+	resp, err := e.client.Post(e.Node+"/v1/chain/get_account", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("eos: get_account %s: %v", name, err)
+	}
+	defer resp.Body.Close()
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("eos: get_account %s: %v", name, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var failed eosErrorResp
+		if json.Unmarshal(raw, &failed) == nil && failed.unknown() {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("eos: get_account %s: %s", name, resp.Status)
+	}
+
+	var out getAccountResp
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, fmt.Errorf("eos: get_account %s: %v", name, err)
+	}
+
+	account := &types.EOSAccount{
+		Privileged: out.Privileged,
+		RAMQuota:   int64(out.RAMQuota),
+		RAMUsage:   int64(out.RAMUsage),
+		CPUWeight:  int64(out.CPUWeight),
+		NetWeight:  int64(out.NetWeight),
+		CPU:        out.CPULimit.resource(),
+		Net:        out.NetLimit.resource(),
+	}
+	// Times are UTC without a zone, i.e. 2018-06-09T11:57:39.000
+	if created, err := time.Parse("2006-01-02T15:04:05", out.Created); err == nil {
+		account.Created = created.Unix()
+	}
+	for _, p := range out.Permissions {
+		perm := types.EOSAccountPermission{
+			Name:      p.PermName,
+			Parent:    p.Parent,
+			Threshold: p.RequiredAuth.Threshold,
+		}
+		for _, k := range p.RequiredAuth.Keys {
+			perm.Keys = append(perm.Keys, types.EOSKeyWeight{Key: k.Key, Weight: k.Weight})
+		}
+		for _, a := range p.RequiredAuth.Accounts {
+			perm.Accounts = append(perm.Accounts, types.EOSPermissionWeight{
+				Actor:      a.Permission.Actor,
+				Permission: a.Permission.Permission,
+				Weight:     a.Weight,
+			})
+		}
+		account.Permissions = append(account.Permissions, perm)
+	}
+
+	if e.History != "" {
+		creator, err := e.creator(name)
+		if err != nil {
+			// The chain metadata is still worth keeping
+			log.Printf("EOS account %s creator: %v", name, err)
+		}
+		account.Creator = creator
+	}
+	return account, nil
+}
+
+// creator looks up who created the account name with Hyperion
+func (e *EOSAccounts) creator(name string) (string, error) {
+	resp, err := e.client.Get(e.History + "/v2/history/get_creator?account=" + url.QueryEscape(name))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return "", nil
+	} else if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("get_creator: %s", resp.Status)
+	}
+
+	var out struct {
+		Creator string `json:"creator"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", err
+	}
+	return out.Creator, nil
+}
+
+func (r resourceResp) resource() types.EOSResource {
+	return types.EOSResource{Used: int64(r.Used), Available: int64(r.Available), Max: int64(r.Max)}
+}
+
+// flexInt reads the integers of the chain api, which some nodes quote
+type flexInt int64
+
+func (i *flexInt) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), `"`)
+	if s == "null" || s == "" {
+		*i = 0
+		return nil
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return err
+	}
+	*i = flexInt(n)
+	return nil
+}
@@ -0,0 +1,197 @@
+package metadata
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/auser/bitping/config"
+	"github.com/auser/bitping/types"
+	"github.com/codegangsta/cli"
+)
+
+// Defaults of the metadata options
+const (
+	DefaultENSNetwork = "ethereum"
+	DefaultEOSNetwork = "eos"
+	DefaultTTL        = time.Hour
+	DefaultCacheSize  = 10000
+	DefaultTimeout    = 10 * time.Second
+)
+
+// metadataFlags maps the cli flags to the config file options they override
+var metadataFlags = map[string]string{
+	"ens-node":          "ens_node",
+	"eos-accounts-node": "eos_node",
+}
+
+// AddCLIFlags adds the metadata flags
+func AddCLIFlags(fs []cli.Flag) []cli.Flag {
+	return append(fs,
+		cli.StringFlag{
+			Name:   "ens-node",
+			Usage:  "ethereum node to reverse resolve addresses to ENS names with",
+			EnvVar: "BITPING_ENS_NODE",
+		},
+		cli.StringFlag{
+			Name:   "eos-accounts-node",
+			Usage:  "EOS node to look up account metadata with",
+			EnvVar: "BITPING_EOS_ACCOUNTS_NODE",
+		},
+	)
+}
+
+// Resolver attaches the ENS names of ethereum addresses and the metadata
+// of EOS accounts to actions. Lookups are cached, both when they find
+// something and when they don't
+type Resolver struct {
+	// ENSNetwork and EOSNetwork are the network labels of the blocks whose
+	// actions are resolved
+	ENSNetwork string
+	EOSNetwork string
+
+	ens   *ENS
+	eos   *EOSAccounts
+	cache *Cache
+}
+
+// FromCLI returns the resolver set up with the metadata flags or in the
+// metadata section of the config file. It returns nil when neither ENS
+// nor EOS resolution is turned on
+func FromCLI(c *cli.Context, cfg *config.Config) (*Resolver, error) {
+	opts := cfg.Metadata.WithFlags(c, metadataFlags)
+	ensNode := opts.String("ens_node", "")
+	eosNode := opts.String("eos_node", "")
+	if ensNode == "" && eosNode == "" {
+		return nil, nil
+	}
+
+	timeout := opts.Duration("timeout", DefaultTimeout)
+	r := &Resolver{
+		ENSNetwork: opts.String("ens_network", DefaultENSNetwork),
+		EOSNetwork: opts.String("eos_network", DefaultEOSNetwork),
+		cache:      NewCache(int(opts.Int64("cache_size", DefaultCacheSize)), opts.Duration("ttl", DefaultTTL)),
+	}
+	if ensNode != "" {
+		ens, err := NewENS(ensNode, opts.String("ens_registry", DefaultENSRegistry), timeout)
+		if err != nil {
+			return nil, fmt.Errorf("metadata: %v", err)
+		}
+		r.ens = ens
+	}
+	if eosNode != "" {
+		r.eos = NewEOSAccounts(eosNode, opts.String("eos_history", ""), timeout)
+	}
+	return r, nil
+}
+
+// NewResolver returns a resolver using ens on the ethereum network and eos
+// on the eos network, either may be nil
+func NewResolver(ens *ENS, eos *EOSAccounts, cache *Cache) *Resolver {
+	return &Resolver{
+		ENSNetwork: DefaultENSNetwork,
+		EOSNetwork: DefaultEOSNetwork,
+		ens:        ens,
+		eos:        eos,
+		cache:      cache,
+	}
+}
+
+// ENSName returns the cached ENS name of address
+func (r *Resolver) ENSName(address string) (string, error) {
+	if r.ens == nil {
+		return "", nil
+	}
+	v, err := r.cache.Get("ens/"+strings.ToLower(address), func() (interface{}, error) {
+		return r.ens.Name(address)
+	})
+	if err != nil {
+		return "", err
+	}
+	return v.(string), nil
+}
+
+// EOSAccount returns the cached metadata of the EOS account name
+func (r *Resolver) EOSAccount(name string) (*types.EOSAccount, error) {
+	if r.eos == nil {
+		return nil, nil
+	}
+	v, err := r.cache.Get("eos/"+name, func() (interface{}, error) {
+		return r.eos.Account(name)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*types.EOSAccount), nil
+}
+
+// Name returns the name of the pipeline stage
+func (r *Resolver) Name() string {
+	return "metadata"
+}
+
+// Process attaches metadata to the senders and recipients of the actions
+// of block, and to the actors authorizing EOS actions. When a lookup fails
+// the other ones still go on and the first error is returned
+func (r *Resolver) Process(block *types.Block) error {
+	var lookup func(string) (*types.Account, error)
+	switch {
+	case block.Network == r.ENSNetwork && r.ens != nil:
+		lookup = r.ensAccount
+	case block.Network == r.EOSNetwork && r.eos != nil:
+		lookup = r.eosAccount
+	default:
+		return nil
+	}
+
+	var first error
+	keep := func(err error) {
+		if err != nil && first == nil {
+			first = err
+		}
+	}
+
+	for i := range block.Transactions {
+		actions := block.Transactions[i].Actions
+		for j := range actions {
+			a := &actions[j]
+			var err error
+			a.FromAccount, err = lookup(a.From)
+			keep(err)
+			a.ToAccount, err = lookup(a.To)
+			keep(err)
+
+			if a.EOSAction == nil || r.eos == nil {
+				continue
+			}
+			for k := range a.EOSAction.Authorization {
+				auth := &a.EOSAction.Authorization[k]
+				auth.ActorAccount, err = r.EOSAccount(auth.Actor)
+				keep(err)
+			}
+		}
+	}
+	return first
+}
+
+func (r *Resolver) ensAccount(address string) (*types.Account, error) {
+	if address == "" {
+		return nil, nil
+	}
+	name, err := r.ENSName(address)
+	if err != nil || name == "" {
+		return nil, err
+	}
+	return &types.Account{ENS: name}, nil
+}
+
+func (r *Resolver) eosAccount(name string) (*types.Account, error) {
+	if name == "" {
+		return nil, nil
+	}
+	account, err := r.EOSAccount(name)
+	if err != nil || account == nil {
+		return nil, err
+	}
+	return &types.Account{EOS: account}, nil
+}
@@ -0,0 +1,102 @@
+package notify
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/auser/bitping/config"
+	"github.com/auser/bitping/types"
+	"github.com/codegangsta/cli"
+)
+
+// EmailNotifier sends a batch of alerts as one plain text email over SMTP.
+// The connection is upgraded with STARTTLS when the server offers it, and
+// credentials are only sent over TLS or to localhost
+type EmailNotifier struct {
+	name string
+
+	// Addr is the host:port of the SMTP server
+	Addr     string
+	Username string
+	Password string
+	From     string
+	To       []string
+
+	explorers map[string]Explorer
+	template  *Template
+	subject   *Template
+}
+
+// NewEmailNotifier returns an unconfigured email notifier
+func NewEmailNotifier(name string, explorers map[string]Explorer) *EmailNotifier {
+	return &EmailNotifier{name: name, explorers: explorers}
+}
+
+// Name returns the name of the notifier
+func (n *EmailNotifier) Name() string {
+	return n.name
+}
+
+// ConfigureFromOptions reads the addr, username, password, from, to,
+// subject and template options
+func (n *EmailNotifier) ConfigureFromOptions(opts config.Options, c *cli.Context) error {
+	n.Addr = opts.String("addr", "")
+	if _, _, err := net.SplitHostPort(n.Addr); err != nil {
+		return fmt.Errorf("addr must be host:port: %v", err)
+	}
+	n.Username = opts.String("username", "")
+	n.Password = opts.String("password", "")
+	n.From = opts.String("from", "")
+	n.To = opts.Strings("to")
+	if n.From == "" || len(n.To) == 0 {
+		return errors.New("from and to are required")
+	}
+
+	var err error
+	if n.template, err = NewTemplate(opts.String("template", DefaultTemplate), n.explorers); err != nil {
+		return err
+	}
+	n.subject, err = NewTemplate(opts.String("subject", DefaultSubject), n.explorers)
+	return err
+}
+
+// Notify sends the alerts in one email
+func (n *EmailNotifier) Notify(alerts []types.Alert) error {
+	if len(alerts) == 0 {
+		return nil
+	}
+	subject, err := n.subject.Render(alerts)
+	if err != nil {
+		return permanentError{err}
+	}
+	// Headers are one line
+	subject = strings.Join(strings.Fields(subject), " ")
+	body, err := n.template.RenderAll(alerts)
+	if err != nil {
+		return permanentError{err}
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", n.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(n.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	msg.WriteString(strings.Replace(body, "\n", "\r\n", -1))
+	msg.WriteString("\r\n")
+
+	var auth smtp.Auth
+	if n.Username != "" {
+		host, _, _ := net.SplitHostPort(n.Addr)
+		auth = smtp.PlainAuth("", n.Username, n.Password, host)
+	}
+	return smtp.SendMail(n.Addr, auth, n.From, n.To, msg.Bytes())
+}
@@ -0,0 +1,215 @@
+package notify
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/auser/bitping/config"
+	"github.com/auser/bitping/types"
+)
+
+// smtpServer accepts mail without TLS, with AUTH PLAIN, and keeps what it
+// got
+type smtpServer struct {
+	ln net.Listener
+
+	sync.Mutex
+	auth []string
+	from string
+	to   []string
+	data string
+}
+
+func newSMTPServer(t *testing.T) *smtpServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &smtpServer{ln: ln}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *smtpServer) Close() {
+	s.ln.Close()
+}
+
+func (s *smtpServer) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(lines ...string) {
+		for _, l := range lines {
+			fmt.Fprintf(conn, "%s\r\n", l)
+		}
+	}
+
+	reply("220 localhost ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+
+		s.Lock()
+		switch cmd {
+		case "EHLO", "HELO":
+			reply("250-localhost", "250-8BITMIME", "250 AUTH PLAIN")
+		case "AUTH":
+			// AUTH PLAIN base64(identity \0 username \0 password)
+			fields := strings.Fields(line)
+			creds, _ := base64.StdEncoding.DecodeString(fields[len(fields)-1])
+			s.auth = strings.Split(string(creds), "\x00")
+			reply("235 2.7.0 Authentication successful")
+		case "MAIL":
+			s.from = mailPath(line)
+			reply("250 OK")
+		case "RCPT":
+			s.to = append(s.to, mailPath(line))
+			reply("250 OK")
+		case "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					s.Unlock()
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(l, "."))
+			}
+			s.data = data.String()
+			reply("250 OK: queued")
+		case "QUIT":
+			reply("221 Bye")
+			s.Unlock()
+			return
+		default:
+			reply("250 OK")
+		}
+		s.Unlock()
+	}
+}
+
+// mailPath is the address in <> of a MAIL or RCPT command, which can
+// be followed by parameters like BODY=8BITMIME
+func mailPath(line string) string {
+	start, end := strings.Index(line, "<"), strings.Index(line, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+	return line[start+1 : end]
+}
+
+func TestEmailNotifier(t *testing.T) {
+	srv := newSMTPServer(t)
+	defer srv.Close()
+
+	explorers := map[string]Explorer{"ethereum": DefaultExplorers["ethereum"]}
+	n := NewEmailNotifier("email", explorers)
+	err := n.ConfigureFromOptions(config.Options{
+		"addr":     srv.ln.Addr().String(),
+		"username": "bitping",
+		"password": "s3cret",
+		"from":     "bitping@example.com",
+		"to":       []interface{}{"ops@example.com", "oncall@example.com"},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A network without an explorer names the transaction and block instead
+	other := testAlert(1)
+	other.Network = "sidechain"
+	if err := n.Notify([]types.Alert{testAlert(0), other}); err != nil {
+		t.Fatal(err)
+	}
+
+	srv.Lock()
+	defer srv.Unlock()
+	if len(srv.auth) != 3 || srv.auth[1] != "bitping" || srv.auth[2] != "s3cret" {
+		t.Errorf("auth = %q", srv.auth)
+	}
+	if srv.from != "bitping@example.com" || strings.Join(srv.to, ",") != "ops@example.com,oncall@example.com" {
+		t.Errorf("envelope from %s to %v", srv.from, srv.to)
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(srv.data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := msg.Header.Get("Subject"); got != "[bitping] 2 alerts, first whale: large transfer" {
+		t.Errorf("subject = %q", got)
+	}
+	if got := msg.Header.Get("To"); got != "ops@example.com, oncall@example.com" {
+		t.Errorf("to = %q", got)
+	}
+	if got := msg.Header.Get("Content-Type"); got != "text/plain; charset=utf-8" {
+		t.Errorf("content type = %q", got)
+	}
+
+	body := new(strings.Builder)
+	bufio.NewReader(msg.Body).WriteTo(body)
+	want := wantText(0) + "\n\n" +
+		"[CRITICAL] whale on sidechain: large transfer\n" +
+		"1500.000000 USDT (1500.00 USD) from Binance (0x28c6…1d60) to 0x6295ee1b4f6dd65047762f924ecd367c17eabf8f\n" +
+		"Transaction " + other.Transaction.Hash + " in block 17000000\n"
+	if got := strings.Replace(body.String(), "\r\n", "\n", -1); got != want {
+		t.Errorf("body:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestEmailSubjectEncoding(t *testing.T) {
+	srv := newSMTPServer(t)
+	defer srv.Close()
+
+	n := NewEmailNotifier("email", nil)
+	err := n.ConfigureFromOptions(config.Options{
+		"addr":    srv.ln.Addr().String(),
+		"from":    "bitping@example.com",
+		"to":      "ops@example.com",
+		"subject": "{{with index . 0}}{{.Message}}{{end}}",
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alert := testAlert(0)
+	alert.Message = "€1M\nmoved"
+	if err := n.Notify([]types.Alert{alert}); err != nil {
+		t.Fatal(err)
+	}
+
+	srv.Lock()
+	defer srv.Unlock()
+	msg, err := mail.ReadMessage(strings.NewReader(srv.data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil || subject != "€1M moved" {
+		t.Errorf("subject = %q, %v", subject, err)
+	}
+	if len(srv.auth) != 0 {
+		t.Errorf("authenticated without a username: %q", srv.auth)
+	}
+}
@@ -0,0 +1,49 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// permanentError is an error retrying won't fix, like a rejected payload
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string {
+	return e.err.Error()
+}
+
+// httpClient is shared by the notifiers posting to web hooks
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// postJSON posts v as json to url. Client errors other than 429 are
+// permanent
+func postJSON(url string, v interface{}) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return permanentError{err}
+	}
+
+	resp, err := httpClient.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		io.Copy(ioutil.Discard, resp.Body)
+		return nil
+	}
+	msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+	err = fmt.Errorf("%s: %s", resp.Status, bytes.TrimSpace(msg))
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+		return permanentError{err}
+	}
+	return err
+}
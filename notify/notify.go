@@ -0,0 +1,253 @@
+package notify
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/auser/bitping/config"
+	"github.com/auser/bitping/iface"
+	"github.com/auser/bitping/types"
+	backoff "github.com/jpillora/backoff"
+)
+
+// Types lists the notifier types New knows how to create
+var Types = []string{"slack", "email", "pagerduty", "webhook"}
+
+// Defaults of the delivery options every notifier takes
+const (
+	DefaultBatchSize = 10
+	DefaultBatchWait = 5 * time.Second
+	DefaultRetries   = 5
+	DefaultRetryWait = time.Second
+)
+
+// queueSize is how many alerts can wait for a channel before routing
+// alerts holds up the alerts stage
+const queueSize = 1024
+
+// severities orders the severities, to send a channel the ones at or above
+// its own
+var severities = map[string]int{
+	types.SeverityInfo:     0,
+	types.SeverityWarning:  1,
+	types.SeverityCritical: 2,
+}
+
+// New returns an unconfigured notifier of the given type linking to the
+// explorers
+func New(kind, name string, explorers map[string]Explorer) (iface.Notifier, error) {
+	switch kind {
+	case "slack":
+		return NewSlackNotifier(name, explorers), nil
+	case "email":
+		return NewEmailNotifier(name, explorers), nil
+	case "pagerduty":
+		return NewPagerDutyNotifier(name, explorers), nil
+	case "webhook":
+		return NewWebhookNotifier(name, explorers), nil
+	}
+	return nil, fmt.Errorf("unknown notifier type: %s", kind)
+}
+
+// Channel delivers the alerts routed to a notifier in batches, in the
+// background. Failed batches are retried with a backoff
+type Channel struct {
+	iface.Notifier
+
+	// Alerts are the rules sent to the channel, all of them when empty
+	Alerts map[string]bool
+	// Severity is the lowest severity sent to the channel
+	Severity string
+
+	// BatchSize alerts are sent together, or the ones that came in
+	// BatchWait after the first of the batch
+	BatchSize int
+	BatchWait time.Duration
+	// Retries is how many times a batch is retried before it's dropped,
+	// waiting from RetryWait up to a minute in between
+	Retries   int
+	RetryWait time.Duration
+
+	queue chan types.Alert
+	done  chan struct{}
+}
+
+// NewChannel returns a channel delivering to notifier, with the default
+// batching and retries
+func NewChannel(notifier iface.Notifier) *Channel {
+	return &Channel{
+		Notifier:  notifier,
+		Severity:  types.SeverityInfo,
+		BatchSize: DefaultBatchSize,
+		BatchWait: DefaultBatchWait,
+		Retries:   DefaultRetries,
+		RetryWait: DefaultRetryWait,
+	}
+}
+
+// FromConfig creates and configures the channel declared in a config file
+// section. The batch_size, batch_wait, retries and retry_wait options are
+// read here, the notifier reads the rest
+func FromConfig(nc config.NotifierConfig, explorers map[string]Explorer) (*Channel, error) {
+	name := nc.Name
+	if name == "" {
+		name = nc.Type
+	}
+
+	n, err := New(nc.Type, name, explorers)
+	if err != nil {
+		return nil, err
+	}
+	if err := n.ConfigureFromOptions(nc.Options, nil); err != nil {
+		return nil, fmt.Errorf("notifier %s: %v", name, err)
+	}
+
+	ch := NewChannel(n)
+	if nc.Severity != "" {
+		if _, ok := severities[nc.Severity]; !ok {
+			return nil, fmt.Errorf("notifier %s: unknown severity %q", name, nc.Severity)
+		}
+		ch.Severity = nc.Severity
+	}
+	if len(nc.Alerts) > 0 {
+		ch.Alerts = make(map[string]bool)
+		for _, rule := range nc.Alerts {
+			ch.Alerts[rule] = true
+		}
+	}
+	ch.BatchSize = int(nc.Options.Int64("batch_size", int64(ch.BatchSize)))
+	ch.BatchWait = nc.Options.Duration("batch_wait", ch.BatchWait)
+	ch.Retries = int(nc.Options.Int64("retries", int64(ch.Retries)))
+	ch.RetryWait = nc.Options.Duration("retry_wait", ch.RetryWait)
+	return ch, nil
+}
+
+// Wants returns true when alert is routed to the channel
+func (ch *Channel) Wants(alert types.Alert) bool {
+	if ch.Alerts != nil && !ch.Alerts[alert.Rule] {
+		return false
+	}
+	return severities[alert.Severity] >= severities[ch.Severity]
+}
+
+// Start starts delivering the alerts sent with Send
+func (ch *Channel) Start() {
+	ch.queue = make(chan types.Alert, queueSize)
+	ch.done = make(chan struct{})
+	go ch.run()
+}
+
+// Send queues alert for delivery
+func (ch *Channel) Send(alert types.Alert) {
+	ch.queue <- alert
+}
+
+// Close delivers the queued alerts and stops the channel
+func (ch *Channel) Close() {
+	close(ch.queue)
+	<-ch.done
+}
+
+func (ch *Channel) run() {
+	defer close(ch.done)
+
+	var (
+		batch []types.Alert
+		timer <-chan time.Time
+	)
+	for {
+		select {
+		case alert, ok := <-ch.queue:
+			if !ok {
+				ch.deliver(batch)
+				return
+			}
+			batch = append(batch, alert)
+			if len(batch) == 1 {
+				timer = time.After(ch.BatchWait)
+			}
+			if len(batch) < ch.BatchSize {
+				continue
+			}
+		case <-timer:
+		}
+
+		ch.deliver(batch)
+		batch, timer = nil, nil
+	}
+}
+
+// deliver notifies batch, retrying with a backoff. Batches that can't be
+// delivered are logged and dropped so one broken channel doesn't hold up
+// the others
+func (ch *Channel) deliver(batch []types.Alert) {
+	if len(batch) == 0 {
+		return
+	}
+
+	b := &backoff.Backoff{Min: ch.RetryWait, Max: time.Minute}
+	for {
+		err := ch.Notify(batch)
+		if err == nil {
+			return
+		}
+		if _, permanent := err.(permanentError); permanent || int(b.Attempt()) >= ch.Retries {
+			log.Printf("Notifier %s: dropping %d alerts: %v", ch.Name(), len(batch), err)
+			return
+		}
+		d := b.Duration()
+		log.Printf("Notifier %s: %v, retrying in %s", ch.Name(), err, d)
+		time.Sleep(d)
+	}
+}
+
+// Dispatcher routes alerts to the channels that want them
+type Dispatcher struct {
+	channels []*Channel
+}
+
+// NewDispatcher starts the channels and returns a dispatcher routing to
+// them
+func NewDispatcher(channels ...*Channel) *Dispatcher {
+	for _, ch := range channels {
+		ch.Start()
+	}
+	return &Dispatcher{channels: channels}
+}
+
+// Notifiers returns a dispatcher to the notifiers declared in the config
+// file. It returns nil when there are none
+func Notifiers(cfg *config.Config) (*Dispatcher, error) {
+	if len(cfg.Notifiers) == 0 {
+		return nil, nil
+	}
+
+	explorers := Explorers(cfg)
+	var channels []*Channel
+	for _, nc := range cfg.Notifiers {
+		ch, err := FromConfig(nc, explorers)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("Notifying %s", ch.Name())
+		channels = append(channels, ch)
+	}
+	return NewDispatcher(channels...), nil
+}
+
+// Dispatch queues alert on the channels that want it
+func (d *Dispatcher) Dispatch(alert types.Alert) {
+	for _, ch := range d.channels {
+		if ch.Wants(alert) {
+			ch.Send(alert)
+		}
+	}
+}
+
+// Close delivers the queued alerts of every channel
+func (d *Dispatcher) Close() {
+	for _, ch := range d.channels {
+		ch.Close()
+	}
+}
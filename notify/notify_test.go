@@ -0,0 +1,263 @@
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/auser/bitping/config"
+	"github.com/auser/bitping/iface"
+	"github.com/auser/bitping/types"
+)
+
+// testAlert returns the i-th alert of a whale rule on a USDT transfer
+func testAlert(i int) types.Alert {
+	hash := fmt.Sprintf("0x%064x", 0xabc0+i)
+	return types.Alert{
+		ID:          fmt.Sprintf("whale-%d", i),
+		Rule:        "whale",
+		Severity:    types.SeverityCritical,
+		Network:     "ethereum",
+		Stat:        "sum",
+		Value:       1500,
+		Message:     "large transfer",
+		BlockHash:   fmt.Sprintf("0x%064x", 17000000),
+		BlockNumber: 17000000,
+		Time:        1682899200,
+		Transaction: &types.Transaction{Hash: hash, TransactionHash: hash},
+		Action: &types.Action{
+			TransactionHash: hash,
+			Address:         "0xdac17f958d2ee523a2206206994597c13d831ec7",
+			From:            "0x28c6c06298d514db089934071355e5743bf21d60",
+			To:              "0x6295ee1b4f6dd65047762f924ecd367c17eabf8f",
+			Symbol:          "USDT",
+			Precision:       6,
+			Amount:          "1500.000000",
+			Fiat:            &types.FiatValue{Currency: "USD", Price: "1", Value: "1500.00"},
+			FromLabel:       &types.Label{Entity: "Binance", Category: "exchange"},
+		},
+	}
+}
+
+// wantText is the default template rendered for testAlert(i)
+func wantText(i int) string {
+	hash := fmt.Sprintf("0x%064x", 0xabc0+i)
+	return "[CRITICAL] whale on ethereum: large transfer\n" +
+		"1500.000000 USDT (1500.00 USD) from Binance (0x28c6…1d60) to 0x6295ee1b4f6dd65047762f924ecd367c17eabf8f\n" +
+		"https://etherscan.io/tx/" + hash
+}
+
+// hook records the json posted to it, answering with the statuses in turn
+// and 200 once they run out
+type hook struct {
+	sync.Mutex
+	statuses []int
+	bodies   [][]byte
+}
+
+func (h *hook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+
+	h.Lock()
+	defer h.Unlock()
+	h.bodies = append(h.bodies, body)
+	status := http.StatusOK
+	if len(h.statuses) > 0 {
+		status, h.statuses = h.statuses[0], h.statuses[1:]
+	}
+	w.WriteHeader(status)
+	fmt.Fprintf(w, `{"status": %d}`, status)
+}
+
+func (h *hook) requests() [][]byte {
+	h.Lock()
+	defer h.Unlock()
+	return append([][]byte(nil), h.bodies...)
+}
+
+// notifiers configures a notifier of each http type posting to url
+func notifiers(t *testing.T, url string) map[string]iface.Notifier {
+	out := map[string]iface.Notifier{
+		"slack":     NewSlackNotifier("slack", DefaultExplorers),
+		"pagerduty": NewPagerDutyNotifier("pagerduty", DefaultExplorers),
+		"webhook":   NewWebhookNotifier("webhook", DefaultExplorers),
+	}
+	opts := config.Options{"webhook": url, "url": url, "routing_key": "R0UT1NG", "channel": "#alerts"}
+	for kind, n := range out {
+		if err := n.ConfigureFromOptions(opts, nil); err != nil {
+			t.Fatalf("%s: %v", kind, err)
+		}
+	}
+	return out
+}
+
+// testChannel returns a started channel retrying quickly
+func testChannel(n iface.Notifier, batchSize, retries int) *Channel {
+	ch := NewChannel(n)
+	ch.BatchSize = batchSize
+	ch.BatchWait = time.Minute
+	ch.Retries = retries
+	ch.RetryWait = time.Millisecond
+	ch.Start()
+	return ch
+}
+
+func TestChannelBatching(t *testing.T) {
+	h := &hook{}
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	ch := testChannel(notifiers(t, srv.URL)["webhook"], 2, 0)
+	for i := 0; i < 5; i++ {
+		ch.Send(testAlert(i))
+	}
+	// Closing delivers the last, partial batch
+	ch.Close()
+
+	reqs := h.requests()
+	if len(reqs) != 3 {
+		t.Fatalf("got %d requests, want 3 batches", len(reqs))
+	}
+	next := 0
+	for i, want := range []int{2, 2, 1} {
+		var payload webhookPayload
+		if err := json.Unmarshal(reqs[i], &payload); err != nil {
+			t.Fatal(err)
+		}
+		if len(payload.Alerts) != want {
+			t.Fatalf("batch %d has %d alerts, want %d", i, len(payload.Alerts), want)
+		}
+		var texts []string
+		for _, a := range payload.Alerts {
+			if a.ID != testAlert(next).ID {
+				t.Errorf("batch %d has alert %s, want %s in order", i, a.ID, testAlert(next).ID)
+			}
+			texts = append(texts, wantText(next))
+			next++
+		}
+		if text := strings.Join(texts, "\n\n"); payload.Text != text {
+			t.Errorf("batch %d text:\n%s\nwant:\n%s", i, payload.Text, text)
+		}
+	}
+}
+
+func TestChannelBatchWait(t *testing.T) {
+	h := &hook{}
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	ch := testChannel(notifiers(t, srv.URL)["webhook"], 10, 0)
+	ch.BatchWait = 20 * time.Millisecond
+	defer ch.Close()
+
+	ch.Send(testAlert(0))
+	deadline := time.Now().Add(5 * time.Second)
+	for len(h.requests()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("partial batch not delivered after batch_wait")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestRetries(t *testing.T) {
+	tests := []struct {
+		statuses []int
+		requests int
+	}{
+		// Server errors and rate limits are retried
+		{[]int{500}, 2},
+		{[]int{502, 503}, 3},
+		{[]int{429}, 2},
+		// Other client errors won't get better
+		{[]int{400}, 1},
+		{[]int{403}, 1},
+		{[]int{404}, 1},
+		// Dropped once the retries run out
+		{[]int{500, 500, 500, 500}, 3},
+	}
+
+	for _, kind := range []string{"slack", "pagerduty", "webhook"} {
+		for _, tt := range tests {
+			h := &hook{statuses: append([]int(nil), tt.statuses...)}
+			srv := httptest.NewServer(h)
+
+			ch := testChannel(notifiers(t, srv.URL)[kind], 1, 2)
+			ch.Send(testAlert(0))
+			ch.Close()
+			srv.Close()
+
+			reqs := h.requests()
+			if len(reqs) != tt.requests {
+				t.Errorf("%s answered %v: got %d requests, want %d", kind, tt.statuses, len(reqs), tt.requests)
+				continue
+			}
+			// Retries send the same payload
+			for i := 1; i < len(reqs); i++ {
+				if string(reqs[i]) != string(reqs[0]) {
+					t.Errorf("%s: retry %d sent a different payload", kind, i)
+				}
+			}
+		}
+	}
+}
+
+func TestSlackPayload(t *testing.T) {
+	h := &hook{}
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	if err := notifiers(t, srv.URL)["slack"].Notify([]types.Alert{testAlert(0), testAlert(1)}); err != nil {
+		t.Fatal(err)
+	}
+	var msg slackMessage
+	if err := json.Unmarshal(h.requests()[0], &msg); err != nil {
+		t.Fatal(err)
+	}
+	if want := wantText(0) + "\n\n" + wantText(1); msg.Text != want {
+		t.Errorf("text:\n%s\nwant:\n%s", msg.Text, want)
+	}
+	if msg.Channel != "#alerts" || msg.Username != "bitping" {
+		t.Errorf("message = %+v", msg)
+	}
+}
+
+func TestPagerDutyEvents(t *testing.T) {
+	h := &hook{}
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	alerts := []types.Alert{testAlert(0), testAlert(1)}
+	if err := notifiers(t, srv.URL)["pagerduty"].Notify(alerts); err != nil {
+		t.Fatal(err)
+	}
+	reqs := h.requests()
+	if len(reqs) != 2 {
+		t.Fatalf("got %d events, want one per alert", len(reqs))
+	}
+	for i, req := range reqs {
+		var event pagerDutyEvent
+		if err := json.Unmarshal(req, &event); err != nil {
+			t.Fatal(err)
+		}
+		a := alerts[i]
+		if event.RoutingKey != "R0UT1NG" || event.EventAction != "trigger" || event.DedupKey != a.ID {
+			t.Errorf("event %d = %+v", i, event)
+		}
+		if event.Payload.Summary != "[CRITICAL] whale on ethereum: large transfer" {
+			t.Errorf("event %d summary = %q", i, event.Payload.Summary)
+		}
+		if event.Payload.Severity != types.SeverityCritical || event.Payload.Timestamp != "2023-05-01T00:00:00Z" {
+			t.Errorf("event %d payload = %+v", i, event.Payload)
+		}
+		if len(event.Links) != 1 || event.Links[0].Href != "https://etherscan.io/tx/"+a.Transaction.Hash {
+			t.Errorf("event %d links = %+v", i, event.Links)
+		}
+	}
+}
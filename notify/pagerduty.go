@@ -0,0 +1,122 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/auser/bitping/config"
+	"github.com/auser/bitping/types"
+	"github.com/codegangsta/cli"
+)
+
+// DefaultPagerDutyURL is the endpoint of the PagerDuty Events API v2
+const DefaultPagerDutyURL = "https://events.pagerduty.com/v2/enqueue"
+
+// PagerDutyNotifier triggers a PagerDuty incident for every alert. The
+// dedup key is the alert ID, so a batch retried after a partial failure
+// doesn't page twice
+type PagerDutyNotifier struct {
+	name string
+
+	URL        string
+	RoutingKey string
+	Source     string
+
+	explorers map[string]Explorer
+	template  *Template
+}
+
+// NewPagerDutyNotifier returns an unconfigured PagerDuty notifier
+func NewPagerDutyNotifier(name string, explorers map[string]Explorer) *PagerDutyNotifier {
+	return &PagerDutyNotifier{name: name, explorers: explorers}
+}
+
+// Name returns the name of the notifier
+func (n *PagerDutyNotifier) Name() string {
+	return n.name
+}
+
+// ConfigureFromOptions reads the routing_key, source, url and template
+// options. The first line of the rendered template is the summary
+func (n *PagerDutyNotifier) ConfigureFromOptions(opts config.Options, c *cli.Context) error {
+	n.RoutingKey = opts.String("routing_key", "")
+	if n.RoutingKey == "" {
+		return errors.New("routing_key is required")
+	}
+	n.URL = opts.String("url", DefaultPagerDutyURL)
+	n.Source = opts.String("source", "bitping")
+
+	var err error
+	n.template, err = NewTemplate(opts.String("template", DefaultTemplate), n.explorers)
+	return err
+}
+
+type pagerDutyEvent struct {
+	RoutingKey  string           `json:"routing_key"`
+	EventAction string           `json:"event_action"`
+	DedupKey    string           `json:"dedup_key"`
+	Payload     pagerDutyPayload `json:"payload"`
+	Links       []pagerDutyLink  `json:"links,omitempty"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string      `json:"summary"`
+	Source        string      `json:"source"`
+	Severity      string      `json:"severity"`
+	Timestamp     string      `json:"timestamp"`
+	Component     string      `json:"component"`
+	Group         string      `json:"group"`
+	CustomDetails interface{} `json:"custom_details"`
+}
+
+type pagerDutyLink struct {
+	Href string `json:"href"`
+	Text string `json:"text"`
+}
+
+// Notify triggers an event for each alert
+func (n *PagerDutyNotifier) Notify(alerts []types.Alert) error {
+	for i := range alerts {
+		a := &alerts[i]
+		text, err := n.template.Render(a)
+		if err != nil {
+			return permanentError{err}
+		}
+
+		// PagerDuty caps summaries at 1024 characters
+		summary := strings.SplitN(text, "\n", 2)[0]
+		if len(summary) > 1024 {
+			summary = summary[:1021] + "..."
+		}
+		event := pagerDutyEvent{
+			RoutingKey:  n.RoutingKey,
+			EventAction: "trigger",
+			DedupKey:    a.ID,
+			Payload: pagerDutyPayload{
+				Summary:   summary,
+				Source:    n.Source,
+				Severity:  a.Severity,
+				Timestamp: time.Unix(a.Time, 0).UTC().Format(time.RFC3339),
+				Component: a.Network,
+				Group:     a.Rule,
+				CustomDetails: map[string]interface{}{
+					"details": text,
+					"alert":   a,
+				},
+			},
+		}
+		if e, ok := n.explorers[a.Network]; ok && e.Transaction != "" && a.Transaction != nil {
+			event.Links = append(event.Links, pagerDutyLink{
+				Href: fmt.Sprintf(e.Transaction, a.Transaction.Hash),
+				Text: "Transaction",
+			})
+		}
+
+		if err := postJSON(n.URL, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package notify
+
+import (
+	"errors"
+
+	"github.com/auser/bitping/config"
+	"github.com/auser/bitping/types"
+	"github.com/codegangsta/cli"
+)
+
+// SlackNotifier posts alerts to a Slack incoming webhook, a batch of
+// alerts as one message. Mattermost and Rocket.Chat webhooks take the same
+// payload
+type SlackNotifier struct {
+	name string
+
+	Webhook  string
+	Channel  string
+	Username string
+
+	explorers map[string]Explorer
+	template  *Template
+}
+
+// NewSlackNotifier returns an unconfigured Slack notifier
+func NewSlackNotifier(name string, explorers map[string]Explorer) *SlackNotifier {
+	return &SlackNotifier{name: name, explorers: explorers}
+}
+
+// Name returns the name of the notifier
+func (n *SlackNotifier) Name() string {
+	return n.name
+}
+
+// ConfigureFromOptions reads the webhook, channel, username and template
+// options
+func (n *SlackNotifier) ConfigureFromOptions(opts config.Options, c *cli.Context) error {
+	n.Webhook = opts.String("webhook", "")
+	if n.Webhook == "" {
+		return errors.New("webhook is required")
+	}
+	n.Channel = opts.String("channel", "")
+	n.Username = opts.String("username", "bitping")
+
+	var err error
+	n.template, err = NewTemplate(opts.String("template", DefaultTemplate), n.explorers)
+	return err
+}
+
+type slackMessage struct {
+	Text     string `json:"text"`
+	Channel  string `json:"channel,omitempty"`
+	Username string `json:"username,omitempty"`
+}
+
+// Notify posts the alerts as one message
+func (n *SlackNotifier) Notify(alerts []types.Alert) error {
+	text, err := n.template.RenderAll(alerts)
+	if err != nil {
+		return permanentError{err}
+	}
+	return postJSON(n.Webhook, slackMessage{Text: text, Channel: n.Channel, Username: n.Username})
+}
@@ -0,0 +1,165 @@
+package notify
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/auser/bitping/config"
+	"github.com/auser/bitping/types"
+)
+
+// DefaultTemplate renders an alert as a few lines of plain text
+const DefaultTemplate = `[{{upper .Severity}}] {{.Rule}} on {{.Network}}: {{.Message}}
+{{with .Action}}{{if .Amount}}{{.Amount}} {{.Symbol}}{{with .Fiat}} ({{.Value}} {{.Currency}}){{end}} {{end}}from {{sender .}} to {{recipient .}}
+{{end}}{{if .Suppressed}}{{.Suppressed}} more held back by the cooldown
+{{end}}{{with txURL .Network .Transaction.Hash}}{{.}}{{else}}Transaction {{.Transaction.Hash}} in block {{.BlockNumber}}{{end}}`
+
+// DefaultSubject is the subject of the emails of a batch of alerts
+const DefaultSubject = `[bitping] {{if gt (len .) 1}}{{len .}} alerts, first {{end}}{{with index . 0}}{{.Rule}}: {{.Message}}{{end}}`
+
+// Explorer links the transactions, addresses and blocks of a network. The
+// urls have a %s where the hash, address or number goes
+type Explorer config.ExplorerConfig
+
+// DefaultExplorers are the explorers of the networks of the chain profiles
+var DefaultExplorers = map[string]Explorer{
+	"ethereum": etherscan("https://etherscan.io"),
+	"ropsten":  etherscan("https://ropsten.etherscan.io"),
+	"rinkeby":  etherscan("https://rinkeby.etherscan.io"),
+	"goerli":   etherscan("https://goerli.etherscan.io"),
+	"kovan":    etherscan("https://kovan.etherscan.io"),
+	"classic":  blockscout("https://blockscout.com/etc/mainnet"),
+	"xdai":     blockscout("https://blockscout.com/xdai/mainnet"),
+	"poa":      blockscout("https://blockscout.com/poa/core"),
+
+	"bitcoin":     mempool("https://mempool.space"),
+	"testnet3":    mempool("https://mempool.space/testnet"),
+	"signet":      mempool("https://mempool.space/signet"),
+	"litecoin":    blockchair("https://blockchair.com/litecoin"),
+	"bitcoincash": blockchair("https://blockchair.com/bitcoin-cash"),
+	"dogecoin":    blockchair("https://blockchair.com/dogecoin"),
+
+	"eos": {
+		Transaction: "https://bloks.io/transaction/%s",
+		Address:     "https://bloks.io/account/%s",
+		Block:       "https://bloks.io/block/%s",
+	},
+}
+
+func etherscan(base string) Explorer {
+	return Explorer{Transaction: base + "/tx/%s", Address: base + "/address/%s", Block: base + "/block/%s"}
+}
+
+func blockscout(base string) Explorer {
+	return Explorer{Transaction: base + "/tx/%s", Address: base + "/address/%s", Block: base + "/blocks/%s"}
+}
+
+func mempool(base string) Explorer {
+	return Explorer{Transaction: base + "/tx/%s", Address: base + "/address/%s", Block: base + "/block/%s"}
+}
+
+func blockchair(base string) Explorer {
+	return Explorer{Transaction: base + "/transaction/%s", Address: base + "/address/%s", Block: base + "/block/%s"}
+}
+
+// Explorers returns the default explorers with the ones of the config file
+// on top
+func Explorers(cfg *config.Config) map[string]Explorer {
+	out := make(map[string]Explorer, len(DefaultExplorers)+len(cfg.Explorers))
+	for network, e := range DefaultExplorers {
+		out[network] = e
+	}
+	for network, e := range cfg.Explorers {
+		out[network] = Explorer(e)
+	}
+	return out
+}
+
+// Template renders alerts with text/template. Besides the alert fields,
+// templates can use:
+//
+//	txURL network hash, addressURL network address, blockURL network number
+//	sender action, recipient action: the ENS name or label of an address
+//	short string: a long hash shortened to 0x28c6…1d60
+//	upper string
+type Template struct {
+	tmpl *template.Template
+}
+
+// NewTemplate parses the template text using the explorers to link
+func NewTemplate(text string, explorers map[string]Explorer) (*Template, error) {
+	link := func(url func(e Explorer) string) func(network string, v interface{}) string {
+		return func(network string, v interface{}) string {
+			e, ok := explorers[network]
+			if !ok || url(e) == "" {
+				return ""
+			}
+			return fmt.Sprintf(url(e), v)
+		}
+	}
+
+	funcs := template.FuncMap{
+		"txURL":      link(func(e Explorer) string { return e.Transaction }),
+		"addressURL": link(func(e Explorer) string { return e.Address }),
+		"blockURL":   link(func(e Explorer) string { return e.Block }),
+		"sender": func(a *types.Action) string {
+			return who(a.From, a.FromLabel, a.FromAccount)
+		},
+		"recipient": func(a *types.Action) string {
+			return who(a.To, a.ToLabel, a.ToAccount)
+		},
+		"short": short,
+		"upper": strings.ToUpper,
+	}
+
+	tmpl, err := template.New("notification").Funcs(funcs).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return &Template{tmpl: tmpl}, nil
+}
+
+// Render executes the template with data, an alert or a batch of them
+func (t *Template) Render(data interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := t.tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// RenderAll renders each alert, separated by a blank line
+func (t *Template) RenderAll(alerts []types.Alert) (string, error) {
+	out := make([]string, len(alerts))
+	for i := range alerts {
+		text, err := t.Render(&alerts[i])
+		if err != nil {
+			return "", err
+		}
+		out[i] = text
+	}
+	return strings.Join(out, "\n\n"), nil
+}
+
+// who names an address by its ENS name or label, keeping the address
+func who(address string, label *types.Label, account *types.Account) string {
+	if address == "" {
+		return "nobody"
+	}
+	switch {
+	case account != nil && account.ENS != "":
+		return fmt.Sprintf("%s (%s)", account.ENS, short(address))
+	case label != nil && label.Entity != "":
+		return fmt.Sprintf("%s (%s)", label.Entity, short(address))
+	}
+	return address
+}
+
+func short(s string) string {
+	if !strings.HasPrefix(s, "0x") || len(s) <= 14 {
+		return s
+	}
+	return s[:6] + "…" + s[len(s)-4:]
+}
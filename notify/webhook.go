@@ -0,0 +1,56 @@
+package notify
+
+import (
+	"errors"
+
+	"github.com/auser/bitping/config"
+	"github.com/auser/bitping/types"
+	"github.com/codegangsta/cli"
+)
+
+// WebhookNotifier posts a batch of alerts as json to a url, along with
+// their rendered text, for services bitping doesn't know
+type WebhookNotifier struct {
+	name string
+
+	URL string
+
+	explorers map[string]Explorer
+	template  *Template
+}
+
+// NewWebhookNotifier returns an unconfigured webhook notifier
+func NewWebhookNotifier(name string, explorers map[string]Explorer) *WebhookNotifier {
+	return &WebhookNotifier{name: name, explorers: explorers}
+}
+
+// Name returns the name of the notifier
+func (n *WebhookNotifier) Name() string {
+	return n.name
+}
+
+// ConfigureFromOptions reads the url and template options
+func (n *WebhookNotifier) ConfigureFromOptions(opts config.Options, c *cli.Context) error {
+	n.URL = opts.String("url", "")
+	if n.URL == "" {
+		return errors.New("url is required")
+	}
+
+	var err error
+	n.template, err = NewTemplate(opts.String("template", DefaultTemplate), n.explorers)
+	return err
+}
+
+type webhookPayload struct {
+	Text   string        `json:"text"`
+	Alerts []types.Alert `json:"alerts"`
+}
+
+// Notify posts the alerts in one request
+func (n *WebhookNotifier) Notify(alerts []types.Alert) error {
+	text, err := n.template.RenderAll(alerts)
+	if err != nil {
+		return permanentError{err}
+	}
+	return postJSON(n.URL, webhookPayload{Text: text, Alerts: alerts})
+}
@@ -0,0 +1,3652 @@
+// Protobuf definition of the unified types in github.com/auser/bitping/types.
+//
+// The chain specific parts that the JSON encoding embeds as pointer structs
+// are a oneof here. Big integers are decimal strings.
+//
+// Sinks with `encoding: protobuf` publish these messages, so field numbers
+// are part of the wire format: never renumber or reuse them, and reserve the
+// numbers of removed fields.
+//
+// Regenerate the Go code in pb/ with `make proto`.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        (unknown)
+// source: bitping.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Filter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Networks to receive, all of them when empty
+	Networks []string `protobuf:"bytes,1,rep,name=networks,proto3" json:"networks,omitempty"`
+	// Only blocks with a transaction touching one of the addresses, when set
+	Addresses []string `protobuf:"bytes,2,rep,name=addresses,proto3" json:"addresses,omitempty"`
+}
+
+func (x *Filter) Reset() {
+	*x = Filter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bitping_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Filter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Filter) ProtoMessage() {}
+
+func (x *Filter) ProtoReflect() protoreflect.Message {
+	mi := &file_bitping_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Filter.ProtoReflect.Descriptor instead.
+func (*Filter) Descriptor() ([]byte, []int) {
+	return file_bitping_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Filter) GetNetworks() []string {
+	if x != nil {
+		return x.Networks
+	}
+	return nil
+}
+
+func (x *Filter) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+type GetBlockRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Network string `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	// Types that are assignable to Id:
+	//	*GetBlockRequest_Number
+	//	*GetBlockRequest_Hash
+	Id isGetBlockRequest_Id `protobuf_oneof:"id"`
+}
+
+func (x *GetBlockRequest) Reset() {
+	*x = GetBlockRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bitping_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlockRequest) ProtoMessage() {}
+
+func (x *GetBlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bitping_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlockRequest.ProtoReflect.Descriptor instead.
+func (*GetBlockRequest) Descriptor() ([]byte, []int) {
+	return file_bitping_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetBlockRequest) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (m *GetBlockRequest) GetId() isGetBlockRequest_Id {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (x *GetBlockRequest) GetNumber() int64 {
+	if x, ok := x.GetId().(*GetBlockRequest_Number); ok {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *GetBlockRequest) GetHash() string {
+	if x, ok := x.GetId().(*GetBlockRequest_Hash); ok {
+		return x.Hash
+	}
+	return ""
+}
+
+type isGetBlockRequest_Id interface {
+	isGetBlockRequest_Id()
+}
+
+type GetBlockRequest_Number struct {
+	Number int64 `protobuf:"varint,2,opt,name=number,proto3,oneof"`
+}
+
+type GetBlockRequest_Hash struct {
+	Hash string `protobuf:"bytes,3,opt,name=hash,proto3,oneof"`
+}
+
+func (*GetBlockRequest_Number) isGetBlockRequest_Id() {}
+
+func (*GetBlockRequest_Hash) isGetBlockRequest_Id() {}
+
+type Block struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hash         string         `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	HeaderHash   string         `protobuf:"bytes,2,opt,name=header_hash,json=headerHash,proto3" json:"header_hash,omitempty"`
+	Network      string         `protobuf:"bytes,3,opt,name=network,proto3" json:"network,omitempty"`
+	NetworkId    int64          `protobuf:"varint,4,opt,name=network_id,json=networkId,proto3" json:"network_id,omitempty"`
+	Number       int64          `protobuf:"varint,5,opt,name=number,proto3" json:"number,omitempty"`
+	Size         float64        `protobuf:"fixed64,6,opt,name=size,proto3" json:"size,omitempty"`
+	Time         int64          `protobuf:"varint,7,opt,name=time,proto3" json:"time,omitempty"`
+	Status       string         `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	Nonce        string         `protobuf:"bytes,9,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Difficulty   string         `protobuf:"bytes,10,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	ParentHash   string         `protobuf:"bytes,11,opt,name=parent_hash,json=parentHash,proto3" json:"parent_hash,omitempty"`
+	Transactions []*Transaction `protobuf:"bytes,12,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	// Version of the unified schema, see schemaVersion in the json
+	SchemaVersion uint32 `protobuf:"varint,13,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	// Types that are assignable to Chain:
+	//	*Block_Bitcoin
+	//	*Block_Eos
+	//	*Block_Ethereum
+	Chain isBlock_Chain `protobuf_oneof:"chain"`
+}
+
+func (x *Block) Reset() {
+	*x = Block{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bitping_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Block) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Block) ProtoMessage() {}
+
+func (x *Block) ProtoReflect() protoreflect.Message {
+	mi := &file_bitping_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Block.ProtoReflect.Descriptor instead.
+func (*Block) Descriptor() ([]byte, []int) {
+	return file_bitping_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Block) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *Block) GetHeaderHash() string {
+	if x != nil {
+		return x.HeaderHash
+	}
+	return ""
+}
+
+func (x *Block) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *Block) GetNetworkId() int64 {
+	if x != nil {
+		return x.NetworkId
+	}
+	return 0
+}
+
+func (x *Block) GetNumber() int64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *Block) GetSize() float64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Block) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *Block) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Block) GetNonce() string {
+	if x != nil {
+		return x.Nonce
+	}
+	return ""
+}
+
+func (x *Block) GetDifficulty() string {
+	if x != nil {
+		return x.Difficulty
+	}
+	return ""
+}
+
+func (x *Block) GetParentHash() string {
+	if x != nil {
+		return x.ParentHash
+	}
+	return ""
+}
+
+func (x *Block) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *Block) GetSchemaVersion() uint32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (m *Block) GetChain() isBlock_Chain {
+	if m != nil {
+		return m.Chain
+	}
+	return nil
+}
+
+func (x *Block) GetBitcoin() *BitcoinBlock {
+	if x, ok := x.GetChain().(*Block_Bitcoin); ok {
+		return x.Bitcoin
+	}
+	return nil
+}
+
+func (x *Block) GetEos() *EOSBlock {
+	if x, ok := x.GetChain().(*Block_Eos); ok {
+		return x.Eos
+	}
+	return nil
+}
+
+func (x *Block) GetEthereum() *EthereumBlock {
+	if x, ok := x.GetChain().(*Block_Ethereum); ok {
+		return x.Ethereum
+	}
+	return nil
+}
+
+type isBlock_Chain interface {
+	isBlock_Chain()
+}
+
+type Block_Bitcoin struct {
+	Bitcoin *BitcoinBlock `protobuf:"bytes,20,opt,name=bitcoin,proto3,oneof"`
+}
+
+type Block_Eos struct {
+	Eos *EOSBlock `protobuf:"bytes,21,opt,name=eos,proto3,oneof"`
+}
+
+type Block_Ethereum struct {
+	Ethereum *EthereumBlock `protobuf:"bytes,22,opt,name=ethereum,proto3,oneof"`
+}
+
+func (*Block_Bitcoin) isBlock_Chain() {}
+
+func (*Block_Eos) isBlock_Chain() {}
+
+func (*Block_Ethereum) isBlock_Chain() {}
+
+type BitcoinBlock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Height            uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Confirmations     uint64 `protobuf:"varint,2,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	StrippedSize      uint64 `protobuf:"varint,3,opt,name=stripped_size,json=strippedSize,proto3" json:"stripped_size,omitempty"`
+	Weight            uint64 `protobuf:"varint,4,opt,name=weight,proto3" json:"weight,omitempty"`
+	Version           string `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
+	VersionHex        string `protobuf:"bytes,6,opt,name=version_hex,json=versionHex,proto3" json:"version_hex,omitempty"`
+	Merkleroot        string `protobuf:"bytes,7,opt,name=merkleroot,proto3" json:"merkleroot,omitempty"`
+	MedianTime        uint64 `protobuf:"varint,8,opt,name=median_time,json=medianTime,proto3" json:"median_time,omitempty"`
+	Bits              string `protobuf:"bytes,9,opt,name=bits,proto3" json:"bits,omitempty"`
+	Chainwork         string `protobuf:"bytes,10,opt,name=chainwork,proto3" json:"chainwork,omitempty"`
+	PreviousBlockHash string `protobuf:"bytes,11,opt,name=previous_block_hash,json=previousBlockHash,proto3" json:"previous_block_hash,omitempty"`
+	NextBlockHash     string `protobuf:"bytes,12,opt,name=next_block_hash,json=nextBlockHash,proto3" json:"next_block_hash,omitempty"`
+}
+
+func (x *BitcoinBlock) Reset() {
+	*x = BitcoinBlock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bitping_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BitcoinBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BitcoinBlock) ProtoMessage() {}
+
+func (x *BitcoinBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_bitping_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BitcoinBlock.ProtoReflect.Descriptor instead.
+func (*BitcoinBlock) Descriptor() ([]byte, []int) {
+	return file_bitping_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BitcoinBlock) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *BitcoinBlock) GetConfirmations() uint64 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+func (x *BitcoinBlock) GetStrippedSize() uint64 {
+	if x != nil {
+		return x.StrippedSize
+	}
+	return 0
+}
+
+func (x *BitcoinBlock) GetWeight() uint64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *BitcoinBlock) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *BitcoinBlock) GetVersionHex() string {
+	if x != nil {
+		return x.VersionHex
+	}
+	return ""
+}
+
+func (x *BitcoinBlock) GetMerkleroot() string {
+	if x != nil {
+		return x.Merkleroot
+	}
+	return ""
+}
+
+func (x *BitcoinBlock) GetMedianTime() uint64 {
+	if x != nil {
+		return x.MedianTime
+	}
+	return 0
+}
+
+func (x *BitcoinBlock) GetBits() string {
+	if x != nil {
+		return x.Bits
+	}
+	return ""
+}
+
+func (x *BitcoinBlock) GetChainwork() string {
+	if x != nil {
+		return x.Chainwork
+	}
+	return ""
+}
+
+func (x *BitcoinBlock) GetPreviousBlockHash() string {
+	if x != nil {
+		return x.PreviousBlockHash
+	}
+	return ""
+}
+
+func (x *BitcoinBlock) GetNextBlockHash() string {
+	if x != nil {
+		return x.NextBlockHash
+	}
+	return ""
+}
+
+type EOSBlock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Producer              string `protobuf:"bytes,1,opt,name=producer,proto3" json:"producer,omitempty"`
+	Confirmed             uint64 `protobuf:"varint,2,opt,name=confirmed,proto3" json:"confirmed,omitempty"`
+	TransactionMerkleRoot string `protobuf:"bytes,3,opt,name=transaction_merkle_root,json=transactionMerkleRoot,proto3" json:"transaction_merkle_root,omitempty"`
+	ActionMerkleRoot      string `protobuf:"bytes,4,opt,name=action_merkle_root,json=actionMerkleRoot,proto3" json:"action_merkle_root,omitempty"`
+	ScheduleVersion       uint64 `protobuf:"varint,5,opt,name=schedule_version,json=scheduleVersion,proto3" json:"schedule_version,omitempty"`
+	ProducerSignature     string `protobuf:"bytes,6,opt,name=producer_signature,json=producerSignature,proto3" json:"producer_signature,omitempty"`
+	RefBlockPrefix        uint64 `protobuf:"varint,7,opt,name=ref_block_prefix,json=refBlockPrefix,proto3" json:"ref_block_prefix,omitempty"`
+	ChainId               string `protobuf:"bytes,8,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+}
+
+func (x *EOSBlock) Reset() {
+	*x = EOSBlock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bitping_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EOSBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EOSBlock) ProtoMessage() {}
+
+func (x *EOSBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_bitping_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EOSBlock.ProtoReflect.Descriptor instead.
+func (*EOSBlock) Descriptor() ([]byte, []int) {
+	return file_bitping_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *EOSBlock) GetProducer() string {
+	if x != nil {
+		return x.Producer
+	}
+	return ""
+}
+
+func (x *EOSBlock) GetConfirmed() uint64 {
+	if x != nil {
+		return x.Confirmed
+	}
+	return 0
+}
+
+func (x *EOSBlock) GetTransactionMerkleRoot() string {
+	if x != nil {
+		return x.TransactionMerkleRoot
+	}
+	return ""
+}
+
+func (x *EOSBlock) GetActionMerkleRoot() string {
+	if x != nil {
+		return x.ActionMerkleRoot
+	}
+	return ""
+}
+
+func (x *EOSBlock) GetScheduleVersion() uint64 {
+	if x != nil {
+		return x.ScheduleVersion
+	}
+	return 0
+}
+
+func (x *EOSBlock) GetProducerSignature() string {
+	if x != nil {
+		return x.ProducerSignature
+	}
+	return ""
+}
+
+func (x *EOSBlock) GetRefBlockPrefix() uint64 {
+	if x != nil {
+		return x.RefBlockPrefix
+	}
+	return 0
+}
+
+func (x *EOSBlock) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+type EthereumBlock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sha3Uncles       string   `protobuf:"bytes,1,opt,name=sha3_uncles,json=sha3Uncles,proto3" json:"sha3_uncles,omitempty"`
+	LogsBloom        string   `protobuf:"bytes,2,opt,name=logs_bloom,json=logsBloom,proto3" json:"logs_bloom,omitempty"`
+	TransactionsRoot string   `protobuf:"bytes,3,opt,name=transactions_root,json=transactionsRoot,proto3" json:"transactions_root,omitempty"`
+	StateRoot        string   `protobuf:"bytes,4,opt,name=state_root,json=stateRoot,proto3" json:"state_root,omitempty"`
+	Miner            string   `protobuf:"bytes,5,opt,name=miner,proto3" json:"miner,omitempty"`
+	Coinbase         string   `protobuf:"bytes,6,opt,name=coinbase,proto3" json:"coinbase,omitempty"`
+	TotalDifficulty  string   `protobuf:"bytes,7,opt,name=total_difficulty,json=totalDifficulty,proto3" json:"total_difficulty,omitempty"`
+	ExtraData        string   `protobuf:"bytes,8,opt,name=extra_data,json=extraData,proto3" json:"extra_data,omitempty"`
+	GasLimit         uint64   `protobuf:"varint,9,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
+	GasUsed          uint64   `protobuf:"varint,10,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
+	Uncles           []string `protobuf:"bytes,11,rep,name=uncles,proto3" json:"uncles,omitempty"`
+}
+
+func (x *EthereumBlock) Reset() {
+	*x = EthereumBlock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bitping_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EthereumBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EthereumBlock) ProtoMessage() {}
+
+func (x *EthereumBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_bitping_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EthereumBlock.ProtoReflect.Descriptor instead.
+func (*EthereumBlock) Descriptor() ([]byte, []int) {
+	return file_bitping_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *EthereumBlock) GetSha3Uncles() string {
+	if x != nil {
+		return x.Sha3Uncles
+	}
+	return ""
+}
+
+func (x *EthereumBlock) GetLogsBloom() string {
+	if x != nil {
+		return x.LogsBloom
+	}
+	return ""
+}
+
+func (x *EthereumBlock) GetTransactionsRoot() string {
+	if x != nil {
+		return x.TransactionsRoot
+	}
+	return ""
+}
+
+func (x *EthereumBlock) GetStateRoot() string {
+	if x != nil {
+		return x.StateRoot
+	}
+	return ""
+}
+
+func (x *EthereumBlock) GetMiner() string {
+	if x != nil {
+		return x.Miner
+	}
+	return ""
+}
+
+func (x *EthereumBlock) GetCoinbase() string {
+	if x != nil {
+		return x.Coinbase
+	}
+	return ""
+}
+
+func (x *EthereumBlock) GetTotalDifficulty() string {
+	if x != nil {
+		return x.TotalDifficulty
+	}
+	return ""
+}
+
+func (x *EthereumBlock) GetExtraData() string {
+	if x != nil {
+		return x.ExtraData
+	}
+	return ""
+}
+
+func (x *EthereumBlock) GetGasLimit() uint64 {
+	if x != nil {
+		return x.GasLimit
+	}
+	return 0
+}
+
+func (x *EthereumBlock) GetGasUsed() uint64 {
+	if x != nil {
+		return x.GasUsed
+	}
+	return 0
+}
+
+func (x *EthereumBlock) GetUncles() []string {
+	if x != nil {
+		return x.Uncles
+	}
+	return nil
+}
+
+type Transaction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockHash       string      `protobuf:"bytes,1,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	BlockNumber     int64       `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	TransactionHash string      `protobuf:"bytes,3,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	Hash            string      `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash,omitempty"`
+	Nonce           int64       `protobuf:"varint,5,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	MempoolStatus   string      `protobuf:"bytes,6,opt,name=mempool_status,json=mempoolStatus,proto3" json:"mempool_status,omitempty"`
+	ReplacedBy      string      `protobuf:"bytes,7,opt,name=replaced_by,json=replacedBy,proto3" json:"replaced_by,omitempty"`
+	IsDerived       bool        `protobuf:"varint,8,opt,name=is_derived,json=isDerived,proto3" json:"is_derived,omitempty"`
+	DerivedIndex    int64       `protobuf:"varint,9,opt,name=derived_index,json=derivedIndex,proto3" json:"derived_index,omitempty"`
+	Inputs          []*TxInput  `protobuf:"bytes,10,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	Outputs         []*TxOutput `protobuf:"bytes,11,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	Actions         []*Action   `protobuf:"bytes,12,rep,name=actions,proto3" json:"actions,omitempty"`
+	Events          []*Event    `protobuf:"bytes,13,rep,name=events,proto3" json:"events,omitempty"`
+	Matches         []string    `protobuf:"bytes,14,rep,name=matches,proto3" json:"matches,omitempty"`
+	// Only set on transactions sent on their own, outside of their block
+	SchemaVersion uint32 `protobuf:"varint,15,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	// Types that are assignable to Chain:
+	//	*Transaction_Bitcoin
+	//	*Transaction_Eos
+	//	*Transaction_Ethereum
+	Chain isTransaction_Chain `protobuf_oneof:"chain"`
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bitping_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_bitping_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_bitping_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Transaction) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *Transaction) GetBlockNumber() int64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *Transaction) GetTransactionHash() string {
+	if x != nil {
+		return x.TransactionHash
+	}
+	return ""
+}
+
+func (x *Transaction) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *Transaction) GetNonce() int64 {
+	if x != nil {
+		return x.Nonce
+	}
+	return 0
+}
+
+func (x *Transaction) GetMempoolStatus() string {
+	if x != nil {
+		return x.MempoolStatus
+	}
+	return ""
+}
+
+func (x *Transaction) GetReplacedBy() string {
+	if x != nil {
+		return x.ReplacedBy
+	}
+	return ""
+}
+
+func (x *Transaction) GetIsDerived() bool {
+	if x != nil {
+		return x.IsDerived
+	}
+	return false
+}
+
+func (x *Transaction) GetDerivedIndex() int64 {
+	if x != nil {
+		return x.DerivedIndex
+	}
+	return 0
+}
+
+func (x *Transaction) GetInputs() []*TxInput {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *Transaction) GetOutputs() []*TxOutput {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+func (x *Transaction) GetActions() []*Action {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+func (x *Transaction) GetEvents() []*Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *Transaction) GetMatches() []string {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *Transaction) GetSchemaVersion() uint32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (m *Transaction) GetChain() isTransaction_Chain {
+	if m != nil {
+		return m.Chain
+	}
+	return nil
+}
+
+func (x *Transaction) GetBitcoin() *BitcoinTransaction {
+	if x, ok := x.GetChain().(*Transaction_Bitcoin); ok {
+		return x.Bitcoin
+	}
+	return nil
+}
+
+func (x *Transaction) GetEos() *EOSTransactionReceipt {
+	if x, ok := x.GetChain().(*Transaction_Eos); ok {
+		return x.Eos
+	}
+	return nil
+}
+
+func (x *Transaction) GetEthereum() *EthereumTransaction {
+	if x, ok := x.GetChain().(*Transaction_Ethereum); ok {
+		return x.Ethereum
+	}
+	return nil
+}
+
+type isTransaction_Chain interface {
+	isTransaction_Chain()
+}
+
+type Transaction_Bitcoin struct {
+	Bitcoin *BitcoinTransaction `protobuf:"bytes,20,opt,name=bitcoin,proto3,oneof"`
+}
+
+type Transaction_Eos struct {
+	Eos *EOSTransactionReceipt `protobuf:"bytes,21,opt,name=eos,proto3,oneof"`
+}
+
+type Transaction_Ethereum struct {
+	Ethereum *EthereumTransaction `protobuf:"bytes,22,opt,name=ethereum,proto3,oneof"`
+}
+
+func (*Transaction_Bitcoin) isTransaction_Chain() {}
+
+func (*Transaction_Eos) isTransaction_Chain() {}
+
+func (*Transaction_Ethereum) isTransaction_Chain() {}
+
+type BitcoinTransaction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	WitnessHash string `protobuf:"bytes,1,opt,name=witness_hash,json=witnessHash,proto3" json:"witness_hash,omitempty"`
+	Version     uint32 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Size        uint64 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Vsize       uint64 `protobuf:"varint,4,opt,name=vsize,proto3" json:"vsize,omitempty"`
+	Weight      uint64 `protobuf:"varint,5,opt,name=weight,proto3" json:"weight,omitempty"`
+	LockTime    uint64 `protobuf:"varint,6,opt,name=lock_time,json=lockTime,proto3" json:"lock_time,omitempty"`
+	// Only set when the value of every input is known
+	Fee *uint64 `protobuf:"varint,7,opt,name=fee,proto3,oneof" json:"fee,omitempty"`
+}
+
+func (x *BitcoinTransaction) Reset() {
+	*x = BitcoinTransaction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bitping_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BitcoinTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BitcoinTransaction) ProtoMessage() {}
+
+func (x *BitcoinTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_bitping_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BitcoinTransaction.ProtoReflect.Descriptor instead.
+func (*BitcoinTransaction) Descriptor() ([]byte, []int) {
+	return file_bitping_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BitcoinTransaction) GetWitnessHash() string {
+	if x != nil {
+		return x.WitnessHash
+	}
+	return ""
+}
+
+func (x *BitcoinTransaction) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *BitcoinTransaction) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *BitcoinTransaction) GetVsize() uint64 {
+	if x != nil {
+		return x.Vsize
+	}
+	return 0
+}
+
+func (x *BitcoinTransaction) GetWeight() uint64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *BitcoinTransaction) GetLockTime() uint64 {
+	if x != nil {
+		return x.LockTime
+	}
+	return 0
+}
+
+func (x *BitcoinTransaction) GetFee() uint64 {
+	if x != nil && x.Fee != nil {
+		return *x.Fee
+	}
+	return 0
+}
+
+type EOSTransactionReceipt struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status        string                `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	CpuUsageUs    uint64                `protobuf:"varint,2,opt,name=cpu_usage_us,json=cpuUsageUs,proto3" json:"cpu_usage_us,omitempty"`
+	NetUsageWords uint64                `protobuf:"varint,3,opt,name=net_usage_words,json=netUsageWords,proto3" json:"net_usage_words,omitempty"`
+	Trx           *EOSTransactionWithID `protobuf:"bytes,4,opt,name=trx,proto3" json:"trx,omitempty"`
+}
+
+func (x *EOSTransactionReceipt) Reset() {
+	*x = EOSTransactionReceipt{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bitping_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EOSTransactionReceipt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EOSTransactionReceipt) ProtoMessage() {}
+
+func (x *EOSTransactionReceipt) ProtoReflect() protoreflect.Message {
+	mi := &file_bitping_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EOSTransactionReceipt.ProtoReflect.Descriptor instead.
+func (*EOSTransactionReceipt) Descriptor() ([]byte, []int) {
+	return file_bitping_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *EOSTransactionReceipt) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *EOSTransactionReceipt) GetCpuUsageUs() uint64 {
+	if x != nil {
+		return x.CpuUsageUs
+	}
+	return 0
+}
+
+func (x *EOSTransactionReceipt) GetNetUsageWords() uint64 {
+	if x != nil {
+		return x.NetUsageWords
+	}
+	return 0
+}
+
+func (x *EOSTransactionReceipt) GetTrx() *EOSTransactionWithID {
+	if x != nil {
+		return x.Trx
+	}
+	return nil
+}
+
+type EOSTransactionWithID struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                    string                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Signatures            []string                `protobuf:"bytes,2,rep,name=signatures,proto3" json:"signatures,omitempty"`
+	Compression           string                  `protobuf:"bytes,3,opt,name=compression,proto3" json:"compression,omitempty"`
+	PackedTrx             string                  `protobuf:"bytes,4,opt,name=packed_trx,json=packedTrx,proto3" json:"packed_trx,omitempty"`
+	PackedContextFreeData string                  `protobuf:"bytes,5,opt,name=packed_context_free_data,json=packedContextFreeData,proto3" json:"packed_context_free_data,omitempty"`
+	ContextFreeData       []string                `protobuf:"bytes,6,rep,name=context_free_data,json=contextFreeData,proto3" json:"context_free_data,omitempty"`
+	Transaction           *EOSUnpackedTransaction `protobuf:"bytes,7,opt,name=transaction,proto3" json:"transaction,omitempty"`
+}
+
+func (x *EOSTransactionWithID) Reset() {
+	*x = EOSTransactionWithID{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bitping_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EOSTransactionWithID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EOSTransactionWithID) ProtoMessage() {}
+
+func (x *EOSTransactionWithID) ProtoReflect() protoreflect.Message {
+	mi := &file_bitping_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EOSTransactionWithID.ProtoReflect.Descriptor instead.
+func (*EOSTransactionWithID) Descriptor() ([]byte, []int) {
+	return file_bitping_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *EOSTransactionWithID) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *EOSTransactionWithID) GetSignatures() []string {
+	if x != nil {
+		return x.Signatures
+	}
+	return nil
+}
+
+func (x *EOSTransactionWithID) GetCompression() string {
+	if x != nil {
+		return x.Compression
+	}
+	return ""
+}
+
+func (x *EOSTransactionWithID) GetPackedTrx() string {
+	if x != nil {
+		return x.PackedTrx
+	}
+	return ""
+}
+
+func (x *EOSTransactionWithID) GetPackedContextFreeData() string {
+	if x != nil {
+		return x.PackedContextFreeData
+	}
+	return ""
+}
+
+func (x *EOSTransactionWithID) GetContextFreeData() []string {
+	if x != nil {
+		return x.ContextFreeData
+	}
+	return nil
+}
+
+func (x *EOSTransactionWithID) GetTransaction() *EOSUnpackedTransaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+type EOSUnpackedTransaction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Expiration            int64           `protobuf:"varint,1,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	RefBlockNum           uint64          `protobuf:"varint,2,opt,name=ref_block_num,json=refBlockNum,proto3" json:"ref_block_num,omitempty"`
+	RefBlockPrefix        uint64          `protobuf:"varint,3,opt,name=ref_block_prefix,json=refBlockPrefix,proto3" json:"ref_block_prefix,omitempty"`
+	MaxNetUsageWords      uint64          `protobuf:"varint,4,opt,name=max_net_usage_words,json=maxNetUsageWords,proto3" json:"max_net_usage_words,omitempty"`
+	MaxCpuUsageMs         uint64          `protobuf:"varint,5,opt,name=max_cpu_usage_ms,json=maxCpuUsageMs,proto3" json:"max_cpu_usage_ms,omitempty"`
+	DelaySec              uint64          `protobuf:"varint,6,opt,name=delay_sec,json=delaySec,proto3" json:"delay_sec,omitempty"`
+	Actions               []*EOSAction    `protobuf:"bytes,7,rep,name=actions,proto3" json:"actions,omitempty"`
+	ContextFreeActions    []*EOSAction    `protobuf:"bytes,8,rep,name=context_free_actions,json=contextFreeActions,proto3" json:"context_free_actions,omitempty"`
+	TransactionExtensions []*EOSExtension `protobuf:"bytes,9,rep,name=transaction_extensions,json=transactionExtensions,proto3" json:"transaction_extensions,omitempty"`
+}
+
+func (x *EOSUnpackedTransaction) Reset() {
+	*x = EOSUnpackedTransaction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bitping_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EOSUnpackedTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EOSUnpackedTransaction) ProtoMessage() {}
+
+func (x *EOSUnpackedTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_bitping_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EOSUnpackedTransaction.ProtoReflect.Descriptor instead.
+func (*EOSUnpackedTransaction) Descriptor() ([]byte, []int) {
+	return file_bitping_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *EOSUnpackedTransaction) GetExpiration() int64 {
+	if x != nil {
+		return x.Expiration
+	}
+	return 0
+}
+
+func (x *EOSUnpackedTransaction) GetRefBlockNum() uint64 {
+	if x != nil {
+		return x.RefBlockNum
+	}
+	return 0
+}
+
+func (x *EOSUnpackedTransaction) GetRefBlockPrefix() uint64 {
+	if x != nil {
+		return x.RefBlockPrefix
+	}
+	return 0
+}
+
+func (x *EOSUnpackedTransaction) GetMaxNetUsageWords() uint64 {
+	if x != nil {
+		return x.MaxNetUsageWords
+	}
+	return 0
+}
+
+func (x *EOSUnpackedTransaction) GetMaxCpuUsageMs() uint64 {
+	if x != nil {
+		return x.MaxCpuUsageMs
+	}
+	return 0
+}
+
+func (x *EOSUnpackedTransaction) GetDelaySec() uint64 {
+	if x != nil {
+		return x.DelaySec
+	}
+	return 0
+}
+
+func (x *EOSUnpackedTransaction) GetActions() []*EOSAction {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+func (x *EOSUnpackedTransaction) GetContextFreeActions() []*EOSAction {
+	if x != nil {
+		return x.ContextFreeActions
+	}
+	return nil
+}
+
+func (x *EOSUnpackedTransaction) GetTransactionExtensions() []*EOSExtension {
+	if x != nil {
+		return x.TransactionExtensions
+	}
+	return nil
+}
+
+type EOSExtension struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type uint64 `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
+	Data string `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *EOSExtension) Reset() {
+	*x = EOSExtension{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bitping_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EOSExtension) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EOSExtension) ProtoMessage() {}
+
+func (x *EOSExtension) ProtoReflect() protoreflect.Message {
+	mi := &file_bitping_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EOSExtension.ProtoReflect.Descriptor instead.
+func (*EOSExtension) Descriptor() ([]byte, []int) {
+	return file_bitping_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *EOSExtension) GetType() uint64 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *EOSExtension) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+type EOSPermissionLevel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Actor        string      `protobuf:"bytes,1,opt,name=actor,proto3" json:"actor,omitempty"`
+	Permission   string      `protobuf:"bytes,2,opt,name=permission,proto3" json:"permission,omitempty"`
+	ActorLabel   *Label      `protobuf:"bytes,3,opt,name=actor_label,json=actorLabel,proto3" json:"actor_label,omitempty"`
+	ActorAccount *EOSAccount `protobuf:"bytes,4,opt,name=actor_account,json=actorAccount,proto3" json:"actor_account,omitempty"`
+}
+
+func (x *EOSPermissionLevel) Reset() {
+	*x = EOSPermissionLevel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bitping_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EOSPermissionLevel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EOSPermissionLevel) ProtoMessage() {}
+
+func (x *EOSPermissionLevel) ProtoReflect() protoreflect.Message {
+	mi := &file_bitping_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EOSPermissionLevel.ProtoReflect.Descriptor instead.
+func (*EOSPermissionLevel) Descriptor() ([]byte, []int) {
+	return file_bitping_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *EOSPermissionLevel) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *EOSPermissionLevel) GetPermission() string {
+	if x != nil {
+		return x.Permission
+	}
+	return ""
+}
+
+func (x *EOSPermissionLevel) GetActorLabel() *Label {
+	if x != nil {
+		return x.ActorLabel
+	}
+	return nil
+}
+
+func (x *EOSPermissionLevel) GetActorAccount() *EOSAccount {
+	if x != nil {
+		return x.ActorAccount
+	}
+	return nil
+}
+
+type EOSAccount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Only known when a history api is configured
+	Creator     string                  `protobuf:"bytes,1,opt,name=creator,proto3" json:"creator,omitempty"`
+	Created     int64                   `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	Privileged  bool                    `protobuf:"varint,3,opt,name=privileged,proto3" json:"privileged,omitempty"`
+	RamQuota    int64                   `protobuf:"varint,4,opt,name=ram_quota,json=ramQuota,proto3" json:"ram_quota,omitempty"`
+	RamUsage    int64                   `protobuf:"varint,5,opt,name=ram_usage,json=ramUsage,proto3" json:"ram_usage,omitempty"`
+	CpuWeight   int64                   `protobuf:"varint,6,opt,name=cpu_weight,json=cpuWeight,proto3" json:"cpu_weight,omitempty"`
+	NetWeight   int64                   `protobuf:"varint,7,opt,name=net_weight,json=netWeight,proto3" json:"net_weight,omitempty"`
+	Cpu         *EOSResource            `protobuf:"bytes,8,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	Net         *EOSResource            `protobuf:"bytes,9,opt,name=net,proto3" json:"net,omitempty"`
+	Permissions []*EOSAccountPermission `protobuf:"bytes,10,rep,name=permissions,proto3" json:"permissions,omitempty"`
+}
+
+func (x *EOSAccount) Reset() {
+	*x = EOSAccount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bitping_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EOSAccount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EOSAccount) ProtoMessage() {}
+
+func (x *EOSAccount) ProtoReflect() protoreflect.Message {
+	mi := &file_bitping_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EOSAccount.ProtoReflect.Descriptor instead.
+func (*EOSAccount) Descriptor() ([]byte, []int) {
+	return file_bitping_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *EOSAccount) GetCreator() string {
+	if x != nil {
+		return x.Creator
+	}
+	return ""
+}
+
+func (x *EOSAccount) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *EOSAccount) GetPrivileged() bool {
+	if x != nil {
+		return x.Privileged
+	}
+	return false
+}
+
+func (x *EOSAccount) GetRamQuota() int64 {
+	if x != nil {
+		return x.RamQuota
+	}
+	return 0
+}
+
+func (x *EOSAccount) GetRamUsage() int64 {
+	if x != nil {
+		return x.RamUsage
+	}
+	return 0
+}
+
+func (x *EOSAccount) GetCpuWeight() int64 {
+	if x != nil {
+		return x.CpuWeight
+	}
+	return 0
+}
+
+func (x *EOSAccount) GetNetWeight() int64 {
+	if x != nil {
+		return x.NetWeight
+	}
+	return 0
+}
+
+func (x *EOSAccount) GetCpu() *EOSResource {
+	if x != nil {
+		return x.Cpu
+	}
+	return nil
+}
+
+func (x *EOSAccount) GetNet() *EOSResource {
+	if x != nil {
+		return x.Net
+	}
+	return nil
+}
+
+func (x *EOSAccount) GetPermissions() []*EOSAccountPermission {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+type EOSResource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Used      int64 `protobuf:"varint,1,opt,name=used,proto3" json:"used,omitempty"`
+	Available int64 `protobuf:"varint,2,opt,name=available,proto3" json:"available,omitempty"`
+	Max       int64 `protobuf:"varint,3,opt,name=max,proto3" json:"max,omitempty"`
+}
+
+func (x *EOSResource) Reset() {
+	*x = EOSResource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bitping_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EOSResource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EOSResource) ProtoMessage() {}
+
+func (x *EOSResource) ProtoReflect() protoreflect.Message {
+	mi := &file_bitping_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EOSResource.ProtoReflect.Descriptor instead.
+func (*EOSResource) Descriptor() ([]byte, []int) {
+	return file_bitping_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *EOSResource) GetUsed() int64 {
+	if x != nil {
+		return x.Used
+	}
+	return 0
+}
+
+func (x *EOSResource) GetAvailable() int64 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+func (x *EOSResource) GetMax() int64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+type EOSAccountPermission struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name      string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Parent    string                 `protobuf:"bytes,2,opt,name=parent,proto3" json:"parent,omitempty"`
+	Threshold uint32                 `protobuf:"varint,3,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	Keys      []*EOSKeyWeight        `protobuf:"bytes,4,rep,name=keys,proto3" json:"keys,omitempty"`
+	Accounts  []*EOSPermissionWeight `protobuf:"bytes,5,rep,name=accounts,proto3" json:"accounts,omitempty"`
+}
+
+func (x *EOSAccountPermission) Reset() {
+	*x = EOSAccountPermission{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bitping_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EOSAccountPermission) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EOSAccountPermission) ProtoMessage() {}
+
+func (x *EOSAccountPermission) ProtoReflect() protoreflect.Message {
+	mi := &file_bitping_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EOSAccountPermission.ProtoReflect.Descriptor instead.
+func (*EOSAccountPermission) Descriptor() ([]byte, []int) {
+	return file_bitping_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *EOSAccountPermission) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *EOSAccountPermission) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *EOSAccountPermission) GetThreshold() uint32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *EOSAccountPermission) GetKeys() []*EOSKeyWeight {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *EOSAccountPermission) GetAccounts() []*EOSPermissionWeight {
+	if x != nil {
+		return x.Accounts
+	}
+	return nil
+}
+
+type EOSKeyWeight struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key    string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Weight uint32 `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
+}
+
+func (x *EOSKeyWeight) Reset() {
+	*x = EOSKeyWeight{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bitping_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EOSKeyWeight) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EOSKeyWeight) ProtoMessage() {}
+
+func (x *EOSKeyWeight) ProtoReflect() protoreflect.Message {
+	mi := &file_bitping_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EOSKeyWeight.ProtoReflect.Descriptor instead.
+func (*EOSKeyWeight) Descriptor() ([]byte, []int) {
+	return file_bitping_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *EOSKeyWeight) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *EOSKeyWeight) GetWeight() uint32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+type EOSPermissionWeight struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Actor      string `protobuf:"bytes,1,opt,name=actor,proto3" json:"actor,omitempty"`
+	Permission string `protobuf:"bytes,2,opt,name=permission,proto3" json:"permission,omitempty"`
+	Weight     uint32 `protobuf:"varint,3,opt,name=weight,proto3" json:"weight,omitempty"`
+}
+
+func (x *EOSPermissionWeight) Reset() {
+	*x = EOSPermissionWeight{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bitping_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EOSPermissionWeight) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EOSPermissionWeight) ProtoMessage() {}
+
+func (x *EOSPermissionWeight) ProtoReflect() protoreflect.Message {
+	mi := &file_bitping_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EOSPermissionWeight.ProtoReflect.Descriptor instead.
+func (*EOSPermissionWeight) Descriptor() ([]byte, []int) {
+	return file_bitping_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *EOSPermissionWeight) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *EOSPermissionWeight) GetPermission() string {
+	if x != nil {
+		return x.Permission
+	}
+	return ""
+}
+
+func (x *EOSPermissionWeight) GetWeight() uint32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+type EthereumTransaction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransactionIndex int64  `protobuf:"varint,1,opt,name=transaction_index,json=transactionIndex,proto3" json:"transaction_index,omitempty"`
+	GasPrice         string `protobuf:"bytes,2,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price,omitempty"`
+	Gas              uint64 `protobuf:"varint,3,opt,name=gas,proto3" json:"gas,omitempty"`
+}
+
+func (x *EthereumTransaction) Reset() {
+	*x = EthereumTransaction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bitping_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EthereumTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EthereumTransaction) ProtoMessage() {}
+
+func (x *EthereumTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_bitping_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EthereumTransaction.ProtoReflect.Descriptor instead.
+func (*EthereumTransaction) Descriptor() ([]byte, []int) {
+	return file_bitping_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *EthereumTransaction) GetTransactionIndex() int64 {
+	if x != nil {
+		return x.TransactionIndex
+	}
+	return 0
+}
+
+func (x *EthereumTransaction) GetGasPrice() string {
+	if x != nil {
+		return x.GasPrice
+	}
+	return ""
+}
+
+func (x *EthereumTransaction) GetGas() uint64 {
+	if x != nil {
+		return x.Gas
+	}
+	return 0
+}
+
+type TxInput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PrevTxHash string    `protobuf:"bytes,1,opt,name=prev_tx_hash,json=prevTxHash,proto3" json:"prev_tx_hash,omitempty"`
+	PrevIndex  uint32    `protobuf:"varint,2,opt,name=prev_index,json=prevIndex,proto3" json:"prev_index,omitempty"`
+	Sequence   uint32    `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Coinbase   string    `protobuf:"bytes,4,opt,name=coinbase,proto3" json:"coinbase,omitempty"`
+	PrevOut    *TxOutput `protobuf:"bytes,5,opt,name=prev_out,json=prevOut,proto3" json:"prev_out,omitempty"`
+}
+
+func (x *TxInput) Reset() {
+	*x = TxInput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bitping_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TxInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxInput) ProtoMessage() {}
+
+func (x *TxInput) ProtoReflect() protoreflect.Message {
+	mi := &file_bitping_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxInput.ProtoReflect.Descriptor instead.
+func (*TxInput) Descriptor() ([]byte, []int) {
+	return file_bitping_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *TxInput) GetPrevTxHash() string {
+	if x != nil {
+		return x.PrevTxHash
+	}
+	return ""
+}
+
+func (x *TxInput) GetPrevIndex() uint32 {
+	if x != nil {
+		return x.PrevIndex
+	}
+	return 0
+}
+
+func (x *TxInput) GetSequence() uint32 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *TxInput) GetCoinbase() string {
+	if x != nil {
+		return x.Coinbase
+	}
+	return ""
+}
+
+func (x *TxInput) GetPrevOut() *TxOutput {
+	if x != nil {
+		return x.PrevOut
+	}
+	return nil
+}
+
+type TxOutput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index      uint32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Value      uint64 `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
+	Script     string `protobuf:"bytes,3,opt,name=script,proto3" json:"script,omitempty"`
+	ScriptType string `protobuf:"bytes,4,opt,name=script_type,json=scriptType,proto3" json:"script_type,omitempty"`
+	Address    string `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (x *TxOutput) Reset() {
+	*x = TxOutput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bitping_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TxOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxOutput) ProtoMessage() {}
+
+func (x *TxOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_bitping_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxOutput.ProtoReflect.Descriptor instead.
+func (*TxOutput) Descriptor() ([]byte, []int) {
+	return file_bitping_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *TxOutput) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *TxOutput) GetValue() uint64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *TxOutput) GetScript() string {
+	if x != nil {
+		return x.Script
+	}
+	return ""
+}
+
+func (x *TxOutput) GetScriptType() string {
+	if x != nil {
+		return x.ScriptType
+	}
+	return ""
+}
+
+func (x *TxOutput) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type Action struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockHash       string `protobuf:"bytes,1,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	BlockNumber     int64  `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	TransactionHash string `protobuf:"bytes,3,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	Address         string `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	Data            []byte `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
+	From            string `protobuf:"bytes,6,opt,name=from,proto3" json:"from,omitempty"`
+	To              string `protobuf:"bytes,7,opt,name=to,proto3" json:"to,omitempty"`
+	Value           string `protobuf:"bytes,8,opt,name=value,proto3" json:"value,omitempty"`
+	Symbol          string `protobuf:"bytes,9,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Precision       uint64 `protobuf:"varint,10,opt,name=precision,proto3" json:"precision,omitempty"`
+	// Value in whole tokens, with precision decimals, when the token is known
+	Amount string `protobuf:"bytes,11,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Value of amount at the block time, when prices are turned on
+	Fiat *FiatValue `protobuf:"bytes,12,opt,name=fiat,proto3" json:"fiat,omitempty"`
+	// Labels of known senders and recipients, when labels are turned on
+	FromLabel *Label `protobuf:"bytes,13,opt,name=from_label,json=fromLabel,proto3" json:"from_label,omitempty"`
+	ToLabel   *Label `protobuf:"bytes,14,opt,name=to_label,json=toLabel,proto3" json:"to_label,omitempty"`
+	// Metadata of senders and recipients, when metadata resolution is turned on
+	FromAccount *Account `protobuf:"bytes,15,opt,name=from_account,json=fromAccount,proto3" json:"from_account,omitempty"`
+	ToAccount   *Account `protobuf:"bytes,16,opt,name=to_account,json=toAccount,proto3" json:"to_account,omitempty"`
+	// Only set on actions sent on their own, outside of their block
+	SchemaVersion uint32 `protobuf:"varint,17,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	// Types that are assignable to Chain:
+	//	*Action_Eos
+	//	*Action_Ethereum
+	Chain isAction_Chain `protobuf_oneof:"chain"`
+}
+
+func (x *Action) Reset() {
+	*x = Action{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bitping_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Action) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Action) ProtoMessage() {}
+
+func (x *Action) ProtoReflect() protoreflect.Message {
+	mi := &file_bitping_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Action.ProtoReflect.Descriptor instead.
+func (*Action) Descriptor() ([]byte, []int) {
+	return file_bitping_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Action) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *Action) GetBlockNumber() int64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *Action) GetTransactionHash() string {
+	if x != nil {
+		return x.TransactionHash
+	}
+	return ""
+}
+
+func (x *Action) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Action) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Action) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *Action) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *Action) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Action) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Action) GetPrecision() uint64 {
+	if x != nil {
+		return x.Precision
+	}
+	return 0
+}
+
+func (x *Action) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *Action) GetFiat() *FiatValue {
+	if x != nil {
+		return x.Fiat
+	}
+	return nil
+}
+
+func (x *Action) GetFromLabel() *Label {
+	if x != nil {
+		return x.FromLabel
+	}
+	return nil
+}
+
+func (x *Action) GetToLabel() *Label {
+	if x != nil {
+		return x.ToLabel
+	}
+	return nil
+}
+
+func (x *Action) GetFromAccount() *Account {
+	if x != nil {
+		return x.FromAccount
+	}
+	return nil
+}
+
+func (x *Action) GetToAccount() *Account {
+	if x != nil {
+		return x.ToAccount
+	}
+	return nil
+}
+
+func (x *Action) GetSchemaVersion() uint32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (m *Action) GetChain() isAction_Chain {
+	if m != nil {
+		return m.Chain
+	}
+	return nil
+}
+
+func (x *Action) GetEos() *EOSAction {
+	if x, ok := x.GetChain().(*Action_Eos); ok {
+		return x.Eos
+	}
+	return nil
+}
+
+func (x *Action) GetEthereum() *EthereumCall {
+	if x, ok := x.GetChain().(*Action_Ethereum); ok {
+		return x.Ethereum
+	}
+	return nil
+}
+
+type isAction_Chain interface {
+	isAction_Chain()
+}
+
+type Action_Eos struct {
+	Eos *EOSAction `protobuf:"bytes,20,opt,name=eos,proto3,oneof"`
+}
+
+type Action_Ethereum struct {
+	Ethereum *EthereumCall `protobuf:"bytes,21,opt,name=ethereum,proto3,oneof"`
+}
+
+func (*Action_Eos) isAction_Chain() {}
+
+func (*Action_Ethereum) isAction_Chain() {}
+
+type FiatValue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ISO 4217 code, i.e. USD
+	Currency string `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Price of a whole token, as a decimal string
+	Price string `protobuf:"bytes,2,opt,name=price,proto3" json:"price,omitempty"`
+	// Value of the amount, as a decimal string
+	Value string `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *FiatValue) Reset() {
+	*x = FiatValue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bitping_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FiatValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FiatValue) ProtoMessage() {}
+
+func (x *FiatValue) ProtoReflect() protoreflect.Message {
+	mi := &file_bitping_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FiatValue.ProtoReflect.Descriptor instead.
+func (*FiatValue) Descriptor() ([]byte, []int) {
+	return file_bitping_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *FiatValue) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *FiatValue) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *FiatValue) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type Account struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Primary ENS name of an ethereum address, when it resolves back to it
+	Ens string      `protobuf:"bytes,1,opt,name=ens,proto3" json:"ens,omitempty"`
+	Eos *EOSAccount `protobuf:"bytes,2,opt,name=eos,proto3" json:"eos,omitempty"`
+}
+
+func (x *Account) Reset() {
+	*x = Account{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bitping_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Account) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Account) ProtoMessage() {}
+
+func (x *Account) ProtoReflect() protoreflect.Message {
+	mi := &file_bitping_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Account.ProtoReflect.Descriptor instead.
+func (*Account) Descriptor() ([]byte, []int) {
+	return file_bitping_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *Account) GetEns() string {
+	if x != nil {
+		return x.Ens
+	}
+	return ""
+}
+
+func (x *Account) GetEos() *EOSAccount {
+	if x != nil {
+		return x.Eos
+	}
+	return nil
+}
+
+type Label struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Entity owning the address, i.e. Binance
+	Entity string `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+	// Category of the address, i.e. exchange
+	Category string `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+}
+
+func (x *Label) Reset() {
+	*x = Label{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bitping_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Label) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Label) ProtoMessage() {}
+
+func (x *Label) ProtoReflect() protoreflect.Message {
+	mi := &file_bitping_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Label.ProtoReflect.Descriptor instead.
+func (*Label) Descriptor() ([]byte, []int) {
+	return file_bitping_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Label) GetEntity() string {
+	if x != nil {
+		return x.Entity
+	}
+	return ""
+}
+
+func (x *Label) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+type EOSAction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Account       string                `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Name          string                `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Authorization []*EOSPermissionLevel `protobuf:"bytes,3,rep,name=authorization,proto3" json:"authorization,omitempty"`
+	HexData       string                `protobuf:"bytes,4,opt,name=hex_data,json=hexData,proto3" json:"hex_data,omitempty"`
+	Data          string                `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *EOSAction) Reset() {
+	*x = EOSAction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bitping_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EOSAction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EOSAction) ProtoMessage() {}
+
+func (x *EOSAction) ProtoReflect() protoreflect.Message {
+	mi := &file_bitping_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EOSAction.ProtoReflect.Descriptor instead.
+func (*EOSAction) Descriptor() ([]byte, []int) {
+	return file_bitping_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *EOSAction) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *EOSAction) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *EOSAction) GetAuthorization() []*EOSPermissionLevel {
+	if x != nil {
+		return x.Authorization
+	}
+	return nil
+}
+
+func (x *EOSAction) GetHexData() string {
+	if x != nil {
+		return x.HexData
+	}
+	return ""
+}
+
+func (x *EOSAction) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+type EthereumCall struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Input []byte `protobuf:"bytes,1,opt,name=input,proto3" json:"input,omitempty"`
+}
+
+func (x *EthereumCall) Reset() {
+	*x = EthereumCall{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bitping_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EthereumCall) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EthereumCall) ProtoMessage() {}
+
+func (x *EthereumCall) ProtoReflect() protoreflect.Message {
+	mi := &file_bitping_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EthereumCall.ProtoReflect.Descriptor instead.
+func (*EthereumCall) Descriptor() ([]byte, []int) {
+	return file_bitping_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *EthereumCall) GetInput() []byte {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+type Event struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Chain:
+	//	*Event_Ethereum
+	Chain isEvent_Chain `protobuf_oneof:"chain"`
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bitping_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_bitping_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_bitping_proto_rawDescGZIP(), []int{27}
+}
+
+func (m *Event) GetChain() isEvent_Chain {
+	if m != nil {
+		return m.Chain
+	}
+	return nil
+}
+
+func (x *Event) GetEthereum() *EthereumEvent {
+	if x, ok := x.GetChain().(*Event_Ethereum); ok {
+		return x.Ethereum
+	}
+	return nil
+}
+
+type isEvent_Chain interface {
+	isEvent_Chain()
+}
+
+type Event_Ethereum struct {
+	Ethereum *EthereumEvent `protobuf:"bytes,20,opt,name=ethereum,proto3,oneof"`
+}
+
+func (*Event_Ethereum) isEvent_Chain() {}
+
+type EthereumEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LogIndex         uint64   `protobuf:"varint,1,opt,name=log_index,json=logIndex,proto3" json:"log_index,omitempty"`
+	TransactionIndex uint64   `protobuf:"varint,2,opt,name=transaction_index,json=transactionIndex,proto3" json:"transaction_index,omitempty"`
+	Address          string   `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Data             []byte   `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	Topics           []string `protobuf:"bytes,5,rep,name=topics,proto3" json:"topics,omitempty"`
+	Removed          bool     `protobuf:"varint,6,opt,name=removed,proto3" json:"removed,omitempty"`
+}
+
+func (x *EthereumEvent) Reset() {
+	*x = EthereumEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bitping_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EthereumEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EthereumEvent) ProtoMessage() {}
+
+func (x *EthereumEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_bitping_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EthereumEvent.ProtoReflect.Descriptor instead.
+func (*EthereumEvent) Descriptor() ([]byte, []int) {
+	return file_bitping_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *EthereumEvent) GetLogIndex() uint64 {
+	if x != nil {
+		return x.LogIndex
+	}
+	return 0
+}
+
+func (x *EthereumEvent) GetTransactionIndex() uint64 {
+	if x != nil {
+		return x.TransactionIndex
+	}
+	return 0
+}
+
+func (x *EthereumEvent) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *EthereumEvent) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *EthereumEvent) GetTopics() []string {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+func (x *EthereumEvent) GetRemoved() bool {
+	if x != nil {
+		return x.Removed
+	}
+	return false
+}
+
+var File_bitping_proto protoreflect.FileDescriptor
+
+var file_bitping_proto_rawDesc = []byte{
+	0x0a, 0x0d, 0x62, 0x69, 0x74, 0x70, 0x69, 0x6e, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x0a, 0x62, 0x69, 0x74, 0x70, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x22, 0x42, 0x0a, 0x06, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
+	0x73, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x22,
+	0x61, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x18, 0x0a, 0x06,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x06,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x42, 0x04, 0x0a, 0x02,
+	0x69, 0x64, 0x22, 0xaa, 0x04, 0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x12, 0x0a, 0x04,
+	0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68,
+	0x12, 0x1f, 0x0a, 0x0b, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x48, 0x61, 0x73,
+	0x68, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x1d, 0x0a, 0x0a, 0x6e,
+	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x69, 0x66, 0x66,
+	0x69, 0x63, 0x75, 0x6c, 0x74, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x69,
+	0x66, 0x66, 0x69, 0x63, 0x75, 0x6c, 0x74, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x65,
+	0x6e, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70,
+	0x61, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x3b, 0x0a, 0x0c, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x62, 0x69, 0x74, 0x70, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x34, 0x0a,
+	0x07, 0x62, 0x69, 0x74, 0x63, 0x6f, 0x69, 0x6e, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x62, 0x69, 0x74, 0x70, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x74, 0x63,
+	0x6f, 0x69, 0x6e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x07, 0x62, 0x69, 0x74, 0x63,
+	0x6f, 0x69, 0x6e, 0x12, 0x28, 0x0a, 0x03, 0x65, 0x6f, 0x73, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x62, 0x69, 0x74, 0x70, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x4f,
+	0x53, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x03, 0x65, 0x6f, 0x73, 0x12, 0x37, 0x0a,
+	0x08, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x62, 0x69, 0x74, 0x70, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x74, 0x68,
+	0x65, 0x72, 0x65, 0x75, 0x6d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x08, 0x65, 0x74,
+	0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x42, 0x07, 0x0a, 0x05, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x22,
+	0x8f, 0x03, 0x0a, 0x0c, 0x42, 0x69, 0x74, 0x63, 0x6f, 0x69, 0x6e, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x23,
+	0x0a, 0x0d, 0x73, 0x74, 0x72, 0x69, 0x70, 0x70, 0x65, 0x64, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x73, 0x74, 0x72, 0x69, 0x70, 0x70, 0x65, 0x64, 0x53,
+	0x69, 0x7a, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x5f, 0x68, 0x65, 0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x48, 0x65, 0x78, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65,
+	0x72, 0x6f, 0x6f, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x65, 0x72, 0x6b,
+	0x6c, 0x65, 0x72, 0x6f, 0x6f, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x6e,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x6d, 0x65, 0x64,
+	0x69, 0x61, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x69, 0x74, 0x73, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x62, 0x69, 0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x63,
+	0x68, 0x61, 0x69, 0x6e, 0x77, 0x6f, 0x72, 0x6b, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x2e, 0x0a, 0x13, 0x70, 0x72, 0x65,
+	0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x61, 0x73, 0x68,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78,
+	0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73,
+	0x68, 0x22, 0xc9, 0x02, 0x0a, 0x08, 0x45, 0x4f, 0x53, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1a,
+	0x0a, 0x08, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x72, 0x6d, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x65, 0x64, 0x12, 0x36, 0x0a, 0x17, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x5f, 0x72,
+	0x6f, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x15, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x52, 0x6f, 0x6f, 0x74,
+	0x12, 0x2c, 0x0a, 0x12, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x65, 0x72, 0x6b, 0x6c,
+	0x65, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x72, 0x6b, 0x6c, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x29,
+	0x0a, 0x10, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x12, 0x70, 0x72, 0x6f,
+	0x64, 0x75, 0x63, 0x65, 0x72, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72, 0x53,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x72, 0x65, 0x66, 0x5f,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0e, 0x72, 0x65, 0x66, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x50, 0x72, 0x65, 0x66,
+	0x69, 0x78, 0x12, 0x19, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x64, 0x22, 0xe7, 0x02,
+	0x0a, 0x0d, 0x45, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12,
+	0x1f, 0x0a, 0x0b, 0x73, 0x68, 0x61, 0x33, 0x5f, 0x75, 0x6e, 0x63, 0x6c, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x68, 0x61, 0x33, 0x55, 0x6e, 0x63, 0x6c, 0x65, 0x73,
+	0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x6f, 0x67, 0x73, 0x5f, 0x62, 0x6c, 0x6f, 0x6f, 0x6d, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x6f, 0x67, 0x73, 0x42, 0x6c, 0x6f, 0x6f, 0x6d, 0x12,
+	0x2b, 0x0a, 0x11, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f,
+	0x72, 0x6f, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6d,
+	0x69, 0x6e, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x69, 0x6e, 0x65,
+	0x72, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x69, 0x6e, 0x62, 0x61, 0x73, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6f, 0x69, 0x6e, 0x62, 0x61, 0x73, 0x65, 0x12, 0x29, 0x0a,
+	0x10, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x64, 0x69, 0x66, 0x66, 0x69, 0x63, 0x75, 0x6c, 0x74,
+	0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x44, 0x69,
+	0x66, 0x66, 0x69, 0x63, 0x75, 0x6c, 0x74, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x74, 0x72,
+	0x61, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65, 0x78,
+	0x74, 0x72, 0x61, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x61, 0x73, 0x5f, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x67, 0x61, 0x73, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x61, 0x73, 0x5f, 0x75, 0x73, 0x65, 0x64,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x67, 0x61, 0x73, 0x55, 0x73, 0x65, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x75, 0x6e, 0x63, 0x6c, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x06, 0x75, 0x6e, 0x63, 0x6c, 0x65, 0x73, 0x22, 0xe2, 0x05, 0x0a, 0x0b, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x29, 0x0a, 0x10, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x48, 0x61, 0x73, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x25,
+	0x0a, 0x0e, 0x6d, 0x65, 0x6d, 0x70, 0x6f, 0x6f, 0x6c, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6d, 0x65, 0x6d, 0x70, 0x6f, 0x6f, 0x6c, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65,
+	0x64, 0x5f, 0x62, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6c,
+	0x61, 0x63, 0x65, 0x64, 0x42, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x73, 0x5f, 0x64, 0x65, 0x72,
+	0x69, 0x76, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x73, 0x44, 0x65,
+	0x72, 0x69, 0x76, 0x65, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x72, 0x69, 0x76, 0x65, 0x64,
+	0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x64, 0x65,
+	0x72, 0x69, 0x76, 0x65, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x2b, 0x0a, 0x06, 0x69, 0x6e,
+	0x70, 0x75, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x62, 0x69, 0x74,
+	0x70, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x78, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52,
+	0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x62, 0x69, 0x74, 0x70, 0x69,
+	0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x78, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x07,
+	0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x12, 0x2c, 0x0a, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x62, 0x69, 0x74, 0x70, 0x69,
+	0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x29, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18,
+	0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x62, 0x69, 0x74, 0x70, 0x69, 0x6e, 0x67, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73,
+	0x12, 0x18, 0x0a, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0d, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x3a, 0x0a, 0x07, 0x62, 0x69, 0x74, 0x63, 0x6f, 0x69, 0x6e, 0x18, 0x14, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x62, 0x69, 0x74, 0x70, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e,
+	0x42, 0x69, 0x74, 0x63, 0x6f, 0x69, 0x6e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x48, 0x00, 0x52, 0x07, 0x62, 0x69, 0x74, 0x63, 0x6f, 0x69, 0x6e, 0x12, 0x35, 0x0a,
+	0x03, 0x65, 0x6f, 0x73, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x62, 0x69, 0x74,
+	0x70, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x4f, 0x53, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x48, 0x00, 0x52,
+	0x03, 0x65, 0x6f, 0x73, 0x12, 0x3d, 0x0a, 0x08, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d,
+	0x18, 0x16, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x62, 0x69, 0x74, 0x70, 0x69, 0x6e, 0x67,
+	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x08, 0x65, 0x74, 0x68, 0x65, 0x72,
+	0x65, 0x75, 0x6d, 0x42, 0x07, 0x0a, 0x05, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x22, 0xcf, 0x01, 0x0a,
+	0x12, 0x42, 0x69, 0x74, 0x63, 0x6f, 0x69, 0x6e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x77, 0x69, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x5f, 0x68,
+	0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x77, 0x69, 0x74, 0x6e, 0x65,
+	0x73, 0x73, 0x48, 0x61, 0x73, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04,
+	0x73, 0x69, 0x7a, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x12,
+	0x15, 0x0a, 0x03, 0x66, 0x65, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x03,
+	0x66, 0x65, 0x65, 0x88, 0x01, 0x01, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x66, 0x65, 0x65, 0x22, 0xad,
+	0x01, 0x0a, 0x15, 0x45, 0x4f, 0x53, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x20, 0x0a, 0x0c, 0x63, 0x70, 0x75, 0x5f, 0x75, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x75, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x63, 0x70, 0x75, 0x55, 0x73, 0x61, 0x67, 0x65,
+	0x55, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x74, 0x5f, 0x75, 0x73, 0x61, 0x67, 0x65, 0x5f,
+	0x77, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x6e, 0x65, 0x74,
+	0x55, 0x73, 0x61, 0x67, 0x65, 0x57, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x32, 0x0a, 0x03, 0x74, 0x72,
+	0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x62, 0x69, 0x74, 0x70, 0x69, 0x6e,
+	0x67, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x4f, 0x53, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x57, 0x69, 0x74, 0x68, 0x49, 0x44, 0x52, 0x03, 0x74, 0x72, 0x78, 0x22, 0xb2,
+	0x02, 0x0a, 0x14, 0x45, 0x4f, 0x53, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x57, 0x69, 0x74, 0x68, 0x49, 0x44, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f,
+	0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x63,
+	0x6b, 0x65, 0x64, 0x5f, 0x74, 0x72, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70,
+	0x61, 0x63, 0x6b, 0x65, 0x64, 0x54, 0x72, 0x78, 0x12, 0x37, 0x0a, 0x18, 0x70, 0x61, 0x63, 0x6b,
+	0x65, 0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x66, 0x72, 0x65, 0x65, 0x5f,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x15, 0x70, 0x61, 0x63, 0x6b,
+	0x65, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x46, 0x72, 0x65, 0x65, 0x44, 0x61, 0x74,
+	0x61, 0x12, 0x2a, 0x0a, 0x11, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x66, 0x72, 0x65,
+	0x65, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x78, 0x74, 0x46, 0x72, 0x65, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x44, 0x0a,
+	0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x62, 0x69, 0x74, 0x70, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e,
+	0x45, 0x4f, 0x53, 0x55, 0x6e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x22, 0xc6, 0x03, 0x0a, 0x16, 0x45, 0x4f, 0x53, 0x55, 0x6e, 0x70, 0x61, 0x63,
+	0x6b, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e,
+	0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22,
+	0x0a, 0x0d, 0x72, 0x65, 0x66, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x72, 0x65, 0x66, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e,
+	0x75, 0x6d, 0x12, 0x28, 0x0a, 0x10, 0x72, 0x65, 0x66, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f,
+	0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x72, 0x65,
+	0x66, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x2d, 0x0a, 0x13,
+	0x6d, 0x61, 0x78, 0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x75, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x77, 0x6f,
+	0x72, 0x64, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x6d, 0x61, 0x78, 0x4e, 0x65,
+	0x74, 0x55, 0x73, 0x61, 0x67, 0x65, 0x57, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x27, 0x0a, 0x10, 0x6d,
+	0x61, 0x78, 0x5f, 0x63, 0x70, 0x75, 0x5f, 0x75, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x6d, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x6d, 0x61, 0x78, 0x43, 0x70, 0x75, 0x55, 0x73, 0x61,
+	0x67, 0x65, 0x4d, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x5f, 0x73, 0x65,
+	0x63, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x53, 0x65,
+	0x63, 0x12, 0x2f, 0x0a, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x07, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x62, 0x69, 0x74, 0x70, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e,
+	0x45, 0x4f, 0x53, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x47, 0x0a, 0x14, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x66, 0x72,
+	0x65, 0x65, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x62, 0x69, 0x74, 0x70, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x4f,
+	0x53, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x12, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74,
+	0x46, 0x72, 0x65, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x4f, 0x0a, 0x16, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x69,
+	0x74, 0x70, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x4f, 0x53, 0x45, 0x78, 0x74, 0x65,
+	0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x15, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x36, 0x0a, 0x0c,
+	0x45, 0x4f, 0x53, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x22, 0xbb, 0x01, 0x0a, 0x12, 0x45, 0x4f, 0x53, 0x50, 0x65, 0x72, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x61,
+	0x63, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x63, 0x74, 0x6f,
+	0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x32, 0x0a, 0x0b, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x62, 0x69, 0x74, 0x70, 0x69, 0x6e, 0x67,
+	0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x0a, 0x61, 0x63, 0x74, 0x6f, 0x72,
+	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x3b, 0x0a, 0x0d, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x62,
+	0x69, 0x74, 0x70, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x4f, 0x53, 0x41, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x52, 0x0c, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x41, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x22, 0xf2, 0x02, 0x0a, 0x0a, 0x45, 0x4f, 0x53, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x76, 0x69, 0x6c, 0x65,
+	0x67, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x70, 0x72, 0x69, 0x76, 0x69,
+	0x6c, 0x65, 0x67, 0x65, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x61, 0x6d, 0x5f, 0x71, 0x75, 0x6f,
+	0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x61, 0x6d, 0x51, 0x75, 0x6f,
+	0x74, 0x61, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x61, 0x6d, 0x5f, 0x75, 0x73, 0x61, 0x67, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x61, 0x6d, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x1d, 0x0a, 0x0a, 0x63, 0x70, 0x75, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x70, 0x75, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1d,
+	0x0a, 0x0a, 0x6e, 0x65, 0x74, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x6e, 0x65, 0x74, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x29, 0x0a,
+	0x03, 0x63, 0x70, 0x75, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x62, 0x69, 0x74,
+	0x70, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x4f, 0x53, 0x52, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x52, 0x03, 0x63, 0x70, 0x75, 0x12, 0x29, 0x0a, 0x03, 0x6e, 0x65, 0x74, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x62, 0x69, 0x74, 0x70, 0x69, 0x6e, 0x67, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x4f, 0x53, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x03,
+	0x6e, 0x65, 0x74, 0x12, 0x42, 0x0a, 0x0b, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x62, 0x69, 0x74, 0x70, 0x69,
+	0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x4f, 0x53, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x70, 0x65, 0x72, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x51, 0x0a, 0x0b, 0x45, 0x4f, 0x53, 0x52, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x75, 0x73, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x76,
+	0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x61,
+	0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x22, 0xcb, 0x01, 0x0a, 0x14, 0x45,
+	0x4f, 0x53, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x12,
+	0x1c, 0x0a, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x2c, 0x0a,
+	0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x69,
+	0x74, 0x70, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x4f, 0x53, 0x4b, 0x65, 0x79, 0x57,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x3b, 0x0a, 0x08, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e,
+	0x62, 0x69, 0x74, 0x70, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x4f, 0x53, 0x50, 0x65,
+	0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x52, 0x08,
+	0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x22, 0x38, 0x0a, 0x0c, 0x45, 0x4f, 0x53, 0x4b,
+	0x65, 0x79, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x22, 0x63, 0x0a, 0x13, 0x45, 0x4f, 0x53, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x63, 0x74,
+	0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x12,
+	0x1e, 0x0a, 0x0a, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x71, 0x0a, 0x13, 0x45, 0x74, 0x68, 0x65, 0x72,
+	0x65, 0x75, 0x6d, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2b,
+	0x0a, 0x11, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1b, 0x0a, 0x09, 0x67,
+	0x61, 0x73, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x67, 0x61, 0x73, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x67, 0x61, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x67, 0x61, 0x73, 0x22, 0xb3, 0x01, 0x0a, 0x07, 0x54,
+	0x78, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x20, 0x0a, 0x0c, 0x70, 0x72, 0x65, 0x76, 0x5f, 0x74,
+	0x78, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72,
+	0x65, 0x76, 0x54, 0x78, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x65, 0x76,
+	0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x70, 0x72,
+	0x65, 0x76, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65,
+	0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65,
+	0x6e, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x69, 0x6e, 0x62, 0x61, 0x73, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6f, 0x69, 0x6e, 0x62, 0x61, 0x73, 0x65, 0x12,
+	0x2f, 0x0a, 0x08, 0x70, 0x72, 0x65, 0x76, 0x5f, 0x6f, 0x75, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x62, 0x69, 0x74, 0x70, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x54,
+	0x78, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x07, 0x70, 0x72, 0x65, 0x76, 0x4f, 0x75, 0x74,
+	0x22, 0x89, 0x01, 0x0a, 0x08, 0x54, 0x78, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0xb5, 0x05, 0x0a,
+	0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x29, 0x0a, 0x10, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x48, 0x61, 0x73, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79,
+	0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x70, 0x72, 0x65, 0x63, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x29, 0x0a, 0x04, 0x66, 0x69,
+	0x61, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x62, 0x69, 0x74, 0x70, 0x69,
+	0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x61, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52,
+	0x04, 0x66, 0x69, 0x61, 0x74, 0x12, 0x30, 0x0a, 0x0a, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x6c, 0x61,
+	0x62, 0x65, 0x6c, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x62, 0x69, 0x74, 0x70,
+	0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x09, 0x66, 0x72,
+	0x6f, 0x6d, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x2c, 0x0a, 0x08, 0x74, 0x6f, 0x5f, 0x6c, 0x61,
+	0x62, 0x65, 0x6c, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x62, 0x69, 0x74, 0x70,
+	0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52, 0x07, 0x74, 0x6f,
+	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x36, 0x0a, 0x0c, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x61, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x62, 0x69,
+	0x74, 0x70, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x52, 0x0b, 0x66, 0x72, 0x6f, 0x6d, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x32, 0x0a,
+	0x0a, 0x74, 0x6f, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x62, 0x69, 0x74, 0x70, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x41,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x09, 0x74, 0x6f, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x29, 0x0a, 0x03, 0x65, 0x6f, 0x73, 0x18,
+	0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x62, 0x69, 0x74, 0x70, 0x69, 0x6e, 0x67, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x4f, 0x53, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x03,
+	0x65, 0x6f, 0x73, 0x12, 0x36, 0x0a, 0x08, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x18,
+	0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x69, 0x74, 0x70, 0x69, 0x6e, 0x67, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x43, 0x61, 0x6c, 0x6c, 0x48,
+	0x00, 0x52, 0x08, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x42, 0x07, 0x0a, 0x05, 0x63,
+	0x68, 0x61, 0x69, 0x6e, 0x22, 0x53, 0x0a, 0x09, 0x46, 0x69, 0x61, 0x74, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x45, 0x0a, 0x07, 0x41, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x65, 0x6e, 0x73, 0x12, 0x28, 0x0a, 0x03, 0x65, 0x6f, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x62, 0x69, 0x74, 0x70, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31,
+	0x2e, 0x45, 0x4f, 0x53, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x03, 0x65, 0x6f, 0x73,
+	0x22, 0x3b, 0x0a, 0x05, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x65, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x22, 0xae, 0x01,
+	0x0a, 0x09, 0x45, 0x4f, 0x53, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x44, 0x0a, 0x0d, 0x61, 0x75, 0x74,
+	0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1e, 0x2e, 0x62, 0x69, 0x74, 0x70, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x4f,
+	0x53, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x65, 0x76, 0x65, 0x6c,
+	0x52, 0x0d, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x19, 0x0a, 0x08, 0x68, 0x65, 0x78, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x68, 0x65, 0x78, 0x44, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x24,
+	0x0a, 0x0c, 0x45, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x43, 0x61, 0x6c, 0x6c, 0x12, 0x14,
+	0x0a, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x69,
+	0x6e, 0x70, 0x75, 0x74, 0x22, 0x49, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x37, 0x0a,
+	0x08, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x62, 0x69, 0x74, 0x70, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x74, 0x68,
+	0x65, 0x72, 0x65, 0x75, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x08, 0x65, 0x74,
+	0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x42, 0x07, 0x0a, 0x05, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x22,
+	0xb9, 0x01, 0x0a, 0x0d, 0x45, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x6f, 0x67, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6c, 0x6f, 0x67, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x2b,
+	0x0a, 0x11, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x18, 0x0a, 0x07, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f, 0x70,
+	0x69, 0x63, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x74, 0x6f, 0x70, 0x69, 0x63,
+	0x73, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x32, 0x7b, 0x0a, 0x07, 0x42,
+	0x69, 0x74, 0x70, 0x69, 0x6e, 0x67, 0x12, 0x34, 0x0a, 0x09, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x62, 0x65, 0x12, 0x12, 0x2e, 0x62, 0x69, 0x74, 0x70, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31,
+	0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x1a, 0x11, 0x2e, 0x62, 0x69, 0x74, 0x70, 0x69, 0x6e,
+	0x67, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x30, 0x01, 0x12, 0x3a, 0x0a, 0x08,
+	0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1b, 0x2e, 0x62, 0x69, 0x74, 0x70, 0x69,
+	0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x62, 0x69, 0x74, 0x70, 0x69, 0x6e, 0x67, 0x2e,
+	0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x1d, 0x5a, 0x1b, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x75, 0x73, 0x65, 0x72, 0x2f, 0x62, 0x69, 0x74,
+	0x70, 0x69, 0x6e, 0x67, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_bitping_proto_rawDescOnce sync.Once
+	file_bitping_proto_rawDescData = file_bitping_proto_rawDesc
+)
+
+func file_bitping_proto_rawDescGZIP() []byte {
+	file_bitping_proto_rawDescOnce.Do(func() {
+		file_bitping_proto_rawDescData = protoimpl.X.CompressGZIP(file_bitping_proto_rawDescData)
+	})
+	return file_bitping_proto_rawDescData
+}
+
+var file_bitping_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
+var file_bitping_proto_goTypes = []interface{}{
+	(*Filter)(nil),                 // 0: bitping.v1.Filter
+	(*GetBlockRequest)(nil),        // 1: bitping.v1.GetBlockRequest
+	(*Block)(nil),                  // 2: bitping.v1.Block
+	(*BitcoinBlock)(nil),           // 3: bitping.v1.BitcoinBlock
+	(*EOSBlock)(nil),               // 4: bitping.v1.EOSBlock
+	(*EthereumBlock)(nil),          // 5: bitping.v1.EthereumBlock
+	(*Transaction)(nil),            // 6: bitping.v1.Transaction
+	(*BitcoinTransaction)(nil),     // 7: bitping.v1.BitcoinTransaction
+	(*EOSTransactionReceipt)(nil),  // 8: bitping.v1.EOSTransactionReceipt
+	(*EOSTransactionWithID)(nil),   // 9: bitping.v1.EOSTransactionWithID
+	(*EOSUnpackedTransaction)(nil), // 10: bitping.v1.EOSUnpackedTransaction
+	(*EOSExtension)(nil),           // 11: bitping.v1.EOSExtension
+	(*EOSPermissionLevel)(nil),     // 12: bitping.v1.EOSPermissionLevel
+	(*EOSAccount)(nil),             // 13: bitping.v1.EOSAccount
+	(*EOSResource)(nil),            // 14: bitping.v1.EOSResource
+	(*EOSAccountPermission)(nil),   // 15: bitping.v1.EOSAccountPermission
+	(*EOSKeyWeight)(nil),           // 16: bitping.v1.EOSKeyWeight
+	(*EOSPermissionWeight)(nil),    // 17: bitping.v1.EOSPermissionWeight
+	(*EthereumTransaction)(nil),    // 18: bitping.v1.EthereumTransaction
+	(*TxInput)(nil),                // 19: bitping.v1.TxInput
+	(*TxOutput)(nil),               // 20: bitping.v1.TxOutput
+	(*Action)(nil),                 // 21: bitping.v1.Action
+	(*FiatValue)(nil),              // 22: bitping.v1.FiatValue
+	(*Account)(nil),                // 23: bitping.v1.Account
+	(*Label)(nil),                  // 24: bitping.v1.Label
+	(*EOSAction)(nil),              // 25: bitping.v1.EOSAction
+	(*EthereumCall)(nil),           // 26: bitping.v1.EthereumCall
+	(*Event)(nil),                  // 27: bitping.v1.Event
+	(*EthereumEvent)(nil),          // 28: bitping.v1.EthereumEvent
+}
+var file_bitping_proto_depIdxs = []int32{
+	6,  // 0: bitping.v1.Block.transactions:type_name -> bitping.v1.Transaction
+	3,  // 1: bitping.v1.Block.bitcoin:type_name -> bitping.v1.BitcoinBlock
+	4,  // 2: bitping.v1.Block.eos:type_name -> bitping.v1.EOSBlock
+	5,  // 3: bitping.v1.Block.ethereum:type_name -> bitping.v1.EthereumBlock
+	19, // 4: bitping.v1.Transaction.inputs:type_name -> bitping.v1.TxInput
+	20, // 5: bitping.v1.Transaction.outputs:type_name -> bitping.v1.TxOutput
+	21, // 6: bitping.v1.Transaction.actions:type_name -> bitping.v1.Action
+	27, // 7: bitping.v1.Transaction.events:type_name -> bitping.v1.Event
+	7,  // 8: bitping.v1.Transaction.bitcoin:type_name -> bitping.v1.BitcoinTransaction
+	8,  // 9: bitping.v1.Transaction.eos:type_name -> bitping.v1.EOSTransactionReceipt
+	18, // 10: bitping.v1.Transaction.ethereum:type_name -> bitping.v1.EthereumTransaction
+	9,  // 11: bitping.v1.EOSTransactionReceipt.trx:type_name -> bitping.v1.EOSTransactionWithID
+	10, // 12: bitping.v1.EOSTransactionWithID.transaction:type_name -> bitping.v1.EOSUnpackedTransaction
+	25, // 13: bitping.v1.EOSUnpackedTransaction.actions:type_name -> bitping.v1.EOSAction
+	25, // 14: bitping.v1.EOSUnpackedTransaction.context_free_actions:type_name -> bitping.v1.EOSAction
+	11, // 15: bitping.v1.EOSUnpackedTransaction.transaction_extensions:type_name -> bitping.v1.EOSExtension
+	24, // 16: bitping.v1.EOSPermissionLevel.actor_label:type_name -> bitping.v1.Label
+	13, // 17: bitping.v1.EOSPermissionLevel.actor_account:type_name -> bitping.v1.EOSAccount
+	14, // 18: bitping.v1.EOSAccount.cpu:type_name -> bitping.v1.EOSResource
+	14, // 19: bitping.v1.EOSAccount.net:type_name -> bitping.v1.EOSResource
+	15, // 20: bitping.v1.EOSAccount.permissions:type_name -> bitping.v1.EOSAccountPermission
+	16, // 21: bitping.v1.EOSAccountPermission.keys:type_name -> bitping.v1.EOSKeyWeight
+	17, // 22: bitping.v1.EOSAccountPermission.accounts:type_name -> bitping.v1.EOSPermissionWeight
+	20, // 23: bitping.v1.TxInput.prev_out:type_name -> bitping.v1.TxOutput
+	22, // 24: bitping.v1.Action.fiat:type_name -> bitping.v1.FiatValue
+	24, // 25: bitping.v1.Action.from_label:type_name -> bitping.v1.Label
+	24, // 26: bitping.v1.Action.to_label:type_name -> bitping.v1.Label
+	23, // 27: bitping.v1.Action.from_account:type_name -> bitping.v1.Account
+	23, // 28: bitping.v1.Action.to_account:type_name -> bitping.v1.Account
+	25, // 29: bitping.v1.Action.eos:type_name -> bitping.v1.EOSAction
+	26, // 30: bitping.v1.Action.ethereum:type_name -> bitping.v1.EthereumCall
+	13, // 31: bitping.v1.Account.eos:type_name -> bitping.v1.EOSAccount
+	12, // 32: bitping.v1.EOSAction.authorization:type_name -> bitping.v1.EOSPermissionLevel
+	28, // 33: bitping.v1.Event.ethereum:type_name -> bitping.v1.EthereumEvent
+	0,  // 34: bitping.v1.Bitping.Subscribe:input_type -> bitping.v1.Filter
+	1,  // 35: bitping.v1.Bitping.GetBlock:input_type -> bitping.v1.GetBlockRequest
+	2,  // 36: bitping.v1.Bitping.Subscribe:output_type -> bitping.v1.Block
+	2,  // 37: bitping.v1.Bitping.GetBlock:output_type -> bitping.v1.Block
+	36, // [36:38] is the sub-list for method output_type
+	34, // [34:36] is the sub-list for method input_type
+	34, // [34:34] is the sub-list for extension type_name
+	34, // [34:34] is the sub-list for extension extendee
+	0,  // [0:34] is the sub-list for field type_name
+}
+
+func init() { file_bitping_proto_init() }
+func file_bitping_proto_init() {
+	if File_bitping_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_bitping_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Filter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bitping_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBlockRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bitping_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Block); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bitping_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BitcoinBlock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bitping_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EOSBlock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bitping_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EthereumBlock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bitping_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Transaction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bitping_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BitcoinTransaction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bitping_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EOSTransactionReceipt); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bitping_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EOSTransactionWithID); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bitping_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EOSUnpackedTransaction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bitping_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EOSExtension); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bitping_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EOSPermissionLevel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bitping_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EOSAccount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bitping_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EOSResource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bitping_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EOSAccountPermission); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bitping_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EOSKeyWeight); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bitping_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EOSPermissionWeight); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bitping_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EthereumTransaction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bitping_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TxInput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bitping_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TxOutput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bitping_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Action); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bitping_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FiatValue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bitping_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Account); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bitping_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Label); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bitping_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EOSAction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bitping_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EthereumCall); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bitping_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Event); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bitping_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EthereumEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_bitping_proto_msgTypes[1].OneofWrappers = []interface{}{
+		(*GetBlockRequest_Number)(nil),
+		(*GetBlockRequest_Hash)(nil),
+	}
+	file_bitping_proto_msgTypes[2].OneofWrappers = []interface{}{
+		(*Block_Bitcoin)(nil),
+		(*Block_Eos)(nil),
+		(*Block_Ethereum)(nil),
+	}
+	file_bitping_proto_msgTypes[6].OneofWrappers = []interface{}{
+		(*Transaction_Bitcoin)(nil),
+		(*Transaction_Eos)(nil),
+		(*Transaction_Ethereum)(nil),
+	}
+	file_bitping_proto_msgTypes[7].OneofWrappers = []interface{}{}
+	file_bitping_proto_msgTypes[21].OneofWrappers = []interface{}{
+		(*Action_Eos)(nil),
+		(*Action_Ethereum)(nil),
+	}
+	file_bitping_proto_msgTypes[27].OneofWrappers = []interface{}{
+		(*Event_Ethereum)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_bitping_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   29,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_bitping_proto_goTypes,
+		DependencyIndexes: file_bitping_proto_depIdxs,
+		MessageInfos:      file_bitping_proto_msgTypes,
+	}.Build()
+	File_bitping_proto = out.File
+	file_bitping_proto_rawDesc = nil
+	file_bitping_proto_goTypes = nil
+	file_bitping_proto_depIdxs = nil
+}
@@ -0,0 +1,191 @@
+// Protobuf definition of the unified types in github.com/auser/bitping/types.
+//
+// The chain specific parts that the JSON encoding embeds as pointer structs
+// are a oneof here. Big integers are decimal strings.
+//
+// Sinks with `encoding: protobuf` publish these messages, so field numbers
+// are part of the wire format: never renumber or reuse them, and reserve the
+// numbers of removed fields.
+//
+// Regenerate the Go code in pb/ with `make proto`.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: bitping.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Bitping_Subscribe_FullMethodName = "/bitping.v1.Bitping/Subscribe"
+	Bitping_GetBlock_FullMethodName  = "/bitping.v1.Bitping/GetBlock"
+)
+
+// BitpingClient is the client API for Bitping service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BitpingClient interface {
+	// Subscribe streams blocks as they are delivered, filtered by network and
+	// address
+	Subscribe(ctx context.Context, in *Filter, opts ...grpc.CallOption) (Bitping_SubscribeClient, error)
+	// GetBlock returns a stored block by number or hash
+	GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*Block, error)
+}
+
+type bitpingClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBitpingClient(cc grpc.ClientConnInterface) BitpingClient {
+	return &bitpingClient{cc}
+}
+
+func (c *bitpingClient) Subscribe(ctx context.Context, in *Filter, opts ...grpc.CallOption) (Bitping_SubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Bitping_ServiceDesc.Streams[0], Bitping_Subscribe_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bitpingSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Bitping_SubscribeClient interface {
+	Recv() (*Block, error)
+	grpc.ClientStream
+}
+
+type bitpingSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *bitpingSubscribeClient) Recv() (*Block, error) {
+	m := new(Block)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *bitpingClient) GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*Block, error) {
+	out := new(Block)
+	err := c.cc.Invoke(ctx, Bitping_GetBlock_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BitpingServer is the server API for Bitping service.
+// All implementations must embed UnimplementedBitpingServer
+// for forward compatibility
+type BitpingServer interface {
+	// Subscribe streams blocks as they are delivered, filtered by network and
+	// address
+	Subscribe(*Filter, Bitping_SubscribeServer) error
+	// GetBlock returns a stored block by number or hash
+	GetBlock(context.Context, *GetBlockRequest) (*Block, error)
+	mustEmbedUnimplementedBitpingServer()
+}
+
+// UnimplementedBitpingServer must be embedded to have forward compatible implementations.
+type UnimplementedBitpingServer struct {
+}
+
+func (UnimplementedBitpingServer) Subscribe(*Filter, Bitping_SubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (UnimplementedBitpingServer) GetBlock(context.Context, *GetBlockRequest) (*Block, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlock not implemented")
+}
+func (UnimplementedBitpingServer) mustEmbedUnimplementedBitpingServer() {}
+
+// UnsafeBitpingServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BitpingServer will
+// result in compilation errors.
+type UnsafeBitpingServer interface {
+	mustEmbedUnimplementedBitpingServer()
+}
+
+func RegisterBitpingServer(s grpc.ServiceRegistrar, srv BitpingServer) {
+	s.RegisterService(&Bitping_ServiceDesc, srv)
+}
+
+func _Bitping_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Filter)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BitpingServer).Subscribe(m, &bitpingSubscribeServer{stream})
+}
+
+type Bitping_SubscribeServer interface {
+	Send(*Block) error
+	grpc.ServerStream
+}
+
+type bitpingSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *bitpingSubscribeServer) Send(m *Block) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Bitping_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BitpingServer).GetBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bitping_GetBlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BitpingServer).GetBlock(ctx, req.(*GetBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Bitping_ServiceDesc is the grpc.ServiceDesc for Bitping service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Bitping_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bitping.v1.Bitping",
+	HandlerType: (*BitpingServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBlock",
+			Handler:    _Bitping_GetBlock_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _Bitping_Subscribe_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "bitping.proto",
+}
@@ -0,0 +1,312 @@
+package pb
+
+import (
+	"github.com/auser/bitping/types"
+)
+
+// This file isn't generated, `make proto` leaves it alone
+
+// FromBlock converts a unified block, with its transactions
+func FromBlock(block types.Block) *Block {
+	b := &Block{
+		Hash:       block.Hash,
+		HeaderHash: block.HeaderHash,
+		Network:    block.Network,
+		NetworkId:  block.NetworkID,
+		Number:     block.Number,
+		Size:       block.Size,
+		Time:       block.Time,
+		Status:     block.Status,
+		Nonce:      block.Nonce,
+		Difficulty: bigString(block.Difficulty),
+		ParentHash: block.ParentHash,
+
+		SchemaVersion: types.SchemaVersion,
+	}
+
+	for _, tx := range block.Transactions {
+		b.Transactions = append(b.Transactions, FromTransaction(tx))
+	}
+
+	switch {
+	case block.BitcoinBlock != nil:
+		bb := block.BitcoinBlock
+		b.Chain = &Block_Bitcoin{Bitcoin: &BitcoinBlock{
+			Height:            bb.Height,
+			Confirmations:     bb.Confirmations,
+			StrippedSize:      bb.StrippedSize,
+			Weight:            bb.Weight,
+			Version:           bb.Version,
+			VersionHex:        bb.VersionHex,
+			Merkleroot:        bb.Merkleroot,
+			MedianTime:        bb.MedianTime,
+			Bits:              bb.Bits,
+			Chainwork:         bb.Chainwork,
+			PreviousBlockHash: bb.PreviousBlockHash,
+			NextBlockHash:     bb.NextBlockHash,
+		}}
+	case block.EOSBlock != nil:
+		eb := block.EOSBlock
+		b.Chain = &Block_Eos{Eos: &EOSBlock{
+			Producer:              eb.Producer,
+			Confirmed:             eb.Confirmed,
+			TransactionMerkleRoot: eb.TransactionMerkleRoot,
+			ActionMerkleRoot:      eb.ActionMerkleRoot,
+			ScheduleVersion:       eb.ScheduleVersion,
+			ProducerSignature:     eb.ProducerSignature,
+			RefBlockPrefix:        eb.RefBlockPrefix,
+			ChainId:               eb.ChainID,
+		}}
+	case block.EthereumBlock != nil:
+		eb := block.EthereumBlock
+		b.Chain = &Block_Ethereum{Ethereum: &EthereumBlock{
+			Sha3Uncles:       eb.Sha3Uncles,
+			LogsBloom:        eb.LogsBloom,
+			TransactionsRoot: eb.TransactionsRoot,
+			StateRoot:        eb.StateRoot,
+			Miner:            eb.Miner,
+			Coinbase:         eb.Coinbase,
+			TotalDifficulty:  bigString(eb.TotalDifficulty),
+			ExtraData:        eb.ExtraData,
+			GasLimit:         eb.GasLimit,
+			GasUsed:          eb.GasUsed,
+			Uncles:           eb.Uncles,
+		}}
+	}
+
+	return b
+}
+
+// FromTransaction converts a unified transaction, with its actions and
+// events
+func FromTransaction(tx types.Transaction) *Transaction {
+	t := &Transaction{
+		BlockHash:       tx.BlockHash,
+		BlockNumber:     tx.BlockNumber,
+		TransactionHash: tx.TransactionHash,
+		Hash:            tx.Hash,
+		Nonce:           tx.Nonce,
+		MempoolStatus:   tx.MempoolStatus,
+		ReplacedBy:      tx.ReplacedBy,
+		IsDerived:       tx.IsDerived,
+		DerivedIndex:    int64(tx.DerivedIndex),
+		Matches:         tx.Matches,
+	}
+
+	for _, in := range tx.Inputs {
+		t.Inputs = append(t.Inputs, fromTxInput(in))
+	}
+	for _, out := range tx.Outputs {
+		t.Outputs = append(t.Outputs, fromTxOutput(out))
+	}
+	for _, action := range tx.Actions {
+		t.Actions = append(t.Actions, FromAction(action))
+	}
+	for _, event := range tx.Events {
+		t.Events = append(t.Events, FromEvent(event))
+	}
+
+	switch {
+	case tx.BitcoinTransaction != nil:
+		bt := tx.BitcoinTransaction
+		t.Chain = &Transaction_Bitcoin{Bitcoin: &BitcoinTransaction{
+			WitnessHash: bt.WitnessHash,
+			Version:     bt.Version,
+			Size:        bt.Size,
+			Vsize:       bt.VSize,
+			Weight:      bt.Weight,
+			LockTime:    bt.LockTime,
+			Fee:         bt.Fee,
+		}}
+	case tx.EOSTransactionReceipt != nil:
+		t.Chain = &Transaction_Eos{Eos: fromEOSReceipt(tx.EOSTransactionReceipt)}
+	case tx.EthereumTransaction != nil:
+		et := tx.EthereumTransaction
+		t.Chain = &Transaction_Ethereum{Ethereum: &EthereumTransaction{
+			TransactionIndex: et.TransactionIndex,
+			GasPrice:         bigString(et.GasPrice),
+			Gas:              et.Gas,
+		}}
+	}
+
+	return t
+}
+
+// FromAction converts a unified action
+func FromAction(action types.Action) *Action {
+	a := &Action{
+		BlockHash:       action.BlockHash,
+		BlockNumber:     action.BlockNumber,
+		TransactionHash: action.TransactionHash,
+		Address:         action.Address,
+		Data:            action.Data,
+		From:            action.From,
+		To:              action.To,
+		Value:           bigString(action.Value),
+		Symbol:          action.Symbol,
+		Precision:       action.Precision,
+		Amount:          action.Amount,
+	}
+
+	if f := action.Fiat; f != nil {
+		a.Fiat = &FiatValue{Currency: f.Currency, Price: f.Price, Value: f.Value}
+	}
+	a.FromLabel = fromLabel(action.FromLabel)
+	a.ToLabel = fromLabel(action.ToLabel)
+	a.FromAccount = fromAccount(action.FromAccount)
+	a.ToAccount = fromAccount(action.ToAccount)
+
+	switch {
+	case action.EOSAction != nil:
+		a.Chain = &Action_Eos{Eos: fromEOSAction(*action.EOSAction)}
+	case action.EthereumCall != nil:
+		a.Chain = &Action_Ethereum{Ethereum: &EthereumCall{Input: action.EthereumCall.Input}}
+	}
+
+	return a
+}
+
+// FromEvent converts a unified event
+func FromEvent(event types.Event) *Event {
+	e := &Event{}
+	if ee := event.EthereumEvent; ee != nil {
+		e.Chain = &Event_Ethereum{Ethereum: &EthereumEvent{
+			LogIndex:         ee.LogIndex,
+			TransactionIndex: ee.TransactionIndex,
+			Address:          ee.Address,
+			Data:             ee.Data,
+			Topics:           ee.Topics,
+			Removed:          ee.Removed,
+		}}
+	}
+	return e
+}
+
+func fromTxOutput(out types.TxOutput) *TxOutput {
+	return &TxOutput{
+		Index:      out.Index,
+		Value:      out.Value,
+		Script:     out.Script,
+		ScriptType: out.ScriptType,
+		Address:    out.Address,
+	}
+}
+
+func fromTxInput(in types.TxInput) *TxInput {
+	i := &TxInput{
+		PrevTxHash: in.PrevTxHash,
+		PrevIndex:  in.PrevIndex,
+		Sequence:   in.Sequence,
+		Coinbase:   in.Coinbase,
+	}
+	if in.PrevOut != nil {
+		i.PrevOut = fromTxOutput(*in.PrevOut)
+	}
+	return i
+}
+
+func fromEOSReceipt(r *types.EOSTransactionReceipt) *EOSTransactionReceipt {
+	trx := r.TRX
+	unpacked := trx.Transaction
+
+	u := &EOSUnpackedTransaction{
+		Expiration:       unpacked.Expiration,
+		RefBlockNum:      unpacked.RefBlockNum,
+		RefBlockPrefix:   unpacked.RefBlockPrefix,
+		MaxNetUsageWords: unpacked.MaxNetUsageWords,
+		MaxCpuUsageMs:    unpacked.MaxCPUUsageMicroSeconds,
+		DelaySec:         unpacked.DelaySec,
+	}
+	for _, action := range unpacked.Actions {
+		u.Actions = append(u.Actions, fromEOSAction(action))
+	}
+	for _, action := range unpacked.ContextFreeActions {
+		u.ContextFreeActions = append(u.ContextFreeActions, fromEOSAction(action))
+	}
+	for _, ext := range unpacked.TransactionExtensions {
+		u.TransactionExtensions = append(u.TransactionExtensions, &EOSExtension{Type: ext.Type, Data: ext.Data})
+	}
+
+	return &EOSTransactionReceipt{
+		Status:        r.Status,
+		CpuUsageUs:    r.CPUUsageMicroSeconds,
+		NetUsageWords: r.NetUsageWords,
+		Trx: &EOSTransactionWithID{
+			Id:                    trx.ID,
+			Signatures:            trx.Signatures,
+			Compression:           trx.Compression,
+			PackedTrx:             trx.PackedTRX,
+			PackedContextFreeData: trx.PackedContextFreeData,
+			ContextFreeData:       trx.ContextFreeData,
+			Transaction:           u,
+		},
+	}
+}
+
+func fromEOSAction(action types.EOSAction) *EOSAction {
+	a := &EOSAction{
+		Account: action.Account,
+		Name:    action.Name,
+		HexData: action.HexData,
+		Data:    action.Data,
+	}
+	for _, auth := range action.Authorization {
+		a.Authorization = append(a.Authorization, &EOSPermissionLevel{
+			Actor:        auth.Actor,
+			Permission:   auth.Permisssion,
+			ActorLabel:   fromLabel(auth.ActorLabel),
+			ActorAccount: fromEOSAccount(auth.ActorAccount),
+		})
+	}
+	return a
+}
+
+func fromLabel(l *types.Label) *Label {
+	if l == nil {
+		return nil
+	}
+	return &Label{Entity: l.Entity, Category: l.Category}
+}
+
+func fromAccount(account *types.Account) *Account {
+	if account == nil {
+		return nil
+	}
+	return &Account{Ens: account.ENS, Eos: fromEOSAccount(account.EOS)}
+}
+
+func fromEOSAccount(account *types.EOSAccount) *EOSAccount {
+	if account == nil {
+		return nil
+	}
+	a := &EOSAccount{
+		Creator:    account.Creator,
+		Created:    account.Created,
+		Privileged: account.Privileged,
+		RamQuota:   account.RAMQuota,
+		RamUsage:   account.RAMUsage,
+		CpuWeight:  account.CPUWeight,
+		NetWeight:  account.NetWeight,
+		Cpu:        &EOSResource{Used: account.CPU.Used, Available: account.CPU.Available, Max: account.CPU.Max},
+		Net:        &EOSResource{Used: account.Net.Used, Available: account.Net.Available, Max: account.Net.Max},
+	}
+	for _, perm := range account.Permissions {
+		p := &EOSAccountPermission{Name: perm.Name, Parent: perm.Parent, Threshold: perm.Threshold}
+		for _, k := range perm.Keys {
+			p.Keys = append(p.Keys, &EOSKeyWeight{Key: k.Key, Weight: k.Weight})
+		}
+		for _, acc := range perm.Accounts {
+			p.Accounts = append(p.Accounts, &EOSPermissionWeight{Actor: acc.Actor, Permission: acc.Permission, Weight: acc.Weight})
+		}
+		a.Permissions = append(a.Permissions, p)
+	}
+	return a
+}
+
+// bigString is the decimal form of i, empty when it's nil
+func bigString(i *types.BigInt) string {
+	if i == nil {
+		return ""
+	}
+	return i.String()
+}
@@ -0,0 +1,276 @@
+package pipeline
+
+import (
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/auser/bitping/alerts"
+	"github.com/auser/bitping/config"
+	"github.com/auser/bitping/filter"
+	"github.com/auser/bitping/iface"
+	"github.com/auser/bitping/labels"
+	"github.com/auser/bitping/metadata"
+	"github.com/auser/bitping/prices"
+	"github.com/auser/bitping/tokens"
+	"github.com/auser/bitping/types"
+	"github.com/codegangsta/cli"
+)
+
+// Stage is a stage of a pipeline and how many blocks it may process at the
+// same time
+type Stage struct {
+	// metrics comes first so its counters are 64-bit aligned for atomic on
+	// 32-bit platforms
+	metrics metrics
+
+	iface.Stage
+	Concurrency int
+}
+
+// metrics are updated atomically while the pipeline runs
+type metrics struct {
+	processed int64
+	errors    int64
+	inFlight  int64
+	totalNs   int64
+	maxNs     int64
+}
+
+// Metrics is a snapshot of the metrics of a stage
+type Metrics struct {
+	Processed int64 `json:"processed"`
+	Errors    int64 `json:"errors"`
+	InFlight  int64 `json:"inFlight"`
+	// Average and maximum time spent processing a block
+	AverageMs float64 `json:"averageMs"`
+	MaxMs     float64 `json:"maxMs"`
+}
+
+// Pipeline runs blocks through its stages in order
+type Pipeline struct {
+	stages []*Stage
+}
+
+// New returns a pipeline running stages in the given order
+func New(stages ...*Stage) *Pipeline {
+	return &Pipeline{stages: stages}
+}
+
+// FromCLI builds the pipeline declared in the pipeline section of the
+// config file. Without one, every stage that's turned on runs once at a
+// time in the order of DefaultOrder
+func FromCLI(c *cli.Context, cfg *config.Config) (*Pipeline, error) {
+	available, err := stages(c, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	declared := cfg.Pipeline
+	if len(declared) == 0 {
+		for _, name := range DefaultOrder {
+			declared = append(declared, config.StageConfig{Stage: name})
+		}
+	}
+
+	p := New()
+	seen := make(map[string]bool)
+	for i, sc := range declared {
+		if _, known := available[sc.Stage]; !known {
+			return nil, fmt.Errorf("pipeline[%d]: unknown stage %q", i, sc.Stage)
+		}
+		if seen[sc.Stage] {
+			return nil, fmt.Errorf("pipeline[%d]: stage %q is already in the pipeline", i, sc.Stage)
+		}
+		seen[sc.Stage] = true
+
+		stage := available[sc.Stage]
+		if stage == nil {
+			// Declared but turned off, i.e. prices without a provider
+			continue
+		}
+		log.Printf("Pipeline stage %s", stage.Name())
+		p.stages = append(p.stages, &Stage{Stage: stage, Concurrency: sc.Concurrency})
+	}
+	return p, nil
+}
+
+// DefaultOrder is the order of the stages when the config file doesn't
+// declare a pipeline
+var DefaultOrder = []string{"tokens", "prices", "labels", "metadata", "alerts", "filters"}
+
+// stages returns the stages bitping knows about by name, nil for the ones
+// that aren't turned on
+func stages(c *cli.Context, cfg *config.Config) (map[string]iface.Stage, error) {
+	out := make(map[string]iface.Stage)
+
+	registry := tokens.FromConfig(cfg)
+	out["tokens"] = registry
+
+	enricher, err := prices.FromCLI(c, cfg, registry)
+	if err != nil {
+		return nil, err
+	}
+	out["prices"] = nil
+	if enricher != nil {
+		out["prices"] = enricher
+	}
+
+	l, err := labels.FromCLI(c, cfg)
+	if err != nil {
+		return nil, err
+	}
+	out["labels"] = nil
+	if l != nil {
+		out["labels"] = l
+	}
+
+	resolver, err := metadata.FromCLI(c, cfg)
+	if err != nil {
+		return nil, err
+	}
+	out["metadata"] = nil
+	if resolver != nil {
+		out["metadata"] = resolver
+	}
+
+	engine, err := alerts.FromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	out["alerts"] = nil
+	if engine != nil {
+		out["alerts"] = engine
+	}
+
+	filters, err := filter.FromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	out["filters"] = nil
+	if filters != nil {
+		out["filters"] = filters
+	}
+
+	return out, nil
+}
+
+// Stages returns the stages of the pipeline in order
+func (p *Pipeline) Stages() []*Stage {
+	return p.stages
+}
+
+// Stage returns the stage name of the pipeline, or nil when it isn't in it
+func (p *Pipeline) Stage(name string) iface.Stage {
+	for _, s := range p.stages {
+		if s.Name() == name {
+			return s.Stage
+		}
+	}
+	return nil
+}
+
+// Run runs the blocks of in through the stages and returns the channel
+// they come out of, in the order they went in. The returned channel is
+// closed once in is closed and drained. Stages work on a copy of each
+// block, so they never change what the watchers still hold
+func (p *Pipeline) Run(in <-chan types.Block) <-chan types.Block {
+	if len(p.stages) == 0 {
+		return in
+	}
+
+	copies := make(chan types.Block)
+	go func() {
+		defer close(copies)
+		for block := range in {
+			copies <- block.Copy()
+		}
+	}()
+
+	var out <-chan types.Block = copies
+	for _, s := range p.stages {
+		out = s.run(out)
+	}
+	return out
+}
+
+// Metrics returns a snapshot of the metrics of each stage by name
+func (p *Pipeline) Metrics() map[string]Metrics {
+	out := make(map[string]Metrics, len(p.stages))
+	for _, s := range p.stages {
+		out[s.Name()] = s.Metrics()
+	}
+	return out
+}
+
+// run processes up to Concurrency blocks of in at a time and emits them in
+// the order they arrived
+func (s *Stage) run(in <-chan types.Block) <-chan types.Block {
+	concurrency := s.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	out := make(chan types.Block)
+	// One block is waited on by the emitter, the rest wait in the queue
+	queue := make(chan chan types.Block, concurrency-1)
+
+	go func() {
+		defer close(queue)
+		for block := range in {
+			result := make(chan types.Block, 1)
+			queue <- result
+			go func(block types.Block) {
+				s.process(&block)
+				result <- block
+			}(block)
+		}
+	}()
+
+	go func() {
+		defer close(out)
+		for result := range queue {
+			out <- <-result
+		}
+	}()
+
+	return out
+}
+
+func (s *Stage) process(block *types.Block) {
+	atomic.AddInt64(&s.metrics.inFlight, 1)
+	start := time.Now()
+
+	err := s.Process(block)
+
+	elapsed := int64(time.Since(start))
+	atomic.AddInt64(&s.metrics.inFlight, -1)
+	atomic.AddInt64(&s.metrics.processed, 1)
+	atomic.AddInt64(&s.metrics.totalNs, elapsed)
+	for {
+		max := atomic.LoadInt64(&s.metrics.maxNs)
+		if elapsed <= max || atomic.CompareAndSwapInt64(&s.metrics.maxNs, max, elapsed) {
+			break
+		}
+	}
+
+	if err != nil {
+		atomic.AddInt64(&s.metrics.errors, 1)
+		log.Printf("Stage %s: block %d on %s: %v", s.Name(), block.Number, block.Network, err)
+	}
+}
+
+// Metrics returns a snapshot of the metrics of the stage
+func (s *Stage) Metrics() Metrics {
+	m := Metrics{
+		Processed: atomic.LoadInt64(&s.metrics.processed),
+		Errors:    atomic.LoadInt64(&s.metrics.errors),
+		InFlight:  atomic.LoadInt64(&s.metrics.inFlight),
+		MaxMs:     float64(atomic.LoadInt64(&s.metrics.maxNs)) / float64(time.Millisecond),
+	}
+	if m.Processed > 0 {
+		m.AverageMs = float64(atomic.LoadInt64(&s.metrics.totalNs)) / float64(m.Processed) / float64(time.Millisecond)
+	}
+	return m
+}
@@ -0,0 +1,98 @@
+package prices
+
+import (
+	"container/list"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/auser/bitping/config"
+	"github.com/auser/bitping/iface"
+	"github.com/codegangsta/cli"
+)
+
+// Cache remembers the prices of a provider, keyed by symbol, currency and
+// time rounded down to Resolution. Missing prices are remembered too, so
+// tokens without a price don't hit the provider for every action. It's
+// safe for concurrent use
+type Cache struct {
+	// Resolution is how close two times have to be to share a price
+	Resolution time.Duration
+	// Size is how many prices are kept, the least recently used go first
+	Size int
+
+	provider iface.PriceProvider
+
+	sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+type cacheEntry struct {
+	key   string
+	price *big.Rat
+}
+
+// NewCache caches the prices of provider
+func NewCache(provider iface.PriceProvider, size int, resolution time.Duration) *Cache {
+	if size <= 0 {
+		size = DefaultCacheSize
+	}
+	if resolution <= 0 {
+		resolution = time.Second
+	}
+	return &Cache{
+		Resolution: resolution,
+		Size:       size,
+		provider:   provider,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+// Name returns the name of the cached provider
+func (c *Cache) Name() string {
+	return c.provider.Name()
+}
+
+// ConfigureFromOptions configures the cached provider
+func (c *Cache) ConfigureFromOptions(opts config.Options, ctx *cli.Context) error {
+	return c.provider.ConfigureFromOptions(opts, ctx)
+}
+
+// Price returns the cached price, asking the provider on a miss. Errors
+// other than ErrNoPrice aren't cached
+func (c *Cache) Price(symbol, currency string, at time.Time) (*big.Rat, error) {
+	at = at.Truncate(c.Resolution)
+	key := fmt.Sprintf("%s/%d", priceKey(symbol, currency), at.Unix())
+
+	c.Lock()
+	if el, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(el)
+		price := el.Value.(*cacheEntry).price
+		c.Unlock()
+		if price == nil {
+			return nil, ErrNoPrice
+		}
+		return price, nil
+	}
+	c.Unlock()
+
+	price, err := c.provider.Price(symbol, currency, at)
+	if err != nil && err != ErrNoPrice {
+		return nil, err
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	if _, ok := c.entries[key]; !ok {
+		c.entries[key] = c.lru.PushFront(&cacheEntry{key: key, price: price})
+		for c.lru.Len() > c.Size {
+			oldest := c.lru.Back()
+			c.lru.Remove(oldest)
+			delete(c.entries, oldest.Value.(*cacheEntry).key)
+		}
+	}
+	return price, err
+}
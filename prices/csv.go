@@ -0,0 +1,150 @@
+package prices
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/auser/bitping/config"
+	"github.com/codegangsta/cli"
+)
+
+// CSVProvider prices tokens from a csv file, for offline use and backfills.
+// Options:
+//
+//	path     the csv file, required
+//	max_age  how old the last price before a time can be to still be used,
+//	         24h by default
+//
+// The file has a symbol,currency,time,price header and a row per price.
+// Times are RFC 3339 or unix seconds and rows can be in any order:
+//
+//	symbol,currency,time,price
+//	ETH,USD,2023-05-01T00:00:00Z,1867.52
+//	BTC,USD,1682899200,29233.10
+type CSVProvider struct {
+	// MaxAge is how old a price can be
+	MaxAge time.Duration
+
+	// prices of each symbol/currency, oldest first
+	prices map[string][]pricePoint
+}
+
+type pricePoint struct {
+	at    time.Time
+	price *big.Rat
+}
+
+// csvColumns is the header of a prices file
+var csvColumns = []string{"symbol", "currency", "time", "price"}
+
+// Name returns the name of the provider
+func (p *CSVProvider) Name() string {
+	return "csv"
+}
+
+// ConfigureFromOptions loads the csv file
+func (p *CSVProvider) ConfigureFromOptions(opts config.Options, c *cli.Context) error {
+	path := opts.String("path", "")
+	if path == "" {
+		return errors.New("path is required")
+	}
+	p.MaxAge = opts.Duration("max_age", 24*time.Hour)
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return p.Load(f)
+}
+
+// Load reads the prices of a csv file, replacing the ones loaded before
+func (p *CSVProvider) Load(r io.Reader) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(csvColumns)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return fmt.Errorf("prices header: %v", err)
+	}
+	for i, column := range csvColumns {
+		if strings.ToLower(header[i]) != column {
+			return fmt.Errorf("prices header must be %s", strings.Join(csvColumns, ","))
+		}
+	}
+
+	prices := make(map[string][]pricePoint)
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		at, err := parseTime(record[2])
+		if err != nil {
+			return fmt.Errorf("line %d: %v", line, err)
+		}
+		price, ok := new(big.Rat).SetString(record[3])
+		if !ok {
+			return fmt.Errorf("line %d: invalid price %q", line, record[3])
+		}
+
+		key := priceKey(record[0], record[1])
+		prices[key] = append(prices[key], pricePoint{at: at, price: price})
+	}
+
+	for _, points := range prices {
+		sort.SliceStable(points, func(i, j int) bool {
+			return points[i].at.Before(points[j].at)
+		})
+	}
+	p.prices = prices
+	return nil
+}
+
+// Price returns the last price of symbol at or before at, unless it's
+// older than MaxAge
+func (p *CSVProvider) Price(symbol, currency string, at time.Time) (*big.Rat, error) {
+	points := p.prices[priceKey(symbol, currency)]
+
+	// First price after at
+	i := sort.Search(len(points), func(i int) bool {
+		return points[i].at.After(at)
+	})
+	if i == 0 {
+		return nil, ErrNoPrice
+	}
+
+	point := points[i-1]
+	if p.MaxAge > 0 && at.Sub(point.at) > p.MaxAge {
+		return nil, ErrNoPrice
+	}
+	return point.price, nil
+}
+
+func parseTime(s string) (time.Time, error) {
+	if unix, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(unix, 0), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q, expected RFC 3339 or unix seconds", s)
+	}
+	return t, nil
+}
+
+func priceKey(symbol, currency string) string {
+	return strings.ToUpper(symbol) + "/" + strings.ToUpper(currency)
+}
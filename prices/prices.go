@@ -0,0 +1,171 @@
+package prices
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/auser/bitping/config"
+	"github.com/auser/bitping/iface"
+	"github.com/auser/bitping/tokens"
+	"github.com/auser/bitping/types"
+	"github.com/codegangsta/cli"
+)
+
+// ErrNoPrice is returned by providers that have no price for a token at
+// the time asked for
+var ErrNoPrice = errors.New("prices: no price")
+
+// Defaults of the prices options
+const (
+	DefaultCurrency   = "USD"
+	DefaultDecimals   = 2
+	DefaultCacheSize  = 10000
+	DefaultResolution = time.Minute
+)
+
+// priceFlags maps the cli flags to the config file options they override
+var priceFlags = map[string]string{
+	"prices":          "path",
+	"prices-provider": "provider",
+	"prices-currency": "currency",
+}
+
+// AddCLIFlags adds the prices flags
+func AddCLIFlags(fs []cli.Flag) []cli.Flag {
+	return append(fs,
+		cli.StringFlag{
+			Name:   "prices",
+			Usage:  "csv file of symbol,currency,time,price rows to value actions with",
+			EnvVar: "BITPING_PRICES",
+		},
+		cli.StringFlag{
+			Name:  "prices-provider",
+			Usage: "price provider, csv by default",
+		},
+		cli.StringFlag{
+			Name:  "prices-currency",
+			Usage: "fiat currency actions are valued in, USD by default",
+		},
+	)
+}
+
+// New returns an unconfigured price provider of the given type
+func New(kind string) (iface.PriceProvider, error) {
+	switch kind {
+	case "csv":
+		return &CSVProvider{}, nil
+	}
+	return nil, fmt.Errorf("unknown price provider: %s", kind)
+}
+
+// FromCLI returns the enricher set up with the --prices flags or in the
+// prices section of the config file, pricing the tokens of registry. It
+// returns nil when prices are off
+func FromCLI(c *cli.Context, cfg *config.Config, registry *tokens.Registry) (*Enricher, error) {
+	opts := cfg.Prices.WithFlags(c, priceFlags)
+	if !opts.Has("provider") && opts.String("path", "") == "" {
+		return nil, nil
+	}
+
+	provider, err := New(opts.String("provider", "csv"))
+	if err != nil {
+		return nil, err
+	}
+	if err := provider.ConfigureFromOptions(opts, c); err != nil {
+		return nil, fmt.Errorf("prices: %v", err)
+	}
+
+	cache := NewCache(provider,
+		int(opts.Int64("cache_size", DefaultCacheSize)),
+		opts.Duration("resolution", DefaultResolution))
+	return NewEnricher(cache, registry, opts.String("currency", DefaultCurrency), int(opts.Int64("decimals", DefaultDecimals))), nil
+}
+
+// Enricher values the amounts of actions in a fiat currency at the time of
+// their block
+type Enricher struct {
+	// Currency actions are valued in
+	Currency string
+	// Decimals of the values
+	Decimals int
+
+	provider iface.PriceProvider
+	tokens   *tokens.Registry
+}
+
+// NewEnricher returns an enricher valuing the tokens of registry in
+// currency with prices of provider
+func NewEnricher(provider iface.PriceProvider, registry *tokens.Registry, currency string, decimals int) *Enricher {
+	return &Enricher{
+		Currency: strings.ToUpper(currency),
+		Decimals: decimals,
+		provider: provider,
+		tokens:   registry,
+	}
+}
+
+// Name returns the name of the pipeline stage
+func (e *Enricher) Name() string {
+	return "prices"
+}
+
+// Process enriches block as a pipeline stage
+func (e *Enricher) Process(block *types.Block) error {
+	return e.Enrich(block)
+}
+
+// Enrich sets the fiat value of the actions of block that have an amount.
+// Prices are looked up by the price id of the token, so the coins of
+// testnets, which have none, and tokens without a price are skipped. When
+// the provider fails the other actions are still valued and the first error
+// is returned
+func (e *Enricher) Enrich(block *types.Block) error {
+	at := time.Unix(block.Time, 0)
+
+	var first error
+	for i := range block.Transactions {
+		actions := block.Transactions[i].Actions
+		for j := range actions {
+			a := &actions[j]
+			if a.Amount == "" || a.Symbol == "" || a.Fiat != nil {
+				continue
+			}
+
+			token, ok := e.tokens.Moved(block.Network, a)
+			if !ok || token.PriceID == "" {
+				continue
+			}
+
+			price, err := e.provider.Price(token.PriceID, e.Currency, at)
+			if err == ErrNoPrice {
+				continue
+			} else if err != nil {
+				if first == nil {
+					first = fmt.Errorf("prices: %s at %s: %v", token.PriceID, at.UTC().Format(time.RFC3339), err)
+				}
+				continue
+			}
+
+			amount, ok := new(big.Rat).SetString(a.Amount)
+			if !ok {
+				continue
+			}
+			a.Fiat = &types.FiatValue{
+				Currency: e.Currency,
+				Price:    formatPrice(price),
+				Value:    new(big.Rat).Mul(amount, price).FloatString(e.Decimals),
+			}
+		}
+	}
+	return first
+}
+
+// formatPrice prints price with up to 8 decimals, without trailing zeros
+func formatPrice(price *big.Rat) string {
+	s := price.FloatString(8)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
@@ -0,0 +1,72 @@
+package prices
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/auser/bitping/config"
+	"github.com/auser/bitping/tokens"
+	"github.com/auser/bitping/types"
+	"github.com/codegangsta/cli"
+)
+
+// fixedPrices has one price per price id, at any time
+type fixedPrices map[string]string
+
+func (p fixedPrices) Price(symbol, currency string, at time.Time) (*big.Rat, error) {
+	s, ok := p[symbol]
+	if !ok {
+		return nil, ErrNoPrice
+	}
+	price, _ := new(big.Rat).SetString(s)
+	return price, nil
+}
+
+func (p fixedPrices) Name() string { return "fixed" }
+
+func (p fixedPrices) ConfigureFromOptions(opts config.Options, c *cli.Context) error {
+	return nil
+}
+
+func TestEnrichByPriceID(t *testing.T) {
+	registry := tokens.Default()
+	e := NewEnricher(fixedPrices{"ETH": "2000", "BTC": "30000"}, registry, "usd", 2)
+
+	tests := []struct {
+		network string
+		action  types.Action
+		value   string
+	}{
+		{"ethereum", types.Action{Value: types.BigIntFromInt(1500000000000000000)}, "3000.00"},
+		{"goerli", types.Action{Value: types.BigIntFromInt(1500000000000000000)}, ""},
+		{"ropsten", types.Action{Value: types.BigIntFromInt(1500000000000000000)}, ""},
+		{"testnet3", types.Action{Value: types.BigIntFromInt(100000000)}, ""},
+		{"bitcoin", types.Action{Value: types.BigIntFromInt(100000000)}, "30000.00"},
+		// WETH is priced as ether
+		{"ethereum", types.Action{
+			EthereumCall: &types.EthereumCall{},
+			Address:      "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2",
+			Symbol:       "WETH",
+			Precision:    18,
+			Value:        types.BigIntFromInt(500000000000000000),
+			Amount:       "0.500000000000000000",
+		}, "1000.00"},
+		// Known, without a price from the provider
+		{"classic", types.Action{Value: types.BigIntFromInt(1000000000000000000)}, ""},
+	}
+	for _, tt := range tests {
+		block := types.Block{Network: tt.network, Time: 1700000000, Transactions: []types.Transaction{{Actions: []types.Action{tt.action}}}}
+		registry.Normalize(&block)
+		if err := e.Enrich(&block); err != nil {
+			t.Fatalf("%s: %v", tt.network, err)
+		}
+		a := block.Transactions[0].Actions[0]
+		switch {
+		case tt.value == "" && a.Fiat != nil:
+			t.Errorf("%s %s valued at %s %s", tt.network, a.Symbol, a.Fiat.Value, a.Fiat.Currency)
+		case tt.value != "" && (a.Fiat == nil || a.Fiat.Value != tt.value || a.Fiat.Currency != "USD"):
+			t.Errorf("%s %s fiat = %+v, want %s USD", tt.network, a.Symbol, a.Fiat, tt.value)
+		}
+	}
+}
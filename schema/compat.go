@@ -0,0 +1,132 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Breaking lists the changes from old to new that would break a consumer
+// written against old. Adding optional or required properties is fine,
+// consumers ignore what they don't know. Removing a property, making it
+// optional or nullable, or changing its type, pattern, minimum or constant
+// isn't
+func Breaking(old, new *Schema) []string {
+	c := &comparer{old: old, new: new, seen: make(map[string]bool)}
+	c.compare("", old, new)
+	sort.Strings(c.changes)
+	return c.changes
+}
+
+type comparer struct {
+	old, new *Schema
+	seen     map[string]bool
+	changes  []string
+}
+
+func (c *comparer) report(path, format string, args ...interface{}) {
+	if path == "" {
+		path = "(root)"
+	}
+	c.changes = append(c.changes, path+": "+fmt.Sprintf(format, args...))
+}
+
+func (c *comparer) compare(path string, old, new *Schema) {
+	old, new = resolve(c.old, old), resolve(c.new, new)
+
+	// Structs refer to each other, compare each pair once
+	key := fmt.Sprintf("%p/%p", old, new)
+	if c.seen[key] {
+		return
+	}
+	c.seen[key] = true
+
+	oldNull, old := unwrap(c.old, old)
+	newNull, new := unwrap(c.new, new)
+	if newNull && !oldNull {
+		c.report(path, "became nullable")
+	}
+
+	if old.Type != new.Type {
+		c.report(path, "type changed from %q to %q", old.Type, new.Type)
+		return
+	}
+	if old.Pattern != new.Pattern {
+		c.report(path, "pattern changed from %q to %q", old.Pattern, new.Pattern)
+	}
+	if !reflect.DeepEqual(old.Minimum, new.Minimum) {
+		c.report(path, "minimum changed")
+	}
+	if fmt.Sprint(old.Const) != fmt.Sprint(new.Const) {
+		c.report(path, "constant changed from %v to %v", old.Const, new.Const)
+	}
+
+	if old.Items != nil {
+		if new.Items == nil {
+			c.report(path, "items are no longer described")
+		} else {
+			c.compare(path+"[]", old.Items, new.Items)
+		}
+	}
+
+	required := make(map[string]bool, len(new.Required))
+	for _, name := range new.Required {
+		required[name] = true
+	}
+	for _, name := range old.Required {
+		if !required[name] {
+			if _, ok := new.Properties[name]; ok {
+				c.report(join(path, name), "is no longer required")
+			}
+		}
+	}
+
+	for name, p := range old.Properties {
+		np, ok := new.Properties[name]
+		if !ok {
+			c.report(join(path, name), "was removed")
+			continue
+		}
+		c.compare(join(path, name), p, np)
+	}
+}
+
+// resolve follows $refs into the $defs of root, including refs wrapped in
+// an anyOf only to carry a description
+func resolve(root, s *Schema) *Schema {
+	for {
+		switch {
+		case s.Ref != "":
+			def, ok := root.Defs[strings.TrimPrefix(s.Ref, "#/$defs/")]
+			if !ok {
+				return s
+			}
+			s = def
+		case len(s.AnyOf) == 1:
+			s = s.AnyOf[0]
+		default:
+			return s
+		}
+	}
+}
+
+// unwrap returns whether s accepts null, and s without the null
+func unwrap(root, s *Schema) (bool, *Schema) {
+	if len(s.AnyOf) != 2 {
+		return false, s
+	}
+	for i, alt := range s.AnyOf {
+		if alt.Type == "null" {
+			return true, resolve(root, s.AnyOf[1-i])
+		}
+	}
+	return false, s
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
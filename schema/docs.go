@@ -0,0 +1,70 @@
+package schema
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+)
+
+// Docs holds the Go doc comments of types, keyed by "Type" and
+// "Type.Field"
+type Docs map[string]string
+
+// LoadDocs reads the doc comments of the Go package in dir
+func LoadDocs(dir string) (Docs, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, nil, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make(Docs)
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					ts := spec.(*ast.TypeSpec)
+					doc := ts.Doc
+					if doc == nil && len(gen.Specs) == 1 {
+						doc = gen.Doc
+					}
+					if text := clean(doc); text != "" {
+						docs[ts.Name.Name] = text
+					}
+
+					st, ok := ts.Type.(*ast.StructType)
+					if !ok {
+						continue
+					}
+					for _, f := range st.Fields.List {
+						doc := f.Doc
+						if doc == nil {
+							doc = f.Comment
+						}
+						text := clean(doc)
+						if text == "" {
+							continue
+						}
+						for _, name := range f.Names {
+							docs[ts.Name.Name+"."+name.Name] = text
+						}
+					}
+				}
+			}
+		}
+	}
+	return docs, nil
+}
+
+// clean joins the lines of a comment into one
+func clean(cg *ast.CommentGroup) string {
+	if cg == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(cg.Text()), " ")
+}
@@ -0,0 +1,268 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/auser/bitping/types"
+)
+
+// Draft is the JSON Schema dialect of the generated schemas
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// BaseID prefixes the $id of the published schemas
+const BaseID = "https://github.com/auser/bitping/schema"
+
+// Schema is the subset of JSON Schema the generator needs
+type Schema struct {
+	Schema      string `json:"$schema,omitempty"`
+	ID          string `json:"$id,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Ref         string `json:"$ref,omitempty"`
+
+	Type    string      `json:"type,omitempty"`
+	Pattern string      `json:"pattern,omitempty"`
+	Minimum *int64      `json:"minimum,omitempty"`
+	Const   interface{} `json:"const,omitempty"`
+
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+
+	// AnyOf is only used to make a schema nullable
+	AnyOf []*Schema `json:"anyOf,omitempty"`
+
+	Defs map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// Roots are the published types, by the name of their schema file. They
+// carry a schemaVersion, which the same types nested in another don't
+var Roots = map[string]interface{}{
+	"block":       types.Block{},
+	"transaction": types.Transaction{},
+	"action":      types.Action{},
+}
+
+var (
+	bigIntType = reflect.TypeOf(types.BigInt{})
+	bytesType  = reflect.TypeOf(types.Bytes{})
+)
+
+// Generate returns the schemas of the Roots for the current SchemaVersion,
+// described with docs
+func Generate(docs Docs) map[string]*Schema {
+	out := make(map[string]*Schema, len(Roots))
+	for name, v := range Roots {
+		t := reflect.TypeOf(v)
+		g := &generator{docs: docs, defs: make(map[string]*Schema), root: t}
+		root := g.schema(t)
+		out[name] = &Schema{
+			Schema:      Draft,
+			ID:          fmt.Sprintf("%s/v%d/%s.schema.json", BaseID, types.SchemaVersion, name),
+			Title:       t.Name(),
+			Description: docs[t.Name()],
+			Ref:         root.Ref,
+			Defs:        g.defs,
+		}
+	}
+	return out
+}
+
+// Marshal encodes s the way it's published
+func Marshal(s *Schema) ([]byte, error) {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+type generator struct {
+	docs Docs
+	defs map[string]*Schema
+	// root is the type getting the schemaVersion
+	root reflect.Type
+}
+
+// schema returns the schema of t as encoding/json encodes it. Structs are
+// added to the $defs and referenced
+func (g *generator) schema(t reflect.Type) *Schema {
+	switch t {
+	case bigIntType:
+		return &Schema{Type: "string", Pattern: "^-?[0-9]+$"}
+	case bytesType:
+		return nullable(&Schema{Type: "string", Pattern: "^0x([0-9a-f]{2})*$"})
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return nullable(g.schema(t.Elem()))
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &Schema{Type: "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		zero := int64(0)
+		return &Schema{Type: "integer", Minimum: &zero}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return nullable(&Schema{Type: "array", Items: g.schema(t.Elem())})
+	case reflect.Map:
+		return nullable(&Schema{Type: "object"})
+	case reflect.Struct:
+		return g.object(t)
+	}
+	// Interfaces can hold anything
+	return &Schema{}
+}
+
+func (g *generator) object(t reflect.Type) *Schema {
+	ref := &Schema{Ref: "#/$defs/" + t.Name()}
+	if _, ok := g.defs[t.Name()]; ok {
+		return ref
+	}
+
+	s := &Schema{
+		Type:        "object",
+		Description: g.docs[t.Name()],
+		Properties:  make(map[string]*Schema),
+	}
+	// Reserve the name first, structs can refer to themselves
+	g.defs[t.Name()] = s
+
+	if t == g.root {
+		s.Properties["schemaVersion"] = &Schema{
+			Type:        "integer",
+			Const:       types.SchemaVersion,
+			Description: "Version of this schema",
+		}
+		s.Required = append(s.Required, "schemaVersion")
+	}
+
+	for _, f := range jsonFields(t) {
+		p := g.schema(f.typ)
+		if doc := g.docs[f.owner+"."+f.goName]; doc != "" {
+			p = describe(p, doc)
+		}
+		s.Properties[f.name] = p
+		if f.required {
+			s.Required = append(s.Required, f.name)
+		}
+	}
+
+	return ref
+}
+
+// field is a struct field as encoding/json sees it
+type field struct {
+	name     string
+	goName   string
+	owner    string
+	typ      reflect.Type
+	index    []int
+	required bool
+}
+
+// jsonFields lists the fields encoding/json encodes for t, with the fields
+// of embedded structs promoted. Like encoding/json, a shallower field hides
+// deeper ones of the same name, and fields at the same depth hide each
+// other. Fields of embedded pointers and omitempty fields aren't required
+func jsonFields(t reflect.Type) []field {
+	type level struct {
+		typ      reflect.Type
+		index    []int
+		required bool
+	}
+
+	var (
+		out     []field
+		seen    = make(map[string]bool)
+		current = []level{{typ: t, required: true}}
+	)
+	for len(current) > 0 {
+		var (
+			next  []level
+			found = make(map[string][]field)
+			order []string
+		)
+		for _, l := range current {
+			for i := 0; i < l.typ.NumField(); i++ {
+				sf := l.typ.Field(i)
+				tag := sf.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+				name, opts := parseTag(tag)
+				index := append(append([]int{}, l.index...), i)
+
+				if sf.Anonymous && name == "" {
+					ft := sf.Type
+					required := l.required
+					if ft.Kind() == reflect.Ptr {
+						ft, required = ft.Elem(), false
+					}
+					if ft.Kind() == reflect.Struct {
+						next = append(next, level{typ: ft, index: index, required: required})
+						continue
+					}
+				}
+				if sf.PkgPath != "" {
+					continue
+				}
+				if name == "" {
+					name = sf.Name
+				}
+				if seen[name] {
+					continue
+				}
+				if _, ok := found[name]; !ok {
+					order = append(order, name)
+				}
+				found[name] = append(found[name], field{
+					name:     name,
+					goName:   sf.Name,
+					owner:    l.typ.Name(),
+					typ:      sf.Type,
+					index:    index,
+					required: l.required && !strings.Contains(opts, "omitempty"),
+				})
+			}
+		}
+		for _, name := range order {
+			seen[name] = true
+			if fs := found[name]; len(fs) == 1 {
+				out = append(out, fs[0])
+			}
+		}
+		current = next
+	}
+	return out
+}
+
+func parseTag(tag string) (string, string) {
+	if i := strings.Index(tag, ","); i >= 0 {
+		return tag[:i], tag[i+1:]
+	}
+	return tag, ""
+}
+
+func nullable(s *Schema) *Schema {
+	return &Schema{AnyOf: []*Schema{s, {Type: "null"}}}
+}
+
+// describe sets the description of s, without touching a shared $ref
+func describe(s *Schema, doc string) *Schema {
+	if s.Ref != "" {
+		return &Schema{Description: doc, AnyOf: []*Schema{s}}
+	}
+	c := *s
+	c.Description = doc
+	return &c
+}
@@ -0,0 +1,291 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/auser/bitping/storage"
+	"github.com/auser/bitping/types"
+	"github.com/codegangsta/cli"
+	"github.com/rs/cors"
+)
+
+// MaxLimit caps the page size clients can ask for
+const MaxLimit = 1000
+
+// Options store the Server options
+type Options struct {
+	// Listen is the address to listen on, i.e. :8080
+	Listen string
+	// CORSOrigins are the origins allowed to call the API from a browser
+	CORSOrigins []string
+}
+
+// Server exposes the blocks indexed in a storage.Store as a REST API:
+//
+//	GET /v1/{network}/blocks/{number|hash|latest}
+//	GET /v1/{network}/tx/{hash}
+//	GET /v1/{network}/address/{address}/actions
+//	GET /v1/{network}/address/{address}/transactions
+//	GET /v1/metrics
+//
+// The address endpoints take from, to, offset and limit query parameters
+type Server struct {
+	Options Options
+	store   *storage.Store
+	mux     *http.ServeMux
+}
+
+// Page is the envelope of list responses
+type Page struct {
+	Items  interface{} `json:"items"`
+	Offset int         `json:"offset"`
+	Limit  int         `json:"limit"`
+	// NextOffset is set when there may be more items
+	NextOffset *int `json:"nextOffset,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// AddCLIFlags adds the server flags
+func AddCLIFlags(fs []cli.Flag) []cli.Flag {
+	return append(fs,
+		cli.StringFlag{
+			Name:   "listen",
+			Usage:  "address the api listens on",
+			Value:  ":8080",
+			EnvVar: "BITPING_LISTEN",
+		},
+		cli.StringSliceFlag{
+			Name:  "cors-origin",
+			Usage: "origin allowed to call the api from a browser, defaults to any",
+		},
+	)
+}
+
+// OptionsFromCLI reads the server flags
+func OptionsFromCLI(c *cli.Context) Options {
+	origins := c.StringSlice("cors-origin")
+	if len(origins) == 0 {
+		origins = []string{"*"}
+	}
+	return Options{
+		Listen:      c.String("listen"),
+		CORSOrigins: origins,
+	}
+}
+
+// New creates a Server over store
+func New(store *storage.Store, opts Options) *Server {
+	s := &Server{
+		Options: opts,
+		store:   store,
+		mux:     http.NewServeMux(),
+	}
+	s.mux.HandleFunc("/v1/", s.route)
+	return s
+}
+
+// Handle registers another handler on the server, i.e. the streaming
+// endpoints
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+// HandleMetrics serves the snapshots of metrics, by name, on /v1/metrics
+func (s *Server) HandleMetrics(metrics map[string]func() interface{}) {
+	s.mux.HandleFunc("/v1/metrics", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		out := make(map[string]interface{}, len(metrics))
+		for name, snapshot := range metrics {
+			out[name] = snapshot()
+		}
+		writeJSON(w, http.StatusOK, out)
+	})
+}
+
+// Handler returns the API handler wrapped with CORS
+func (s *Server) Handler() http.Handler {
+	return cors.New(cors.Options{
+		AllowedOrigins: s.Options.CORSOrigins,
+		AllowedMethods: []string{http.MethodGet},
+	}).Handler(s.mux)
+}
+
+// ListenAndServe serves the API on Options.Listen
+func (s *Server) ListenAndServe() error {
+	log.Printf("API listening on %s", s.Options.Listen)
+	return http.ListenAndServe(s.Options.Listen, s.Handler())
+}
+
+// route dispatches /v1/{network}/... requests
+func (s *Server) route(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/v1/"), "/"), "/")
+	switch {
+	case len(parts) == 3 && parts[1] == "blocks":
+		s.block(w, parts[0], parts[2])
+	case len(parts) == 3 && parts[1] == "tx":
+		s.transaction(w, parts[0], parts[2])
+	case len(parts) == 4 && parts[1] == "address" && parts[3] == "actions":
+		s.addressActions(w, r, parts[0], parts[2])
+	case len(parts) == 4 && parts[1] == "address" && parts[3] == "transactions":
+		s.addressTransactions(w, r, parts[0], parts[2])
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (s *Server) block(w http.ResponseWriter, network, id string) {
+	if id == "latest" {
+		head, err := s.store.Head(network)
+		if err != nil {
+			writeStoreError(w, err)
+			return
+		}
+		id = strconv.FormatInt(head, 10)
+	}
+
+	number, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		block, err := s.store.BlockByHash(network, id)
+		if err != nil {
+			writeStoreError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, block)
+		return
+	}
+
+	block, err := s.store.Block(network, number)
+	if err != nil {
+		writeStoreError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, block)
+}
+
+func (s *Server) transaction(w http.ResponseWriter, network, hash string) {
+	tx, err := s.store.Transaction(network, hash)
+	if err != nil {
+		writeStoreError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, tx.Versioned())
+}
+
+func (s *Server) addressActions(w http.ResponseWriter, r *http.Request, network, address string) {
+	q, err := parseQuery(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	actions, err := s.store.AddressActions(network, address, q)
+	if err != nil {
+		writeStoreError(w, err)
+		return
+	}
+	items := make([]types.VersionedAction, len(actions))
+	for i, action := range actions {
+		items[i] = action.Versioned()
+	}
+	writeJSON(w, http.StatusOK, newPage(items, len(items), q))
+}
+
+func (s *Server) addressTransactions(w http.ResponseWriter, r *http.Request, network, address string) {
+	q, err := parseQuery(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	txs, err := s.store.AddressTransactions(network, address, q)
+	if err != nil {
+		writeStoreError(w, err)
+		return
+	}
+	items := make([]types.VersionedTransaction, len(txs))
+	for i, tx := range txs {
+		items[i] = tx.Versioned()
+	}
+	writeJSON(w, http.StatusOK, newPage(items, len(items), q))
+}
+
+// parseQuery reads the from, to, offset and limit query parameters
+func parseQuery(r *http.Request) (storage.Query, error) {
+	q := storage.Query{Limit: storage.DefaultLimit}
+
+	for name, dst := range map[string]*int64{"from": &q.From, "to": &q.To} {
+		if v := r.URL.Query().Get(name); v != "" {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil || n < 0 {
+				return q, fmt.Errorf("invalid %s %q", name, v)
+			}
+			*dst = n
+		}
+	}
+	for name, dst := range map[string]*int{"offset": &q.Offset, "limit": &q.Limit} {
+		if v := r.URL.Query().Get(name); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				return q, fmt.Errorf("invalid %s %q", name, v)
+			}
+			*dst = n
+		}
+	}
+
+	if q.Limit == 0 {
+		q.Limit = storage.DefaultLimit
+	}
+	if q.Limit > MaxLimit {
+		q.Limit = MaxLimit
+	}
+	if q.To > 0 && q.To < q.From {
+		return q, fmt.Errorf("to is lower than from")
+	}
+	return q, nil
+}
+
+func newPage(items interface{}, count int, q storage.Query) Page {
+	page := Page{Items: items, Offset: q.Offset, Limit: q.Limit}
+	if count == q.Limit {
+		next := q.Offset + count
+		page.NextOffset = &next
+	}
+	return page
+}
+
+func writeStoreError(w http.ResponseWriter, err error) {
+	if err == storage.ErrNotFound {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	log.Printf("API storage error: %v", err)
+	writeError(w, http.StatusInternalServerError, "internal error")
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, errorResponse{Error: msg})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("API write error: %v", err)
+	}
+}
@@ -0,0 +1,134 @@
+package sinks
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Position is the last block of a network a sink delivered
+type Position struct {
+	Number int64  `json:"number"`
+	Hash   string `json:"hash"`
+	// Undelivered is the lowest height that couldn't be delivered. The
+	// position doesn't move past it until it is
+	Undelivered int64 `json:"undelivered,omitempty"`
+}
+
+// Next returns the height the sink needs blocks from
+func (pos Position) Next() int64 {
+	if pos.Undelivered > 0 {
+		return pos.Undelivered
+	}
+	return pos.Number + 1
+}
+
+// Checkpoint records the last delivered block of every network in a json
+// file. It's only kept in memory when it has no path
+type Checkpoint struct {
+	sync.Mutex
+
+	path      string
+	positions map[string]Position
+}
+
+// OpenCheckpoint loads the checkpoint at path, which doesn't have to exist
+// yet
+func OpenCheckpoint(path string) (*Checkpoint, error) {
+	cp := &Checkpoint{
+		path:      path,
+		positions: make(map[string]Position),
+	}
+	if path == "" {
+		return cp, nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cp, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &cp.positions); err != nil {
+		return nil, err
+	}
+	return cp, nil
+}
+
+// Positions returns a copy of the positions by network
+func (cp *Checkpoint) Positions() map[string]Position {
+	cp.Lock()
+	defer cp.Unlock()
+
+	out := make(map[string]Position, len(cp.positions))
+	for network, pos := range cp.positions {
+		out[network] = pos
+	}
+	return out
+}
+
+// Advance moves the position of network to the given block and saves the
+// checkpoint. After a reorg the position can move back. It doesn't move
+// past an undelivered height, and delivering that height clears it
+func (cp *Checkpoint) Advance(network string, number int64, hash string) error {
+	cp.Lock()
+	defer cp.Unlock()
+
+	pos := cp.positions[network]
+	switch {
+	case pos.Undelivered > 0 && number > pos.Undelivered:
+		return nil
+	case number == pos.Undelivered:
+		pos.Undelivered = 0
+	}
+	pos.Number, pos.Hash = number, hash
+	cp.positions[network] = pos
+	return cp.save()
+}
+
+// Hold records that the block of network at number wasn't delivered, unless
+// a lower height is already held, and saves the checkpoint
+func (cp *Checkpoint) Hold(network string, number int64) error {
+	cp.Lock()
+	defer cp.Unlock()
+
+	pos := cp.positions[network]
+	if pos.Undelivered > 0 && pos.Undelivered <= number {
+		return nil
+	}
+	pos.Undelivered = number
+	cp.positions[network] = pos
+	return cp.save()
+}
+
+// save writes the positions to the checkpoint file. The lock is held
+func (cp *Checkpoint) save() error {
+	if cp.path == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(cp.positions)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so a crash never leaves a truncated
+	// checkpoint behind
+	tmp, err := ioutil.TempFile(filepath.Dir(cp.path), filepath.Base(cp.path)+".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), cp.path)
+}
@@ -0,0 +1,191 @@
+package sinks
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/auser/bitping/config"
+)
+
+// KafkaPublisher publishes to a Kafka topic through the Confluent REST Proxy
+// v3 records api, which acknowledges every record once the brokers have it.
+// Options:
+//
+//	proxy      url of the REST Proxy, required
+//	topic      the topic, required
+//	cluster    the cluster id, looked up from the proxy when it's left out
+//	username   basic auth credentials for the proxy
+//	password
+//
+// Records are keyed by the network, so each network's records land in one
+// partition and stay in order. The message attributes are record headers
+type KafkaPublisher struct {
+	url      string
+	username string
+	password string
+	client   *http.Client
+}
+
+type kafkaData struct {
+	Type string `json:"type"`
+	Data []byte `json:"data"`
+}
+
+type kafkaKey struct {
+	Type string `json:"type"`
+	Data string `json:"data"`
+}
+
+type kafkaHeader struct {
+	Name  string `json:"name"`
+	Value []byte `json:"value"`
+}
+
+type kafkaRecord struct {
+	Key     kafkaKey      `json:"key"`
+	Value   kafkaData     `json:"value"`
+	Headers []kafkaHeader `json:"headers,omitempty"`
+}
+
+type kafkaResult struct {
+	ErrorCode int    `json:"error_code"`
+	Message   string `json:"message"`
+	Partition int    `json:"partition_id"`
+	Offset    int64  `json:"offset"`
+}
+
+type kafkaClusters struct {
+	Data []struct {
+		ClusterID string `json:"cluster_id"`
+	} `json:"data"`
+}
+
+// Configure reads the Kafka options and looks up the cluster id when it's
+// not set
+func (p *KafkaPublisher) Configure(opts config.Options) error {
+	proxy := strings.TrimRight(opts.String("proxy", ""), "/")
+	topic := opts.String("topic", "")
+	if proxy == "" || topic == "" {
+		return errors.New("proxy and topic are required")
+	}
+	p.username = opts.String("username", "")
+	p.password = opts.String("password", "")
+	p.client = &http.Client{Timeout: time.Minute}
+
+	cluster := opts.String("cluster", "")
+	if cluster == "" {
+		var clusters kafkaClusters
+		if err := p.get(proxy+"/v3/clusters", &clusters); err != nil {
+			return fmt.Errorf("looking up the kafka cluster: %v", err)
+		}
+		if len(clusters.Data) != 1 {
+			return fmt.Errorf("the proxy knows %d kafka clusters, set cluster", len(clusters.Data))
+		}
+		cluster = clusters.Data[0].ClusterID
+	}
+
+	p.url = fmt.Sprintf("%s/v3/clusters/%s/topics/%s/records", proxy, cluster, topic)
+	return nil
+}
+
+// Publish streams msgs as records in a single request and returns once the
+// proxy acknowledged every one of them
+func (p *KafkaPublisher) Publish(msgs []Message) error {
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, m := range msgs {
+		record := kafkaRecord{
+			Key:   kafkaKey{Type: "STRING", Data: m.Key},
+			Value: kafkaData{Type: "BINARY", Data: m.Data},
+		}
+		for _, name := range sortedKeys(m.Attributes) {
+			record.Headers = append(record.Headers, kafkaHeader{Name: name, Value: []byte(m.Attributes[name])})
+		}
+		if err := enc.Encode(record); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest("POST", p.url, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := p.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// The proxy answers with one result per record, in order
+	acked := 0
+	dec := json.NewDecoder(resp.Body)
+	for {
+		var res kafkaResult
+		if err := dec.Decode(&res); err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		if res.ErrorCode != http.StatusOK {
+			return fmt.Errorf("kafka produce: error %d: %s", res.ErrorCode, res.Message)
+		}
+		acked++
+	}
+	if acked != len(msgs) {
+		return fmt.Errorf("kafka produce: %d of %d records acknowledged", acked, len(msgs))
+	}
+	return nil
+}
+
+// Close does nothing, every publish already waited for its acknowledgement
+func (p *KafkaPublisher) Close() error {
+	return nil
+}
+
+func (p *KafkaPublisher) get(url string, v interface{}) error {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := p.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// do sends req with the credentials and turns error statuses into errors
+func (p *KafkaPublisher) do(req *http.Request) (*http.Response, error) {
+	if p.username != "" {
+		req.SetBasicAuth(p.username, p.password)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		data, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("kafka proxy: %s: %s", resp.Status, bytes.TrimSpace(data))
+	}
+	return resp, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
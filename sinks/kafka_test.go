@@ -0,0 +1,101 @@
+package sinks
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/auser/bitping/config"
+	"github.com/auser/bitping/types"
+)
+
+// kafkaProxy is a REST Proxy v3 acknowledging records until failing is set
+type kafkaProxy struct {
+	sync.Mutex
+	records []kafkaRecord
+	failing bool
+}
+
+func (p *kafkaProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == "GET" && r.URL.Path == "/v3/clusters":
+		w.Write([]byte(`{"data": [{"cluster_id": "lkc-1"}]}`))
+
+	case r.Method == "POST" && r.URL.Path == "/v3/clusters/lkc-1/topics/blocks/records":
+		p.Lock()
+		defer p.Unlock()
+		enc := json.NewEncoder(w)
+		dec := json.NewDecoder(r.Body)
+		for offset := int64(0); ; offset++ {
+			var record kafkaRecord
+			if err := dec.Decode(&record); err == io.EOF {
+				return
+			} else if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if p.failing {
+				enc.Encode(kafkaResult{ErrorCode: 500, Message: "not enough replicas"})
+				continue
+			}
+			p.records = append(p.records, record)
+			enc.Encode(kafkaResult{ErrorCode: 200, Offset: offset})
+		}
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestKafkaPublisher(t *testing.T) {
+	proxy := &kafkaProxy{}
+	srv := httptest.NewServer(proxy)
+	defer srv.Close()
+
+	s := newTestQueueSink(t, &KafkaPublisher{}, config.Options{
+		"proxy":       srv.URL,
+		"topic":       "blocks",
+		"granularity": PerTransaction,
+	})
+
+	block := testBlock("ethereum", 12)
+	if err := s.Publish(block); err != nil {
+		t.Fatal(err)
+	}
+	if len(proxy.records) != 2 {
+		t.Fatalf("proxy got %d records, want 2", len(proxy.records))
+	}
+	for i, record := range proxy.records {
+		if record.Key.Type != "STRING" || record.Key.Data != "ethereum" {
+			t.Errorf("record %d key = %+v, want the network", i, record.Key)
+		}
+		headers := make(map[string]string)
+		for _, h := range record.Headers {
+			headers[h.Name] = string(h.Value)
+		}
+		if headers[AttrNetwork] != "ethereum" || headers[AttrHeight] != "12" {
+			t.Errorf("record %d headers = %v", i, headers)
+		}
+		if headers[AttrTransaction] != block.Transactions[i].Hash {
+			t.Errorf("record %d is transaction %s, want %s in order", i, headers[AttrTransaction], block.Transactions[i].Hash)
+		}
+		var tx types.Transaction
+		if err := json.Unmarshal(record.Value.Data, &tx); err != nil || tx.Hash != block.Transactions[i].Hash {
+			t.Errorf("record %d value: %v", i, err)
+		}
+	}
+	if got := s.Checkpoint()["ethereum"].Number; got != 12 {
+		t.Fatalf("checkpoint = %d, want 12", got)
+	}
+
+	proxy.failing = true
+	if err := s.Publish(testBlock("ethereum", 13)); err == nil {
+		t.Fatal("expected the unacknowledged records to fail")
+	}
+	if got := s.Checkpoint()["ethereum"].Number; got != 12 {
+		t.Fatalf("checkpoint moved to %d without an ack", got)
+	}
+}
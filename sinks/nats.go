@@ -0,0 +1,106 @@
+package sinks
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/auser/bitping/config"
+	nats "github.com/nats-io/nats.go"
+)
+
+// NATSPublisher publishes to NATS subjects named after the network, i.e.
+// bitping.ethereum. Options:
+//
+//	url          server url, nats://127.0.0.1:4222 by default
+//	subject      subject prefix, bitping by default
+//	credentials  user credentials file
+//	jetstream    publish to JetStream and wait for its acks, true by
+//	             default. A stream has to capture the subjects. Without
+//	             it publishing only waits for the server to have the
+//	             messages
+//	timeout      how long to wait for acknowledgements, 30s by default
+//
+// The message attributes are NATS headers. JetStream deduplicates retried
+// messages by their Nats-Msg-Id header
+type NATSPublisher struct {
+	subject string
+	timeout time.Duration
+
+	conn *nats.Conn
+	js   nats.JetStreamContext
+}
+
+// Configure reads the NATS options and connects
+func (p *NATSPublisher) Configure(opts config.Options) error {
+	p.subject = opts.String("subject", "bitping")
+	p.timeout = opts.Duration("timeout", 30*time.Second)
+
+	natsOpts := []nats.Option{nats.Name("bitping"), nats.MaxReconnects(-1)}
+	if creds := opts.String("credentials", ""); creds != "" {
+		natsOpts = append(natsOpts, nats.UserCredentials(creds))
+	}
+
+	var err error
+	if p.conn, err = nats.Connect(opts.String("url", nats.DefaultURL), natsOpts...); err != nil {
+		return err
+	}
+
+	if opts.Bool("jetstream", true) {
+		if p.js, err = p.conn.JetStream(nats.MaxWait(p.timeout)); err != nil {
+			p.conn.Close()
+			return err
+		}
+	}
+	return nil
+}
+
+// Publish publishes msgs and waits for every acknowledgement
+func (p *NATSPublisher) Publish(msgs []Message) error {
+	if p.js == nil {
+		for _, m := range msgs {
+			if err := p.conn.PublishMsg(p.message(m)); err != nil {
+				return err
+			}
+		}
+		return p.conn.FlushTimeout(p.timeout)
+	}
+
+	futures := make([]nats.PubAckFuture, 0, len(msgs))
+	for _, m := range msgs {
+		f, err := p.js.PublishMsgAsync(p.message(m))
+		if err != nil {
+			return err
+		}
+		futures = append(futures, f)
+	}
+
+	timeout := time.After(p.timeout)
+	for _, f := range futures {
+		select {
+		case <-f.Ok():
+		case err := <-f.Err():
+			return err
+		case <-timeout:
+			return fmt.Errorf("nats: timed out waiting for acknowledgements")
+		}
+	}
+	return nil
+}
+
+func (p *NATSPublisher) message(m Message) *nats.Msg {
+	msg := nats.NewMsg(p.subject + "." + m.Key)
+	msg.Data = m.Data
+	for k, v := range m.Attributes {
+		msg.Header.Set(k, v)
+	}
+	msg.Header.Set(nats.MsgIdHdr, m.ID())
+	return msg
+}
+
+// Close drains and closes the connection
+func (p *NATSPublisher) Close() error {
+	if p.conn == nil {
+		return nil
+	}
+	return p.conn.Drain()
+}
@@ -0,0 +1,181 @@
+package sinks
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/auser/bitping/config"
+	nats "github.com/nats-io/nats.go"
+)
+
+// natsServer speaks enough of the NATS client protocol to take published
+// messages and, when ack is set, answer them like JetStream does
+type natsServer struct {
+	ln  net.Listener
+	ack bool
+
+	sync.Mutex
+	msgs []natsMsg
+}
+
+type natsMsg struct {
+	subject string
+	header  textproto.MIMEHeader
+	data    []byte
+}
+
+func newNATSServer(t *testing.T, ack bool) *natsServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &natsServer{ln: ln, ack: ack}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *natsServer) URL() string {
+	return "nats://" + s.ln.Addr().String()
+}
+
+func (s *natsServer) Close() {
+	s.ln.Close()
+}
+
+func (s *natsServer) Messages() []natsMsg {
+	s.Lock()
+	defer s.Unlock()
+	return append([]natsMsg(nil), s.msgs...)
+}
+
+func (s *natsServer) serve(conn net.Conn) {
+	defer conn.Close()
+	fmt.Fprintf(conn, "INFO {\"server_id\":\"test\",\"version\":\"2.10.0\",\"proto\":1,\"headers\":true,\"max_payload\":1048576}\r\n")
+
+	r := bufio.NewReader(conn)
+	subs := make(map[string]string) // subject prefix of wildcard subs -> sid
+	seq := 0
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		args := strings.Fields(line)
+		if len(args) == 0 {
+			continue
+		}
+
+		switch strings.ToUpper(args[0]) {
+		case "PING":
+			io.WriteString(conn, "PONG\r\n")
+
+		case "SUB":
+			subs[strings.TrimSuffix(args[1], "*")] = args[len(args)-1]
+
+		case "HPUB":
+			// HPUB subject [reply] header-size total-size
+			hdrLen, _ := strconv.Atoi(args[len(args)-2])
+			total, _ := strconv.Atoi(args[len(args)-1])
+			payload := make([]byte, total+2)
+			if _, err := io.ReadFull(r, payload); err != nil {
+				return
+			}
+			tp := textproto.NewReader(bufio.NewReader(bytes.NewReader(payload[:hdrLen])))
+			tp.ReadLine() // NATS/1.0
+			header, _ := tp.ReadMIMEHeader()
+
+			s.Lock()
+			s.msgs = append(s.msgs, natsMsg{subject: args[1], header: header, data: payload[hdrLen:total]})
+			s.Unlock()
+
+			if len(args) == 5 && s.ack {
+				reply := args[2]
+				for prefix, sid := range subs {
+					if strings.HasPrefix(reply, prefix) {
+						seq++
+						ack := fmt.Sprintf(`{"stream":"BITPING","seq":%d}`, seq)
+						fmt.Fprintf(conn, "MSG %s %s %d\r\n%s\r\n", reply, sid, len(ack), ack)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestNATSPublisher(t *testing.T) {
+	srv := newNATSServer(t, true)
+	defer srv.Close()
+
+	p := &NATSPublisher{}
+	s := newTestQueueSink(t, p, config.Options{"url": srv.URL()})
+	defer s.Close()
+
+	for _, block := range []struct {
+		network string
+		number  int64
+	}{{"ethereum", 20}, {"classic", 21}} {
+		if err := s.Publish(testBlock(block.network, block.number)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	msgs := srv.Messages()
+	if len(msgs) != 2 {
+		t.Fatalf("server got %d messages, want 2", len(msgs))
+	}
+	for i, want := range []struct{ subject, network, height string }{
+		{"bitping.ethereum", "ethereum", "20"},
+		{"bitping.classic", "classic", "21"},
+	} {
+		m := msgs[i]
+		if m.subject != want.subject {
+			t.Errorf("message %d subject = %s, want %s", i, m.subject, want.subject)
+		}
+		if m.header.Get(AttrNetwork) != want.network || m.header.Get(AttrHeight) != want.height {
+			t.Errorf("message %d headers = %v", i, m.header)
+		}
+		if m.header.Get(nats.MsgIdHdr) == "" {
+			t.Errorf("message %d has no %s for deduplication", i, nats.MsgIdHdr)
+		}
+		if !bytes.HasPrefix(m.data, []byte("{")) {
+			t.Errorf("message %d data isn't the json block: %.20s", i, m.data)
+		}
+	}
+	if got := s.Checkpoint()["classic"].Number; got != 21 {
+		t.Fatalf("checkpoint = %d, want 21", got)
+	}
+}
+
+func TestNATSPublisherWithoutAcks(t *testing.T) {
+	srv := newNATSServer(t, false)
+	defer srv.Close()
+
+	s := newTestQueueSink(t, &NATSPublisher{}, config.Options{"url": srv.URL(), "timeout": "200ms"})
+	defer s.Close()
+
+	if err := s.Publish(testBlock("ethereum", 40)); err == nil {
+		t.Fatal("expected publishing to time out without JetStream acks")
+	}
+	if got := s.Checkpoint()["ethereum"]; got.Number != 0 || got.Undelivered != 40 {
+		t.Fatalf("checkpoint = %+v without an ack, want 40 undelivered", got)
+	}
+	if len(srv.Messages()) != 1 {
+		t.Fatalf("server got %d messages, want 1", len(srv.Messages()))
+	}
+}
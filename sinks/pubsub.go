@@ -0,0 +1,164 @@
+package sinks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/auser/bitping/config"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const pubsubScope = "https://www.googleapis.com/auth/pubsub"
+
+// pubsubBatchSize is the most messages the publish api takes at once
+const pubsubBatchSize = 1000
+
+// PubSubPublisher publishes to a Google Cloud Pub/Sub topic through the
+// REST api. Options:
+//
+//	project          the gcp project, required
+//	topic            the topic id, required
+//	endpoint         api endpoint, https://pubsub.googleapis.com by default.
+//	                 Ordering keys need a regional endpoint like
+//	                 https://us-east1-pubsub.googleapis.com
+//	emulator_host    host:port of the Pub/Sub emulator, defaults to
+//	                 $PUBSUB_EMULATOR_HOST. No credentials are used with it
+//	credentials      service account json file, the application default
+//	                 credentials otherwise
+//	ordering         set the ordering key, true by default
+//
+// Subscriptions need message ordering enabled to get each network's
+// messages in order
+type PubSubPublisher struct {
+	url      string
+	client   *http.Client
+	ordering bool
+}
+
+type pubsubMessage struct {
+	Data        []byte            `json:"data"`
+	Attributes  map[string]string `json:"attributes,omitempty"`
+	OrderingKey string            `json:"orderingKey,omitempty"`
+}
+
+type pubsubPublishRequest struct {
+	Messages []pubsubMessage `json:"messages"`
+}
+
+type pubsubPublishResponse struct {
+	MessageIDs []string `json:"messageIds"`
+}
+
+// Configure reads the Pub/Sub options and sets up the credentials
+func (p *PubSubPublisher) Configure(opts config.Options) error {
+	project := opts.String("project", "")
+	topic := opts.String("topic", "")
+	if project == "" || topic == "" {
+		return errors.New("project and topic are required")
+	}
+	p.ordering = opts.Bool("ordering", true)
+
+	endpoint := strings.TrimRight(opts.String("endpoint", "https://pubsub.googleapis.com"), "/")
+	emulator := opts.String("emulator_host", os.Getenv("PUBSUB_EMULATOR_HOST"))
+
+	switch {
+	case emulator != "":
+		endpoint = "http://" + emulator
+		p.client = &http.Client{Timeout: time.Minute}
+
+	case opts.Has("credentials"):
+		data, err := ioutil.ReadFile(opts.String("credentials", ""))
+		if err != nil {
+			return err
+		}
+		creds, err := google.CredentialsFromJSON(context.Background(), data, pubsubScope)
+		if err != nil {
+			return err
+		}
+		p.client = oauthClient(creds)
+
+	default:
+		creds, err := google.FindDefaultCredentials(context.Background(), pubsubScope)
+		if err != nil {
+			return err
+		}
+		p.client = oauthClient(creds)
+	}
+
+	p.url = fmt.Sprintf("%s/v1/projects/%s/topics/%s:publish", endpoint, project, topic)
+	return nil
+}
+
+// Publish publishes msgs in batches and returns once Pub/Sub assigned every
+// one of them an id
+func (p *PubSubPublisher) Publish(msgs []Message) error {
+	for len(msgs) > 0 {
+		n := len(msgs)
+		if n > pubsubBatchSize {
+			n = pubsubBatchSize
+		}
+		if err := p.publish(msgs[:n]); err != nil {
+			return err
+		}
+		msgs = msgs[n:]
+	}
+	return nil
+}
+
+func (p *PubSubPublisher) publish(msgs []Message) error {
+	req := pubsubPublishRequest{Messages: make([]pubsubMessage, len(msgs))}
+	for i, m := range msgs {
+		req.Messages[i] = pubsubMessage{Data: m.Data, Attributes: m.Attributes}
+		if p.ordering {
+			req.Messages[i].OrderingKey = m.Key
+		}
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	resp, err := p.client.Post(p.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("pubsub publish: %s: %s", resp.Status, bytes.TrimSpace(data))
+	}
+
+	var res pubsubPublishResponse
+	if err := json.Unmarshal(data, &res); err != nil {
+		return err
+	}
+	if len(res.MessageIDs) != len(msgs) {
+		return fmt.Errorf("pubsub publish: %d of %d messages acknowledged", len(res.MessageIDs), len(msgs))
+	}
+	return nil
+}
+
+// Close does nothing, every publish already waited for its acknowledgement
+func (p *PubSubPublisher) Close() error {
+	return nil
+}
+
+func oauthClient(creds *google.Credentials) *http.Client {
+	client := oauth2.NewClient(context.Background(), creds.TokenSource)
+	client.Timeout = time.Minute
+	return client
+}
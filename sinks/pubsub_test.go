@@ -0,0 +1,112 @@
+package sinks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/auser/bitping/config"
+)
+
+// pubsubEmulator answers publish requests with message ids until failing
+// is set
+type pubsubEmulator struct {
+	sync.Mutex
+	messages []pubsubMessage
+	failing  bool
+}
+
+func (e *pubsubEmulator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" || r.URL.Path != "/v1/projects/demo/topics/blocks:publish" {
+		http.NotFound(w, r)
+		return
+	}
+	var req pubsubPublishRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	e.Lock()
+	defer e.Unlock()
+	if e.failing {
+		http.Error(w, `{"error": {"code": 503, "status": "UNAVAILABLE"}}`, http.StatusServiceUnavailable)
+		return
+	}
+	var res pubsubPublishResponse
+	for _, m := range req.Messages {
+		e.messages = append(e.messages, m)
+		res.MessageIDs = append(res.MessageIDs, fmt.Sprint(len(e.messages)))
+	}
+	json.NewEncoder(w).Encode(res)
+}
+
+func TestPubSubPublisher(t *testing.T) {
+	emulator := &pubsubEmulator{}
+	srv := httptest.NewServer(emulator)
+	defer srv.Close()
+
+	opts := func(extra config.Options) config.Options {
+		o := config.Options{
+			"project":       "demo",
+			"topic":         "blocks",
+			"emulator_host": strings.TrimPrefix(srv.URL, "http://"),
+			"granularity":   PerAction,
+		}
+		for k, v := range extra {
+			o[k] = v
+		}
+		return o
+	}
+
+	s := newTestQueueSink(t, &PubSubPublisher{}, opts(nil))
+	if err := s.Publish(testBlock("ethereum", 30)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Publish(testBlock("classic", 31)); err != nil {
+		t.Fatal(err)
+	}
+	if len(emulator.messages) != 4 {
+		t.Fatalf("emulator got %d messages, want 4", len(emulator.messages))
+	}
+	for i, m := range emulator.messages {
+		network, height := "ethereum", "30"
+		if i >= 2 {
+			network, height = "classic", "31"
+		}
+		if m.OrderingKey != network {
+			t.Errorf("message %d ordering key = %q, want %q", i, m.OrderingKey, network)
+		}
+		if m.Attributes[AttrNetwork] != network || m.Attributes[AttrHeight] != height {
+			t.Errorf("message %d attributes = %v", i, m.Attributes)
+		}
+		if m.Attributes[AttrIndex] != fmt.Sprint(i%2) {
+			t.Errorf("message %d index = %s, want the actions in order", i, m.Attributes[AttrIndex])
+		}
+	}
+	if got := s.Checkpoint()["ethereum"].Number; got != 30 {
+		t.Fatalf("checkpoint = %d, want 30", got)
+	}
+
+	emulator.failing = true
+	if err := s.Publish(testBlock("ethereum", 32)); err == nil {
+		t.Fatal("expected the rejected publish to fail")
+	}
+	if got := s.Checkpoint()["ethereum"].Number; got != 30 {
+		t.Fatalf("checkpoint moved to %d without an ack", got)
+	}
+
+	emulator.failing = false
+	emulator.messages = nil
+	s = newTestQueueSink(t, &PubSubPublisher{}, opts(config.Options{"ordering": false}))
+	if err := s.Publish(testBlock("ethereum", 33)); err != nil {
+		t.Fatal(err)
+	}
+	if key := emulator.messages[0].OrderingKey; key != "" {
+		t.Errorf("ordering key %q set with ordering turned off", key)
+	}
+}
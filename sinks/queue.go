@@ -0,0 +1,376 @@
+package sinks
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/auser/bitping/config"
+	"github.com/auser/bitping/pb"
+	"github.com/auser/bitping/types"
+	"github.com/codegangsta/cli"
+	backoff "github.com/jpillora/backoff"
+	"google.golang.org/protobuf/proto"
+)
+
+// Granularities of the messages published by a QueueSink
+const (
+	// PerBlock publishes every block as a single message
+	PerBlock = "block"
+	// PerTransaction publishes a message for every transaction
+	PerTransaction = "transaction"
+	// PerAction publishes a message for every action
+	PerAction = "action"
+)
+
+// Encodings of the messages published by a QueueSink
+const (
+	// EncodingJSON is the unified json, described by the schema in schema/
+	EncodingJSON = "json"
+	// EncodingProtobuf is the binary encoding of the messages in
+	// proto/bitping.proto: pb.Block, pb.Transaction or pb.Action depending
+	// on the granularity
+	EncodingProtobuf = "protobuf"
+)
+
+// Content types of the encodings, set in the content_type attribute
+var contentTypes = map[string]string{
+	EncodingJSON:     "application/json",
+	EncodingProtobuf: "application/x-protobuf",
+}
+
+// Message attributes. Brokers call them attributes or headers
+const (
+	AttrNetwork     = "network"
+	AttrHeight      = "height"
+	AttrBlockHash   = "block_hash"
+	AttrKind        = "kind"
+	AttrTransaction = "transaction"
+	AttrIndex       = "index"
+	AttrContentType = "content_type"
+	// AttrStatus is the block status, only set when blocks are gated on
+	// confirmations
+	AttrStatus = "status"
+)
+
+// Message is what a QueueSink hands to its Publisher
+type Message struct {
+	// Key is the ordering key. Brokers keep messages with the same key in
+	// the order they were published, so it's the network
+	Key        string
+	Attributes map[string]string
+	Data       []byte
+}
+
+// ID identifies a message across retries so brokers that deduplicate can
+// drop a message that was published twice. The pending and confirmed
+// copies of a block get different ids
+func (m Message) ID() string {
+	a := m.Attributes
+	id := a[AttrNetwork] + "/" + a[AttrBlockHash] + "/" + a[AttrKind] + "/" + a[AttrIndex]
+	if status := a[AttrStatus]; status != "" {
+		id += "/" + status
+	}
+	return id
+}
+
+// Publisher sends messages to a broker
+type Publisher interface {
+	// Configure the publisher from the sink options
+	Configure(opts config.Options) error
+
+	// Publish sends msgs in order and returns once the broker acknowledged
+	// every one of them
+	Publish(msgs []Message) error
+
+	Close() error
+}
+
+// MaxBacklog is how many undelivered blocks of a network a QueueSink keeps
+// to resend. Blocks past it are only resent once the watchers resume from
+// the checkpoint on the next start
+const MaxBacklog = 1024
+
+// QueueSink publishes blocks, or their transactions or actions, to a message
+// queue. It only advances its checkpoint once the broker acknowledged every
+// message of a block. A block that can't be delivered is resent, in order,
+// before the next block of its network, and its height is saved in the
+// checkpoint so the checkpoint never gets ahead of what was delivered, even
+// across restarts
+type QueueSink struct {
+	name string
+
+	// Granularity is one of PerBlock, PerTransaction or PerAction
+	Granularity string
+	// Encoding is EncodingJSON or EncodingProtobuf
+	Encoding string
+	// Retries is how many times publishing a block is retried, with a
+	// backoff, before giving up on it
+	Retries int
+
+	publisher  Publisher
+	checkpoint *Checkpoint
+	// backlog holds the undelivered blocks of each network, lowest first,
+	// and lost the lowest height that didn't fit. Publish is only called
+	// from one goroutine
+	backlog map[string][]types.Block
+	lost    map[string]int64
+}
+
+// NewQueueSink creates a sink publishing with publisher
+func NewQueueSink(name string, publisher Publisher) *QueueSink {
+	return &QueueSink{
+		name:        name,
+		Granularity: PerBlock,
+		Encoding:    EncodingJSON,
+		Retries:     5,
+		publisher:   publisher,
+		backlog:     make(map[string][]types.Block),
+		lost:        make(map[string]int64),
+	}
+}
+
+// Name returns the name of the sink
+func (s *QueueSink) Name() string {
+	return s.name
+}
+
+// ConfigureFromOptions reads the granularity, encoding, retries and
+// checkpoint options
+// and configures the publisher with the rest
+func (s *QueueSink) ConfigureFromOptions(opts config.Options, c *cli.Context) error {
+	s.Granularity = opts.String("granularity", PerBlock)
+	switch s.Granularity {
+	case PerBlock, PerTransaction, PerAction:
+	default:
+		return fmt.Errorf("granularity must be block, transaction or action")
+	}
+	s.Encoding = opts.String("encoding", EncodingJSON)
+	if _, ok := contentTypes[s.Encoding]; !ok {
+		return fmt.Errorf("encoding must be json or protobuf")
+	}
+	s.Retries = int(opts.Int64("retries", int64(s.Retries)))
+
+	var err error
+	if s.checkpoint, err = OpenCheckpoint(opts.String("checkpoint", "")); err != nil {
+		return err
+	}
+	for network, pos := range s.checkpoint.Positions() {
+		log.Printf("Sink %s: %s acknowledged up to block %d", s.name, network, pos.Number)
+		if pos.Undelivered > 0 {
+			log.Printf("Sink %s: %s block %d is undelivered", s.name, network, pos.Undelivered)
+		}
+	}
+
+	return s.publisher.Configure(opts)
+}
+
+// Publish publishes block and advances the checkpoint once the broker
+// acknowledged it. The undelivered blocks of the network are resent first,
+// and block joins them when they still fail
+func (s *QueueSink) Publish(block types.Block) error {
+	if err := s.resend(block.Network); err != nil {
+		return s.hold(block, err)
+	}
+
+	msgs, err := Messages(block, s.Granularity, s.Encoding)
+	if err != nil {
+		return err
+	}
+	if len(msgs) > 0 {
+		b := &backoff.Backoff{Max: time.Minute}
+		for {
+			if err = s.publisher.Publish(msgs); err == nil {
+				break
+			}
+			if int(b.Attempt()) >= s.Retries {
+				return s.hold(block, err)
+			}
+			d := b.Duration()
+			log.Printf("Sink %s: %v, retrying in %s", s.name, err, d)
+			time.Sleep(d)
+		}
+	}
+	return s.advance(block)
+}
+
+// resend publishes the backlog of network once, lowest first, stopping at
+// the first block that still fails
+func (s *QueueSink) resend(network string) error {
+	for len(s.backlog[network]) > 0 {
+		block := s.backlog[network][0]
+		msgs, err := Messages(block, s.Granularity, s.Encoding)
+		if err != nil {
+			return err
+		}
+		if len(msgs) > 0 {
+			if err := s.publisher.Publish(msgs); err != nil {
+				return err
+			}
+		}
+		log.Printf("Sink %s: resent block %d on %s", s.name, block.Number, network)
+
+		s.backlog[network] = s.backlog[network][1:]
+		if err := s.advance(block); err != nil {
+			return err
+		}
+	}
+	delete(s.backlog, network)
+	return nil
+}
+
+// hold adds block to the backlog of its network and saves the lowest
+// undelivered height in the checkpoint
+func (s *QueueSink) hold(block types.Block, err error) error {
+	network := block.Network
+	if len(s.backlog[network]) < MaxBacklog {
+		s.backlog[network] = append(s.backlog[network], block)
+	} else if h, ok := s.lost[network]; !ok || block.Number < h {
+		s.lost[network] = block.Number
+	}
+
+	if cpErr := s.checkpoint.Hold(network, block.Number); cpErr != nil {
+		log.Printf("Sink %s: checkpoint: %v", s.name, cpErr)
+	}
+	return fmt.Errorf("sink %s: block %d on %s: %v", s.name, block.Number, network, err)
+}
+
+// advance moves the checkpoint to the delivered block, then holds it at the
+// next undelivered height of the network
+func (s *QueueSink) advance(block types.Block) error {
+	network := block.Network
+	if s.lost[network] == block.Number {
+		delete(s.lost, network)
+	}
+	if err := s.checkpoint.Advance(network, block.Number, block.Hash); err != nil {
+		return err
+	}
+
+	next, ok := s.lost[network]
+	if backlog := s.backlog[network]; len(backlog) > 0 && (!ok || backlog[0].Number < next) {
+		next, ok = backlog[0].Number, true
+	}
+	if !ok {
+		return nil
+	}
+	return s.checkpoint.Hold(network, next)
+}
+
+// Checkpoint returns the last acknowledged block of each network
+func (s *QueueSink) Checkpoint() map[string]Position {
+	return s.checkpoint.Positions()
+}
+
+// Resume returns the height each network in the checkpoint needs blocks
+// from: the lowest undelivered one, or the one after the last acknowledged
+func (s *QueueSink) Resume() map[string]int64 {
+	out := make(map[string]int64)
+	for network, pos := range s.checkpoint.Positions() {
+		out[network] = pos.Next()
+	}
+	return out
+}
+
+// Close closes the publisher
+func (s *QueueSink) Close() error {
+	return s.publisher.Close()
+}
+
+// Messages splits block into messages of the given granularity and
+// encoding. Every message is keyed by the network and carries the network,
+// height, block hash and content type attributes, and the status when the
+// block has one
+func Messages(block types.Block, granularity, encoding string) ([]Message, error) {
+	contentType, ok := contentTypes[encoding]
+	if !ok {
+		return nil, fmt.Errorf("unknown encoding %q", encoding)
+	}
+
+	attrs := func(kind string, index int) map[string]string {
+		a := map[string]string{
+			AttrNetwork:     block.Network,
+			AttrHeight:      strconv.FormatInt(block.Number, 10),
+			AttrBlockHash:   block.Hash,
+			AttrKind:        kind,
+			AttrIndex:       strconv.Itoa(index),
+			AttrContentType: contentType,
+		}
+		if block.Status != "" {
+			a[AttrStatus] = block.Status
+		}
+		return a
+	}
+
+	var msgs []Message
+	add := func(v interface{}, a map[string]string) error {
+		data, err := encode(v, encoding)
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, Message{Key: block.Network, Attributes: a, Data: data})
+		return nil
+	}
+
+	switch granularity {
+	case PerBlock:
+		if err := add(block, attrs(PerBlock, 0)); err != nil {
+			return nil, err
+		}
+
+	case PerTransaction:
+		for i, tx := range block.Transactions {
+			a := attrs(PerTransaction, i)
+			a[AttrTransaction] = tx.Hash
+			if err := add(tx, a); err != nil {
+				return nil, err
+			}
+		}
+
+	case PerAction:
+		index := 0
+		for _, tx := range block.Transactions {
+			for _, action := range tx.Actions {
+				a := attrs(PerAction, index)
+				a[AttrTransaction] = tx.Hash
+				if err := add(action, a); err != nil {
+					return nil, err
+				}
+				index++
+			}
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown granularity %q", granularity)
+	}
+
+	return msgs, nil
+}
+
+// encode encodes a block, transaction or action
+func encode(v interface{}, encoding string) ([]byte, error) {
+	if encoding != EncodingProtobuf {
+		switch v := v.(type) {
+		case types.Transaction:
+			return json.Marshal(v.Versioned())
+		case types.Action:
+			return json.Marshal(v.Versioned())
+		}
+		return json.Marshal(v)
+	}
+
+	switch v := v.(type) {
+	case types.Block:
+		return proto.Marshal(pb.FromBlock(v))
+	case types.Transaction:
+		t := pb.FromTransaction(v)
+		t.SchemaVersion = types.SchemaVersion
+		return proto.Marshal(t)
+	case types.Action:
+		a := pb.FromAction(v)
+		a.SchemaVersion = types.SchemaVersion
+		return proto.Marshal(a)
+	}
+	return nil, fmt.Errorf("can't encode %T as protobuf", v)
+}
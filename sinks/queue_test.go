@@ -0,0 +1,391 @@
+package sinks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/auser/bitping/config"
+	"github.com/auser/bitping/types"
+)
+
+// testBlock returns an ethereum block at height number with a transfer and
+// a token transfer carrying input data and a log
+func testBlock(network string, number int64) types.Block {
+	hash := fmt.Sprintf("0x%064x", number)
+	wei, _ := new(big.Int).SetString("1500000000000000000", 10)
+	input := make([]byte, 68)
+	copy(input, []byte{0xa9, 0x05, 0x9c, 0xbb})
+	input[35], input[67] = 0x42, 0x10
+
+	block := types.Block{
+		EthereumBlock: &types.EthereumBlock{
+			Sha3Uncles:       "0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347",
+			LogsBloom:        "0x" + fmt.Sprintf("%0512x", 1),
+			TransactionsRoot: "0x3a1b03875115b79539e5bd33fb00d8f7b7cd61929d5a3c574f507b8acf415bee",
+			StateRoot:        "0xf1133199d44695dfa8fd1bcfe424d82854b5cebef75bddd7e40ea94cda515bcb",
+			Miner:            "0x8888f1f195afa192cfee860698584c030f4c9db1",
+			TotalDifficulty:  types.BigIntFromInt(58750003716598352),
+			GasLimit:         30000000,
+			GasUsed:          86000,
+			Uncles:           []string{},
+		},
+		Hash:       hash,
+		HeaderHash: hash,
+		Network:    network,
+		NetworkID:  1,
+		Number:     number,
+		Size:       1024,
+		Time:       1600000000 + number*13,
+		Nonce:      "0xfb6e1a62d119228b",
+		Difficulty: types.BigIntFromInt(2000000000),
+		ParentHash: fmt.Sprintf("0x%064x", number-1),
+	}
+
+	for i := 0; i < 2; i++ {
+		txHash := fmt.Sprintf("0x%062x%02x", number, i)
+		tx := types.Transaction{
+			EthereumTransaction: &types.EthereumTransaction{
+				TransactionIndex: int64(i),
+				GasPrice:         types.BigIntFromInt(20000000000),
+				Gas:              60000,
+			},
+			BlockHash:       hash,
+			BlockNumber:     number,
+			TransactionHash: txHash,
+			Hash:            txHash,
+			Nonce:           int64(i),
+		}
+		action := types.Action{
+			EthereumCall:    &types.EthereumCall{Input: types.Bytes{}},
+			BlockHash:       hash,
+			BlockNumber:     number,
+			TransactionHash: txHash,
+			From:            "0xa94f5374fce5edbc8e2a8697c15331677e6ebf0b",
+			To:              "0x6295ee1b4f6dd65047762f924ecd367c17eabf8f",
+			Value:           types.NewBigInt(wei),
+			Symbol:          "ETH",
+			Precision:       18,
+			Data:            types.Bytes{},
+		}
+		if i == 1 {
+			action.EthereumCall.Input = types.Bytes(input)
+			action.Data = types.Bytes(input)
+			action.Value = types.BigIntFromInt(0)
+			tx.Events = append(tx.Events, types.Event{EthereumEvent: &types.EthereumEvent{
+				TransactionIndex: uint64(i),
+				Address:          "0xdac17f958d2ee523a2206206994597c13d831ec7",
+				Data:             types.Bytes(input[36:]),
+				Topics: []string{
+					"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+					"0x000000000000000000000000a94f5374fce5edbc8e2a8697c15331677e6ebf0b",
+					"0x0000000000000000000000006295ee1b4f6dd65047762f924ecd367c17eabf8f",
+				},
+			}})
+		}
+		tx.Actions = append(tx.Actions, action)
+		block.Transactions = append(block.Transactions, tx)
+	}
+	return block
+}
+
+func TestMessages(t *testing.T) {
+	block := testBlock("ethereum", 100)
+
+	tests := []struct {
+		granularity string
+		count       int
+	}{
+		{PerBlock, 1},
+		{PerTransaction, 2},
+		{PerAction, 2},
+	}
+	for _, tt := range tests {
+		for _, encoding := range []string{EncodingJSON, EncodingProtobuf} {
+			msgs, err := Messages(block, tt.granularity, encoding)
+			if err != nil {
+				t.Fatalf("%s/%s: %v", tt.granularity, encoding, err)
+			}
+			if len(msgs) != tt.count {
+				t.Fatalf("%s/%s: got %d messages, want %d", tt.granularity, encoding, len(msgs), tt.count)
+			}
+			for i, m := range msgs {
+				if m.Key != "ethereum" {
+					t.Errorf("%s/%s: message %d keyed %q, want the network", tt.granularity, encoding, i, m.Key)
+				}
+				want := map[string]string{
+					AttrNetwork:     "ethereum",
+					AttrHeight:      "100",
+					AttrBlockHash:   block.Hash,
+					AttrKind:        tt.granularity,
+					AttrIndex:       strconv.Itoa(i),
+					AttrContentType: contentTypes[encoding],
+				}
+				if tt.granularity != PerBlock {
+					want[AttrTransaction] = block.Transactions[i].Hash
+				}
+				for k, v := range want {
+					if m.Attributes[k] != v {
+						t.Errorf("%s/%s: message %d %s = %q, want %q", tt.granularity, encoding, i, k, m.Attributes[k], v)
+					}
+				}
+			}
+		}
+	}
+
+	// The pending and confirmed copies of a block aren't duplicates
+	ids := make(map[string]bool)
+	for _, status := range []string{"", types.BlockStatusPending, types.BlockStatusConfirmed} {
+		block.Status = status
+		msgs, err := Messages(block, PerBlock, EncodingJSON)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if msgs[0].Attributes[AttrStatus] != status {
+			t.Errorf("%q block: status attribute %q", status, msgs[0].Attributes[AttrStatus])
+		}
+		if ids[msgs[0].ID()] {
+			t.Errorf("%q block: id %s already used", status, msgs[0].ID())
+		}
+		ids[msgs[0].ID()] = true
+	}
+	block.Status = ""
+
+	if _, err := Messages(block, "event", EncodingJSON); err == nil {
+		t.Error("unknown granularity: expected an error")
+	}
+}
+
+// fakePublisher acknowledges everything but the heights in fail
+type fakePublisher struct {
+	fail      map[string]bool
+	published []Message
+}
+
+func (p *fakePublisher) Configure(opts config.Options) error { return nil }
+func (p *fakePublisher) Close() error                        { return nil }
+
+func (p *fakePublisher) Publish(msgs []Message) error {
+	for _, m := range msgs {
+		if p.fail[m.Attributes[AttrHeight]] {
+			return errors.New("broker unavailable")
+		}
+	}
+	p.published = append(p.published, msgs...)
+	return nil
+}
+
+// newTestQueueSink returns a sink publishing with p without retries
+func newTestQueueSink(t *testing.T, p Publisher, opts config.Options) *QueueSink {
+	s := NewQueueSink("test", p)
+	if opts == nil {
+		opts = config.Options{}
+	}
+	opts["retries"] = 0
+	if err := s.ConfigureFromOptions(opts, nil); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// heights returns the heights of the published messages of network
+func (p *fakePublisher) heights(network string) []string {
+	var out []string
+	for _, m := range p.published {
+		if m.Attributes[AttrNetwork] == network {
+			out = append(out, m.Attributes[AttrHeight])
+		}
+	}
+	return out
+}
+
+func TestQueueSinkCheckpoint(t *testing.T) {
+	p := &fakePublisher{fail: map[string]bool{"3": true}}
+	s := newTestQueueSink(t, p, nil)
+
+	checkpoint := func(network string) Position {
+		return s.Checkpoint()[network]
+	}
+
+	for n := int64(1); n <= 2; n++ {
+		if err := s.Publish(testBlock("ethereum", n)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := checkpoint("ethereum"); got.Number != 2 || got.Undelivered != 0 {
+		t.Fatalf("checkpoint after acked blocks = %+v, want 2", got)
+	}
+
+	if err := s.Publish(testBlock("ethereum", 3)); err == nil {
+		t.Fatal("expected block 3 to fail")
+	}
+	if got := checkpoint("ethereum"); got.Number != 2 || got.Undelivered != 3 {
+		t.Fatalf("checkpoint after a failed block = %+v, want 2 with 3 undelivered", got)
+	}
+
+	// Block 3 is resent first and still fails, so block 4 waits behind it
+	if err := s.Publish(testBlock("ethereum", 4)); err == nil {
+		t.Fatal("expected block 4 to wait for block 3")
+	}
+	if got := checkpoint("ethereum"); got.Number != 2 || got.Undelivered != 3 {
+		t.Fatalf("checkpoint after a block past the gap = %+v, want 2 with 3 undelivered", got)
+	}
+
+	// Other networks aren't held up
+	if err := s.Publish(testBlock("classic", 9)); err != nil {
+		t.Fatal(err)
+	}
+	if got := checkpoint("classic"); got.Number != 9 {
+		t.Fatalf("classic checkpoint = %+v, want 9", got)
+	}
+
+	// Once the broker is back, blocks 3 and 4 are resent in order before 5
+	delete(p.fail, "3")
+	if err := s.Publish(testBlock("ethereum", 5)); err != nil {
+		t.Fatal(err)
+	}
+	if got := checkpoint("ethereum"); got.Number != 5 || got.Undelivered != 0 {
+		t.Fatalf("checkpoint after resending = %+v, want 5", got)
+	}
+	if got := strings.Join(p.heights("ethereum"), " "); got != "1 2 3 4 5" {
+		t.Fatalf("published heights %s, want 1 2 3 4 5", got)
+	}
+}
+
+func TestQueueSinkRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	opts := func() config.Options {
+		return config.Options{"checkpoint": path}
+	}
+
+	p := &fakePublisher{fail: map[string]bool{"3": true}}
+	s := newTestQueueSink(t, p, opts())
+	for n := int64(1); n <= 4; n++ {
+		s.Publish(testBlock("ethereum", n))
+	}
+	s.Close()
+
+	// The undelivered height survives the restart, and the watchers are
+	// resumed from it
+	p = &fakePublisher{}
+	s = newTestQueueSink(t, p, opts())
+	defer s.Close()
+	if got := s.Resume()["ethereum"]; got != 3 {
+		t.Fatalf("resume from %d after a restart, want 3", got)
+	}
+	if got := s.Checkpoint()["ethereum"]; got.Number != 2 || got.Undelivered != 3 {
+		t.Fatalf("checkpoint after a restart = %+v, want 2 with 3 undelivered", got)
+	}
+
+	for n := int64(3); n <= 5; n++ {
+		if err := s.Publish(testBlock("ethereum", n)); err != nil {
+			t.Fatal(err)
+		}
+		if got := s.Checkpoint()["ethereum"]; got.Number != n || got.Undelivered != 0 {
+			t.Fatalf("checkpoint after replaying block %d = %+v", n, got)
+		}
+	}
+	if got := s.Resume()["ethereum"]; got != 6 {
+		t.Fatalf("resume from %d once caught up, want 6", got)
+	}
+}
+
+func TestQueueSinkEncodings(t *testing.T) {
+	p := &fakePublisher{}
+	s := newTestQueueSink(t, p, config.Options{"granularity": PerAction})
+	if err := s.Publish(testBlock("ethereum", 7)); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.published) != 2 {
+		t.Fatalf("published %d messages, want 2", len(p.published))
+	}
+	var action types.Action
+	if err := json.Unmarshal(p.published[1].Data, &action); err != nil {
+		t.Fatal(err)
+	}
+	if action.TransactionHash != testBlock("ethereum", 7).Transactions[1].Hash {
+		t.Errorf("second message is the action of %s", action.TransactionHash)
+	}
+
+	s = NewQueueSink("test", &fakePublisher{})
+	if err := s.ConfigureFromOptions(config.Options{"encoding": "avro"}, nil); err == nil {
+		t.Error("unknown encoding: expected an error")
+	}
+}
+
+// benchBlock returns a mainnet sized block: 200 contract calls with 260
+// bytes of input and 3 logs each
+func benchBlock() types.Block {
+	block := testBlock("ethereum", 12000000)
+	call := block.Transactions[1]
+	input := make([]byte, 260)
+	for i := range input {
+		input[i] = byte(i * 7)
+	}
+
+	block.Transactions = make([]types.Transaction, 200)
+	for i := range block.Transactions {
+		tx := call
+		tx.Hash = fmt.Sprintf("0x%064x", 1000+i)
+		tx.TransactionHash = tx.Hash
+		tx.EthereumTransaction = &types.EthereumTransaction{TransactionIndex: int64(i), GasPrice: call.GasPrice, Gas: call.Gas}
+
+		action := call.Actions[0]
+		action.TransactionHash = tx.Hash
+		action.EthereumCall = &types.EthereumCall{Input: types.Bytes(input)}
+		action.Data = types.Bytes(input)
+		tx.Actions = []types.Action{action}
+
+		tx.Events = nil
+		for j := 0; j < 3; j++ {
+			log := *call.Events[0].EthereumEvent
+			log.LogIndex = uint64(3*i + j)
+			log.TransactionIndex = uint64(i)
+			tx.Events = append(tx.Events, types.Event{EthereumEvent: &log})
+		}
+		block.Transactions[i] = tx
+	}
+	return block
+}
+
+func BenchmarkEncode(b *testing.B) {
+	block := benchBlock()
+	for _, encoding := range []string{EncodingJSON, EncodingProtobuf} {
+		b.Run(encoding, func(b *testing.B) {
+			var size int
+			for i := 0; i < b.N; i++ {
+				data, err := encode(block, encoding)
+				if err != nil {
+					b.Fatal(err)
+				}
+				size = len(data)
+			}
+			b.ReportMetric(float64(size), "bytes/block")
+		})
+	}
+}
+
+func BenchmarkMessages(b *testing.B) {
+	block := benchBlock()
+	for _, encoding := range []string{EncodingJSON, EncodingProtobuf} {
+		b.Run(encoding, func(b *testing.B) {
+			var size int
+			for i := 0; i < b.N; i++ {
+				msgs, err := Messages(block, PerAction, encoding)
+				if err != nil {
+					b.Fatal(err)
+				}
+				size = 0
+				for _, m := range msgs {
+					size += len(m.Data)
+				}
+			}
+			b.ReportMetric(float64(size), "bytes/block")
+		})
+	}
+}
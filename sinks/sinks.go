@@ -0,0 +1,63 @@
+package sinks
+
+import (
+	"fmt"
+
+	"github.com/auser/bitping/config"
+	"github.com/auser/bitping/iface"
+)
+
+// Types lists the sink types New knows how to create
+var Types = []string{"pubsub", "kafka", "nats", "sql"}
+
+// New returns an unconfigured sink of the given type
+func New(kind, name string) (iface.Sink, error) {
+	switch kind {
+	case "pubsub":
+		return NewQueueSink(name, &PubSubPublisher{}), nil
+	case "kafka":
+		return NewQueueSink(name, &KafkaPublisher{}), nil
+	case "nats":
+		return NewQueueSink(name, &NATSPublisher{}), nil
+	case "sql", "postgres":
+		return NewSQLSink(name), nil
+	}
+	return nil, fmt.Errorf("unknown sink type: %s", kind)
+}
+
+// FromConfig creates and configures the sink declared in a config file
+// section
+func FromConfig(sc config.SinkConfig) (iface.Sink, error) {
+	name := sc.Name
+	if name == "" {
+		name = sc.Type
+	}
+
+	s, err := New(sc.Type, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ConfigureFromOptions(sc.Options, nil); err != nil {
+		return nil, fmt.Errorf("sink %s: %v", name, err)
+	}
+
+	return s, nil
+}
+
+// Sinks returns the sinks declared in the config file. Sinks that were
+// already created are closed when one fails
+func Sinks(cfg *config.Config) ([]iface.Sink, error) {
+	var out []iface.Sink
+	for _, sc := range cfg.Sinks {
+		s, err := FromConfig(sc)
+		if err != nil {
+			for _, s := range out {
+				s.Close()
+			}
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
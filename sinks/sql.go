@@ -0,0 +1,446 @@
+package sinks
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/auser/bitping/config"
+	"github.com/auser/bitping/types"
+	"github.com/codegangsta/cli"
+	_ "github.com/lib/pq"
+)
+
+// SQLSink writes blocks, transactions, actions, events and the inputs and
+// outputs of UTXO transactions to the tables
+// created by sqlMigrations. Options:
+//
+//	dsn      connection string, required, i.e.
+//	         postgres://bitping@localhost/bitping?sslmode=disable
+//	driver   database/sql driver, postgres by default
+//	migrate  apply pending migrations when the sink starts, true by default
+//
+// Each block is written in a single database transaction. Writing a block
+// that's already stored does nothing, and a block replacing another at its
+// height rolls back everything stored from that height up first, which is
+// what a reorg looks like
+type SQLSink struct {
+	name string
+	db   *sql.DB
+}
+
+// NewSQLSink creates an unconfigured SQL sink
+func NewSQLSink(name string) *SQLSink {
+	return &SQLSink{name: name}
+}
+
+// Name returns the name of the sink
+func (s *SQLSink) Name() string {
+	return s.name
+}
+
+// ConfigureFromOptions connects to the database and migrates it
+func (s *SQLSink) ConfigureFromOptions(opts config.Options, c *cli.Context) error {
+	dsn := opts.String("dsn", "")
+	if dsn == "" {
+		return errors.New("dsn is required")
+	}
+
+	var err error
+	if s.db, err = sql.Open(opts.String("driver", "postgres"), dsn); err != nil {
+		return err
+	}
+	if err := s.db.Ping(); err != nil {
+		s.db.Close()
+		return err
+	}
+
+	if opts.Bool("migrate", true) {
+		if err := s.Migrate(); err != nil {
+			s.db.Close()
+			return err
+		}
+	}
+	return nil
+}
+
+// Migrate applies the migrations that haven't been yet
+func (s *SQLSink) Migrate() error {
+	if _, err := s.db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER PRIMARY KEY)`); err != nil {
+		return err
+	}
+
+	var current int
+	if err := s.db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&current); err != nil {
+		return err
+	}
+
+	for i := current; i < len(sqlMigrations); i++ {
+		version := i + 1
+		log.Printf("Sink %s: migrating to schema version %d", s.name, version)
+
+		tx, err := s.db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(sqlMigrations[i]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d: %v", version, err)
+		}
+		if _, err := tx.Exec(`INSERT INTO schema_migrations (version) VALUES ($1)`, version); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Publish writes block in a single transaction
+func (s *SQLSink) Publish(block types.Block) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if err := writeBlock(tx, block); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("sink %s: block %d on %s: %v", s.name, block.Number, block.Network, err)
+	}
+	return tx.Commit()
+}
+
+// Close closes the database
+func (s *SQLSink) Close() error {
+	if s.db == nil {
+		return nil
+	}
+	return s.db.Close()
+}
+
+func writeBlock(tx *sql.Tx, block types.Block) error {
+	var stored string
+	err := tx.QueryRow(`SELECT hash FROM blocks WHERE network = $1 AND number = $2`,
+		block.Network, block.Number).Scan(&stored)
+	switch {
+	case err == nil && stored == block.Hash:
+		// Replayed, or the confirmed copy of a block first written while
+		// pending. Only the status changes
+		_, err := tx.Exec(`UPDATE blocks SET status = $3 WHERE network = $1 AND number = $2`,
+			block.Network, block.Number, block.Status)
+		return err
+	case err != nil && err != sql.ErrNoRows:
+		return err
+	}
+
+	// Whatever is stored from this height up belongs to a chain that was
+	// reorged away
+	for _, table := range []string{"events", "actions", "inputs", "outputs", "transactions"} {
+		if _, err := tx.Exec(`DELETE FROM `+table+` WHERE network = $1 AND block_number >= $2`,
+			block.Network, block.Number); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec(`DELETE FROM blocks WHERE network = $1 AND number >= $2`,
+		block.Network, block.Number); err != nil {
+		return err
+	}
+
+	if err := insertBlock(tx, block); err != nil {
+		return err
+	}
+	for i, t := range block.Transactions {
+		if err := insertTransaction(tx, block, i, t); err != nil {
+			return err
+		}
+		for j, action := range t.Actions {
+			if err := insertAction(tx, block, i, j, t, action); err != nil {
+				return err
+			}
+		}
+		for j, event := range t.Events {
+			if err := insertEvent(tx, block, i, j, t, event); err != nil {
+				return err
+			}
+		}
+		for j, input := range t.Inputs {
+			if err := insertInput(tx, block, i, j, t, input); err != nil {
+				return err
+			}
+		}
+		for _, output := range t.Outputs {
+			if err := insertOutput(tx, block, i, t, output); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// insert inserts a row of columns and values into table
+func insert(tx *sql.Tx, table string, columns []string, values []interface{}) error {
+	params := make([]string, len(columns))
+	for i := range params {
+		params[i] = fmt.Sprintf("$%d", i+1)
+	}
+	_, err := tx.Exec(fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s)`,
+		table, strings.Join(columns, ", "), strings.Join(params, ", ")), values...)
+	return err
+}
+
+// row collects the columns and values of an insert
+type row struct {
+	columns []string
+	values  []interface{}
+}
+
+func (r *row) set(column string, value interface{}) {
+	r.columns = append(r.columns, column)
+	r.values = append(r.values, value)
+}
+
+func insertBlock(tx *sql.Tx, block types.Block) error {
+	r := &row{}
+	r.set("network", block.Network)
+	r.set("number", block.Number)
+	r.set("hash", block.Hash)
+	r.set("chain", blockChain(block))
+	r.set("header_hash", block.HeaderHash)
+	r.set("parent_hash", block.ParentHash)
+	r.set("network_id", block.NetworkID)
+	r.set("time", block.Time)
+	r.set("size", block.Size)
+	r.set("status", block.Status)
+	r.set("nonce", block.Nonce)
+	r.set("difficulty", sqlBigInt(block.Difficulty))
+
+	if b := block.EthereumBlock; b != nil {
+		r.set("eth_sha3_uncles", b.Sha3Uncles)
+		r.set("eth_logs_bloom", b.LogsBloom)
+		r.set("eth_transactions_root", b.TransactionsRoot)
+		r.set("eth_state_root", b.StateRoot)
+		r.set("eth_miner", b.Miner)
+		r.set("eth_coinbase", b.Coinbase)
+		r.set("eth_total_difficulty", sqlBigInt(b.TotalDifficulty))
+		r.set("eth_extra_data", b.ExtraData)
+		r.set("eth_gas_limit", int64(b.GasLimit))
+		r.set("eth_gas_used", int64(b.GasUsed))
+	}
+	if b := block.EOSBlock; b != nil {
+		r.set("eos_producer", b.Producer)
+		r.set("eos_confirmed", int64(b.Confirmed))
+		r.set("eos_transaction_mroot", b.TransactionMerkleRoot)
+		r.set("eos_action_mroot", b.ActionMerkleRoot)
+		r.set("eos_schedule_version", int64(b.ScheduleVersion))
+		r.set("eos_producer_signature", b.ProducerSignature)
+		r.set("eos_ref_block_prefix", int64(b.RefBlockPrefix))
+		r.set("eos_chain_id", b.ChainID)
+	}
+	if b := block.BitcoinBlock; b != nil {
+		r.set("btc_merkleroot", b.Merkleroot)
+		r.set("btc_bits", b.Bits)
+		r.set("btc_chainwork", b.Chainwork)
+		r.set("btc_stripped_size", int64(b.StrippedSize))
+		r.set("btc_weight", int64(b.Weight))
+		r.set("btc_version", b.Version)
+		r.set("btc_median_time", int64(b.MedianTime))
+	}
+
+	return insert(tx, "blocks", r.columns, r.values)
+}
+
+func insertTransaction(tx *sql.Tx, block types.Block, index int, t types.Transaction) error {
+	r := &row{}
+	r.set("network", block.Network)
+	r.set("block_number", block.Number)
+	r.set("tx_index", index)
+	r.set("hash", t.Hash)
+	r.set("block_hash", block.Hash)
+	r.set("nonce", t.Nonce)
+	r.set("is_derived", t.IsDerived)
+	r.set("derived_index", t.DerivedIndex)
+
+	if et := t.EthereumTransaction; et != nil {
+		r.set("eth_transaction_index", et.TransactionIndex)
+		r.set("eth_gas_price", sqlBigInt(et.GasPrice))
+		r.set("eth_gas", int64(et.Gas))
+	}
+	if receipt := t.EOSTransactionReceipt; receipt != nil {
+		r.set("eos_status", receipt.Status)
+		r.set("eos_cpu_usage_us", int64(receipt.CPUUsageMicroSeconds))
+		r.set("eos_net_usage_words", int64(receipt.NetUsageWords))
+		r.set("eos_trx_id", receipt.TRX.ID)
+		r.set("eos_expiration", receipt.TRX.Transaction.Expiration)
+		r.set("eos_ref_block_num", int64(receipt.TRX.Transaction.RefBlockNum))
+		r.set("eos_ref_block_prefix", int64(receipt.TRX.Transaction.RefBlockPrefix))
+	}
+	if bt := t.BitcoinTransaction; bt != nil {
+		r.set("btc_witness_hash", bt.WitnessHash)
+		r.set("btc_version", int64(bt.Version))
+		r.set("btc_size", int64(bt.Size))
+		r.set("btc_vsize", int64(bt.VSize))
+		r.set("btc_weight", int64(bt.Weight))
+		r.set("btc_lock_time", int64(bt.LockTime))
+		if bt.Fee != nil {
+			r.set("btc_fee", int64(*bt.Fee))
+		}
+	}
+
+	return insert(tx, "transactions", r.columns, r.values)
+}
+
+func insertAction(tx *sql.Tx, block types.Block, txIndex, index int, t types.Transaction, action types.Action) error {
+	r := &row{}
+	r.set("network", block.Network)
+	r.set("block_number", block.Number)
+	r.set("tx_index", txIndex)
+	r.set("action_index", index)
+	r.set("transaction_hash", t.Hash)
+	r.set("address", action.Address)
+	r.set("from_address", action.From)
+	r.set("to_address", action.To)
+	r.set("value", sqlBigInt(action.Value))
+	r.set("symbol", action.Symbol)
+	r.set("precision", int64(action.Precision))
+	r.set("amount", sql.NullString{String: action.Amount, Valid: action.Amount != ""})
+	if f := action.Fiat; f != nil {
+		r.set("fiat_currency", f.Currency)
+		r.set("fiat_price", f.Price)
+		r.set("fiat_value", f.Value)
+	}
+	if l := action.FromLabel; l != nil {
+		r.set("from_entity", l.Entity)
+		r.set("from_category", l.Category)
+	}
+	if l := action.ToLabel; l != nil {
+		r.set("to_entity", l.Entity)
+		r.set("to_category", l.Category)
+	}
+	if a := action.FromAccount; a != nil && a.ENS != "" {
+		r.set("from_ens", a.ENS)
+	}
+	if a := action.ToAccount; a != nil && a.ENS != "" {
+		r.set("to_ens", a.ENS)
+	}
+	if a := action.FromAccount; a != nil && a.EOS != nil {
+		account, err := json.Marshal(a.EOS)
+		if err != nil {
+			return err
+		}
+		r.set("from_eos", string(account))
+	}
+	if a := action.ToAccount; a != nil && a.EOS != nil {
+		account, err := json.Marshal(a.EOS)
+		if err != nil {
+			return err
+		}
+		r.set("to_eos", string(account))
+	}
+	r.set("data", []byte(action.Data))
+
+	if call := action.EthereumCall; call != nil {
+		r.set("eth_input", []byte(call.Input))
+	}
+	if ea := action.EOSAction; ea != nil {
+		auth, err := json.Marshal(ea.Authorization)
+		if err != nil {
+			return err
+		}
+		r.set("eos_account", ea.Account)
+		r.set("eos_name", ea.Name)
+		r.set("eos_authorization", string(auth))
+		r.set("eos_hex_data", ea.HexData)
+		r.set("eos_data", ea.Data)
+	}
+
+	return insert(tx, "actions", r.columns, r.values)
+}
+
+func insertEvent(tx *sql.Tx, block types.Block, txIndex, index int, t types.Transaction, event types.Event) error {
+	r := &row{}
+	r.set("network", block.Network)
+	r.set("block_number", block.Number)
+	r.set("tx_index", txIndex)
+	r.set("event_index", index)
+	r.set("transaction_hash", t.Hash)
+
+	if ee := event.EthereumEvent; ee != nil {
+		topics, err := json.Marshal(ee.Topics)
+		if err != nil {
+			return err
+		}
+		r.set("eth_log_index", int64(ee.LogIndex))
+		r.set("eth_transaction_index", int64(ee.TransactionIndex))
+		r.set("eth_address", ee.Address)
+		r.set("eth_data", []byte(ee.Data))
+		r.set("eth_topics", string(topics))
+		r.set("eth_removed", ee.Removed)
+	}
+
+	return insert(tx, "events", r.columns, r.values)
+}
+
+func insertInput(tx *sql.Tx, block types.Block, txIndex, index int, t types.Transaction, input types.TxInput) error {
+	r := &row{}
+	r.set("network", block.Network)
+	r.set("block_number", block.Number)
+	r.set("tx_index", txIndex)
+	r.set("input_index", index)
+	r.set("transaction_hash", t.Hash)
+	r.set("sequence", int64(input.Sequence))
+	if input.Coinbase != "" {
+		r.set("coinbase", input.Coinbase)
+	} else {
+		r.set("prev_tx_hash", input.PrevTxHash)
+		r.set("prev_index", int64(input.PrevIndex))
+	}
+	if out := input.PrevOut; out != nil {
+		r.set("prev_value", int64(out.Value))
+		r.set("prev_script", out.Script)
+		r.set("prev_script_type", out.ScriptType)
+		r.set("prev_address", out.Address)
+	}
+
+	return insert(tx, "inputs", r.columns, r.values)
+}
+
+func insertOutput(tx *sql.Tx, block types.Block, txIndex int, t types.Transaction, output types.TxOutput) error {
+	r := &row{}
+	r.set("network", block.Network)
+	r.set("block_number", block.Number)
+	r.set("tx_index", txIndex)
+	r.set("output_index", int64(output.Index))
+	r.set("transaction_hash", t.Hash)
+	r.set("value", int64(output.Value))
+	r.set("script", output.Script)
+	r.set("script_type", output.ScriptType)
+	r.set("address", output.Address)
+
+	return insert(tx, "outputs", r.columns, r.values)
+}
+
+// blockChain names the chain the block came from
+func blockChain(block types.Block) string {
+	switch {
+	case block.EthereumBlock != nil:
+		return "ethereum"
+	case block.EOSBlock != nil:
+		return "eos"
+	case block.BitcoinBlock != nil:
+		return "bitcoin"
+	}
+	return ""
+}
+
+// sqlBigInt is the decimal form of i, or null
+func sqlBigInt(i *types.BigInt) sql.NullString {
+	if i == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: i.String(), Valid: true}
+}
@@ -0,0 +1,214 @@
+package sinks
+
+// sqlMigrations are applied in order, each in its own transaction, and
+// recorded in schema_migrations. Never edit one that was released, add
+// another
+var sqlMigrations = []string{
+	// 1: blocks, transactions, actions and events. Chain specific fields get
+	// prefixed columns that are null for the other chains. Transactions,
+	// actions and events are keyed by their position in the block so a
+	// replayed block overwrites nothing
+	`
+CREATE TABLE blocks (
+	network                TEXT NOT NULL,
+	number                 BIGINT NOT NULL,
+	hash                   TEXT NOT NULL,
+	chain                  TEXT NOT NULL,
+	header_hash            TEXT,
+	parent_hash            TEXT,
+	network_id             BIGINT,
+	time                   BIGINT,
+	size                   DOUBLE PRECISION,
+	status                 TEXT,
+	nonce                  TEXT,
+	difficulty             NUMERIC,
+
+	eth_sha3_uncles        TEXT,
+	eth_logs_bloom         TEXT,
+	eth_transactions_root  TEXT,
+	eth_state_root         TEXT,
+	eth_miner              TEXT,
+	eth_coinbase           TEXT,
+	eth_total_difficulty   NUMERIC,
+	eth_extra_data         TEXT,
+	eth_gas_limit          BIGINT,
+	eth_gas_used           BIGINT,
+
+	eos_producer           TEXT,
+	eos_confirmed          BIGINT,
+	eos_transaction_mroot  TEXT,
+	eos_action_mroot       TEXT,
+	eos_schedule_version   BIGINT,
+	eos_producer_signature TEXT,
+	eos_ref_block_prefix   BIGINT,
+	eos_chain_id           TEXT,
+
+	btc_merkleroot         TEXT,
+	btc_bits               TEXT,
+	btc_chainwork          TEXT,
+	btc_stripped_size      BIGINT,
+	btc_weight             BIGINT,
+	btc_version            TEXT,
+	btc_median_time        BIGINT,
+
+	PRIMARY KEY (network, number)
+);
+
+CREATE INDEX blocks_hash ON blocks (network, hash);
+
+CREATE TABLE transactions (
+	network                 TEXT NOT NULL,
+	block_number            BIGINT NOT NULL,
+	tx_index                INTEGER NOT NULL,
+	hash                    TEXT NOT NULL,
+	block_hash              TEXT NOT NULL,
+	nonce                   BIGINT,
+	is_derived              BOOLEAN,
+	derived_index           INTEGER,
+
+	eth_transaction_index   BIGINT,
+	eth_gas_price           NUMERIC,
+	eth_gas                 BIGINT,
+
+	eos_status              TEXT,
+	eos_cpu_usage_us        BIGINT,
+	eos_net_usage_words     BIGINT,
+	eos_trx_id              TEXT,
+	eos_expiration          BIGINT,
+	eos_ref_block_num       BIGINT,
+	eos_ref_block_prefix    BIGINT,
+
+	btc_witness_hash        TEXT,
+	btc_version             BIGINT,
+	btc_size                BIGINT,
+	btc_vsize               BIGINT,
+	btc_weight              BIGINT,
+	btc_lock_time           BIGINT,
+	btc_fee                 BIGINT,
+
+	PRIMARY KEY (network, block_number, tx_index)
+);
+
+CREATE INDEX transactions_hash ON transactions (network, hash);
+
+CREATE TABLE actions (
+	network          TEXT NOT NULL,
+	block_number     BIGINT NOT NULL,
+	tx_index         INTEGER NOT NULL,
+	action_index     INTEGER NOT NULL,
+	transaction_hash TEXT NOT NULL,
+	address          TEXT,
+	from_address     TEXT,
+	to_address       TEXT,
+	value            NUMERIC,
+	symbol           TEXT,
+	precision        BIGINT,
+	data             BYTEA,
+
+	eth_input        BYTEA,
+
+	eos_account      TEXT,
+	eos_name         TEXT,
+	eos_authorization TEXT,
+	eos_hex_data     TEXT,
+	eos_data         TEXT,
+
+	PRIMARY KEY (network, block_number, tx_index, action_index)
+);
+
+CREATE INDEX actions_from ON actions (network, from_address);
+CREATE INDEX actions_to ON actions (network, to_address);
+
+CREATE TABLE events (
+	network               TEXT NOT NULL,
+	block_number          BIGINT NOT NULL,
+	tx_index              INTEGER NOT NULL,
+	event_index           INTEGER NOT NULL,
+	transaction_hash      TEXT NOT NULL,
+
+	eth_log_index         BIGINT,
+	eth_transaction_index BIGINT,
+	eth_address           TEXT,
+	eth_data              BYTEA,
+	eth_topics            TEXT,
+	eth_removed           BOOLEAN,
+
+	PRIMARY KEY (network, block_number, tx_index, event_index)
+);
+
+CREATE INDEX events_address ON events (network, eth_address);
+`,
+
+	// 2: normalized amounts of actions
+	`
+ALTER TABLE actions ADD COLUMN amount NUMERIC;
+`,
+
+	// 3: fiat values of actions
+	`
+ALTER TABLE actions ADD COLUMN fiat_currency TEXT;
+ALTER TABLE actions ADD COLUMN fiat_price NUMERIC;
+ALTER TABLE actions ADD COLUMN fiat_value NUMERIC;
+`,
+
+	// 4: labels of senders and recipients
+	`
+ALTER TABLE actions ADD COLUMN from_entity TEXT;
+ALTER TABLE actions ADD COLUMN from_category TEXT;
+ALTER TABLE actions ADD COLUMN to_entity TEXT;
+ALTER TABLE actions ADD COLUMN to_category TEXT;
+`,
+
+	// 5: ENS names of senders and recipients
+	`
+ALTER TABLE actions ADD COLUMN from_ens TEXT;
+ALTER TABLE actions ADD COLUMN to_ens TEXT;
+`,
+
+	// 6: inputs and outputs of UTXO transactions. The prev_ columns hold the
+	// output an input spends, when it could be looked up
+	`
+CREATE TABLE inputs (
+	network          TEXT NOT NULL,
+	block_number     BIGINT NOT NULL,
+	tx_index         INTEGER NOT NULL,
+	input_index      INTEGER NOT NULL,
+	transaction_hash TEXT NOT NULL,
+	prev_tx_hash     TEXT,
+	prev_index       BIGINT,
+	sequence         BIGINT,
+	coinbase         TEXT,
+	prev_value       BIGINT,
+	prev_script      TEXT,
+	prev_script_type TEXT,
+	prev_address     TEXT,
+
+	PRIMARY KEY (network, block_number, tx_index, input_index)
+);
+
+CREATE INDEX inputs_spent ON inputs (network, prev_tx_hash, prev_index);
+CREATE INDEX inputs_address ON inputs (network, prev_address);
+
+CREATE TABLE outputs (
+	network          TEXT NOT NULL,
+	block_number     BIGINT NOT NULL,
+	tx_index         INTEGER NOT NULL,
+	output_index     BIGINT NOT NULL,
+	transaction_hash TEXT NOT NULL,
+	value            BIGINT,
+	script           TEXT,
+	script_type      TEXT,
+	address          TEXT,
+
+	PRIMARY KEY (network, block_number, tx_index, output_index)
+);
+
+CREATE INDEX outputs_address ON outputs (network, address);
+`,
+
+	// 7: EOS account metadata of senders and recipients, as json
+	`
+ALTER TABLE actions ADD COLUMN from_eos TEXT;
+ALTER TABLE actions ADD COLUMN to_eos TEXT;
+`,
+}
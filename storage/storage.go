@@ -0,0 +1,497 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/auser/bitping/config"
+	"github.com/auser/bitping/types"
+	"github.com/codegangsta/cli"
+	"github.com/tidwall/buntdb"
+)
+
+// ErrNotFound is returned when a block or transaction isn't stored
+var ErrNotFound = errors.New("storage: not found")
+
+// DefaultLimit is the page size used when a query doesn't set one
+const DefaultLimit = 100
+
+// Keys are laid out so each network's records sort by height:
+//
+//	b:<network>:<height>                  block without its transactions
+//	x:<network>:<height>:<index>          transaction
+//	h:<network>:<block hash>              height
+//	t:<network>:<tx hash>                 transaction key
+//	a:<network>:<address>:<height>:<index> empty, marks a transaction
+//	                                      touching the address
+const (
+	heightFormat = "%020d"
+	indexFormat  = "%06d"
+)
+
+// Options store the Store options
+type Options struct {
+	// Path is the buntdb file, or :memory:
+	Path string
+	// MaxBlocks is how many of the most recent heights are kept per network.
+	// Zero keeps everything
+	MaxBlocks int64
+	// MaxAge drops blocks whose timestamp is older than this. Zero keeps
+	// everything
+	MaxAge time.Duration
+}
+
+// storeFlags maps the cli flags to the config file options they override
+var storeFlags = map[string]string{
+	"store":            "path",
+	"store-max-blocks": "max_blocks",
+	"store-max-age":    "max_age",
+}
+
+// Store indexes delivered blocks by network, height, hash, transaction hash
+// and address in an embedded buntdb database
+type Store struct {
+	db      *buntdb.DB
+	Options Options
+}
+
+// Query selects a page of results between two heights
+type Query struct {
+	// From and To are the inclusive heights to look at. To is unbounded
+	// when it's zero
+	From int64
+	To   int64
+	// Offset results are skipped and at most Limit are returned
+	Offset int
+	Limit  int
+}
+
+// AddCLIFlags adds the storage flags
+func AddCLIFlags(fs []cli.Flag) []cli.Flag {
+	return append(fs,
+		cli.StringFlag{
+			Name:   "store",
+			Usage:  "buntdb file to index delivered blocks in, or :memory:",
+			EnvVar: "BITPING_STORE",
+		},
+		cli.Int64Flag{
+			Name:  "store-max-blocks",
+			Usage: "how many recent blocks to keep per network, 0 keeps all",
+		},
+		cli.DurationFlag{
+			Name:  "store-max-age",
+			Usage: "drop stored blocks older than this, 0 keeps all",
+		},
+	)
+}
+
+// FromCLI opens the store set with --store or in the store section of the
+// config file. It returns nil when storage is off
+func FromCLI(c *cli.Context, cfg *config.Config) (*Store, error) {
+	opts := cfg.Store.WithFlags(c, storeFlags)
+	if opts.String("path", "") == "" {
+		return nil, nil
+	}
+
+	return Open(Options{
+		Path:      opts.String("path", ""),
+		MaxBlocks: opts.Int64("max_blocks", 0),
+		MaxAge:    opts.Duration("max_age", 0),
+	})
+}
+
+// Open opens the database at opts.Path
+func Open(opts Options) (*Store, error) {
+	db, err := buntdb.Open(opts.Path)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{db: db, Options: opts}, nil
+}
+
+// Close closes the database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Put indexes a block. A block already stored at the same height is
+// replaced along with its transactions. When it's another block, which is
+// what a reorg looks like, the blocks stored above it belong to the chain
+// that was reorged away and are dropped too. Blocks falling out of the
+// retention window are dropped
+func (s *Store) Put(block types.Block) error {
+	return s.db.Update(func(tx *buntdb.Tx) error {
+		var stored types.Block
+		err := getJSON(tx, blockKey(block.Network, block.Number), &stored)
+		switch {
+		case err == nil && stored.Hash != block.Hash:
+			err = deleteFrom(tx, block.Network, block.Number)
+		case err == nil || err == ErrNotFound:
+			err = deleteBlock(tx, block.Network, block.Number)
+		}
+		if err != nil {
+			return err
+		}
+
+		header := block
+		header.Transactions = nil
+		if err := setJSON(tx, blockKey(block.Network, block.Number), header); err != nil {
+			return err
+		}
+		if _, _, err := tx.Set(hashKey(block.Network, block.Hash), strconv.FormatInt(block.Number, 10), nil); err != nil {
+			return err
+		}
+
+		for i, transaction := range block.Transactions {
+			key := txKey(block.Network, block.Number, i)
+			if err := setJSON(tx, key, transaction); err != nil {
+				return err
+			}
+			if _, _, err := tx.Set(txHashKey(block.Network, transaction.Hash), key, nil); err != nil {
+				return err
+			}
+			for _, address := range transaction.Addresses() {
+				if _, _, err := tx.Set(addressKey(block.Network, address, block.Number, i), "", nil); err != nil {
+					return err
+				}
+			}
+		}
+
+		return s.prune(tx, block.Network, block.Number)
+	})
+}
+
+// prune drops the blocks of network that are out of the retention window
+func (s *Store) prune(tx *buntdb.Tx, network string, head int64) error {
+	if s.Options.MaxBlocks <= 0 && s.Options.MaxAge <= 0 {
+		return nil
+	}
+
+	var expired []int64
+
+	cutoff := time.Now().Add(-s.Options.MaxAge).Unix()
+	err := tx.AscendRange("", blockKey(network, 0), blockKey(network, math.MaxInt64), func(key, value string) bool {
+		number := keyHeight(key)
+		if s.Options.MaxBlocks > 0 && number <= head-s.Options.MaxBlocks {
+			expired = append(expired, number)
+			return true
+		}
+		if s.Options.MaxAge > 0 {
+			var block types.Block
+			if json.Unmarshal([]byte(value), &block) == nil && block.Time < cutoff {
+				expired = append(expired, number)
+				return true
+			}
+		}
+		// Blocks are in height order, so everything after this is recent
+		// enough too
+		return false
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, number := range expired {
+		if err := deleteBlock(tx, network, number); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Head returns the highest stored height of network
+func (s *Store) Head(network string) (int64, error) {
+	head := int64(-1)
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		return tx.DescendLessOrEqual("", blockKey(network, math.MaxInt64), func(key, value string) bool {
+			if strings.HasPrefix(key, blockPrefix(network)) {
+				head = keyHeight(key)
+			}
+			return false
+		})
+	})
+	if err == nil && head < 0 {
+		err = ErrNotFound
+	}
+	return head, err
+}
+
+// Block returns the stored block at a height with its transactions
+func (s *Store) Block(network string, number int64) (types.Block, error) {
+	var block types.Block
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		var err error
+		block, err = getBlock(tx, network, number)
+		return err
+	})
+	return block, err
+}
+
+// BlockByHash returns the stored block with the given hash
+func (s *Store) BlockByHash(network, hash string) (types.Block, error) {
+	var block types.Block
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		value, err := get(tx, hashKey(network, hash))
+		if err != nil {
+			return err
+		}
+		number, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		block, err = getBlock(tx, network, number)
+		return err
+	})
+	return block, err
+}
+
+// Transaction returns the stored transaction with the given hash
+func (s *Store) Transaction(network, hash string) (types.Transaction, error) {
+	var transaction types.Transaction
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		key, err := get(tx, txHashKey(network, hash))
+		if err != nil {
+			return err
+		}
+		return getJSON(tx, key, &transaction)
+	})
+	return transaction, err
+}
+
+// AddressTransactions returns the transactions touching address between
+// q.From and q.To, lowest first
+func (s *Store) AddressTransactions(network, address string, q Query) ([]types.Transaction, error) {
+	var transactions []types.Transaction
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		keys, err := addressTxKeys(tx, network, address, q)
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			var transaction types.Transaction
+			if err := getJSON(tx, key, &transaction); err != nil {
+				return err
+			}
+			transactions = append(transactions, transaction)
+		}
+		return nil
+	})
+	return transactions, err
+}
+
+// AddressActions returns the actions from, to or on address between q.From
+// and q.To, lowest first. Offset and Limit count actions
+func (s *Store) AddressActions(network, address string, q Query) ([]types.Action, error) {
+	var actions []types.Action
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		page := q
+		page.Offset, page.Limit = 0, -1
+		keys, err := addressTxKeys(tx, network, address, page)
+		if err != nil {
+			return err
+		}
+
+		skip, limit := q.Offset, pageLimit(q.Limit)
+		for _, key := range keys {
+			var transaction types.Transaction
+			if err := getJSON(tx, key, &transaction); err != nil {
+				return err
+			}
+			for _, action := range transaction.Actions {
+				if !action.Touches(address) {
+					continue
+				}
+				if skip > 0 {
+					skip--
+					continue
+				}
+				actions = append(actions, action)
+				if len(actions) == limit {
+					return nil
+				}
+			}
+		}
+		return nil
+	})
+	return actions, err
+}
+
+// addressTxKeys returns the transaction keys of the page of q touching
+// address. A negative limit returns all of them
+func addressTxKeys(tx *buntdb.Tx, network, address string, q Query) ([]string, error) {
+	to := q.To
+	if to <= 0 {
+		to = math.MaxInt64 - 1
+	}
+	prefix := addressPrefix(network, address)
+	start := prefix + fmt.Sprintf(heightFormat, q.From)
+	end := prefix + fmt.Sprintf(heightFormat, to+1)
+
+	limit := q.Limit
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+	skip := q.Offset
+
+	var keys []string
+	err := tx.AscendRange("", start, end, func(key, value string) bool {
+		if skip > 0 {
+			skip--
+			return true
+		}
+		// The height and index follow the prefix
+		parts := strings.Split(key[len(prefix):], ":")
+		number, _ := strconv.ParseInt(parts[0], 10, 64)
+		index, _ := strconv.Atoi(parts[1])
+		keys = append(keys, txKey(network, number, index))
+		return limit < 0 || len(keys) < limit
+	})
+	return keys, err
+}
+
+func pageLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultLimit
+	}
+	return limit
+}
+
+func getBlock(tx *buntdb.Tx, network string, number int64) (types.Block, error) {
+	var block types.Block
+	if err := getJSON(tx, blockKey(network, number), &block); err != nil {
+		return block, err
+	}
+
+	var err error
+	tx.AscendRange("", txPrefix(network, number), txPrefix(network, number+1), func(key, value string) bool {
+		var transaction types.Transaction
+		if err = json.Unmarshal([]byte(value), &transaction); err != nil {
+			return false
+		}
+		block.Transactions = append(block.Transactions, transaction)
+		return true
+	})
+	return block, err
+}
+
+// deleteFrom removes the blocks of network from a height up
+func deleteFrom(tx *buntdb.Tx, network string, number int64) error {
+	var numbers []int64
+	err := tx.AscendRange("", blockKey(network, number), blockKey(network, math.MaxInt64), func(key, value string) bool {
+		numbers = append(numbers, keyHeight(key))
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, n := range numbers {
+		if err := deleteBlock(tx, network, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteBlock removes the block at a height and everything indexing it
+func deleteBlock(tx *buntdb.Tx, network string, number int64) error {
+	var block types.Block
+	err := getJSON(tx, blockKey(network, number), &block)
+	if err == ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var keys []string
+	tx.AscendRange("", txPrefix(network, number), txPrefix(network, number+1), func(key, value string) bool {
+		keys = append(keys, key)
+
+		var transaction types.Transaction
+		if json.Unmarshal([]byte(value), &transaction) == nil {
+			keys = append(keys, txHashKey(network, transaction.Hash))
+			index, _ := strconv.Atoi(key[strings.LastIndex(key, ":")+1:])
+			for _, address := range transaction.Addresses() {
+				keys = append(keys, addressKey(network, address, number, index))
+			}
+		}
+		return true
+	})
+	keys = append(keys, blockKey(network, number), hashKey(network, block.Hash))
+
+	for _, key := range keys {
+		if _, err := tx.Delete(key); err != nil && err != buntdb.ErrNotFound {
+			return err
+		}
+	}
+	return nil
+}
+
+func blockPrefix(network string) string {
+	return "b:" + network + ":"
+}
+
+func blockKey(network string, number int64) string {
+	return blockPrefix(network) + fmt.Sprintf(heightFormat, number)
+}
+
+func txPrefix(network string, number int64) string {
+	return "x:" + network + ":" + fmt.Sprintf(heightFormat, number) + ":"
+}
+
+func txKey(network string, number int64, index int) string {
+	return txPrefix(network, number) + fmt.Sprintf(indexFormat, index)
+}
+
+func hashKey(network, hash string) string {
+	return "h:" + network + ":" + hash
+}
+
+func txHashKey(network, hash string) string {
+	return "t:" + network + ":" + hash
+}
+
+func addressPrefix(network, address string) string {
+	return "a:" + network + ":" + types.NormalizeAddress(address) + ":"
+}
+
+func addressKey(network, address string, number int64, index int) string {
+	return addressPrefix(network, address) + fmt.Sprintf(heightFormat+":"+indexFormat, number, index)
+}
+
+// keyHeight returns the height at the end of a block key
+func keyHeight(key string) int64 {
+	number, _ := strconv.ParseInt(key[strings.LastIndex(key, ":")+1:], 10, 64)
+	return number
+}
+
+func get(tx *buntdb.Tx, key string) (string, error) {
+	value, err := tx.Get(key)
+	if err == buntdb.ErrNotFound {
+		return "", ErrNotFound
+	}
+	return value, err
+}
+
+func getJSON(tx *buntdb.Tx, key string, v interface{}) error {
+	value, err := get(tx, key)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(value), v)
+}
+
+func setJSON(tx *buntdb.Tx, key string, v interface{}) error {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, _, err = tx.Set(key, string(value), nil)
+	return err
+}
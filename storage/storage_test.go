@@ -0,0 +1,256 @@
+package storage
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/auser/bitping/types"
+	"github.com/tidwall/buntdb"
+)
+
+const (
+	alice = "0xa94f5374fce5edbc8e2a8697c15331677e6ebf0b"
+	bob   = "0x6295ee1b4f6dd65047762f924ecd367c17eabf8f"
+)
+
+// testBlock returns block number of fork on network, with a transfer from
+// alice to bob for each of transfers. Hashes read like a3 for block 3 of
+// fork a
+func testBlock(network, fork string, number int64, transfers int) types.Block {
+	hash := fmt.Sprintf("%s%d", fork, number)
+	block := types.Block{
+		Network:    network,
+		Number:     number,
+		Hash:       hash,
+		ParentHash: fmt.Sprintf("%s%d", fork, number-1),
+		Time:       time.Now().Unix(),
+	}
+	for i := 0; i < transfers; i++ {
+		txHash := fmt.Sprintf("%s-%d", hash, i)
+		block.Transactions = append(block.Transactions, types.Transaction{
+			BlockHash:   hash,
+			BlockNumber: number,
+			Hash:        txHash,
+			Actions: []types.Action{{
+				TransactionHash: txHash,
+				From:            alice,
+				To:              bob,
+				Value:           types.BigIntFromInt(int64(i)),
+			}},
+		})
+	}
+	return block
+}
+
+func openTestStore(t *testing.T, opts Options) *Store {
+	opts.Path = ":memory:"
+	s, err := Open(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func put(t *testing.T, s *Store, blocks ...types.Block) {
+	for _, block := range blocks {
+		if err := s.Put(block); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// keys returns every key of the store, sorted
+func keys(t *testing.T, s *Store) []string {
+	var out []string
+	err := s.db.View(func(tx *buntdb.Tx) error {
+		return tx.Ascend("", func(key, value string) bool {
+			out = append(out, key)
+			return true
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestKeyLayout(t *testing.T) {
+	s := openTestStore(t, Options{})
+	put(t, s, testBlock("ethereum", "a", 7, 2))
+
+	want := []string{
+		"a:ethereum:" + bob + ":00000000000000000007:000000",
+		"a:ethereum:" + bob + ":00000000000000000007:000001",
+		"a:ethereum:" + alice + ":00000000000000000007:000000",
+		"a:ethereum:" + alice + ":00000000000000000007:000001",
+		"b:ethereum:00000000000000000007",
+		"h:ethereum:a7",
+		"t:ethereum:a7-0",
+		"t:ethereum:a7-1",
+		"x:ethereum:00000000000000000007:000000",
+		"x:ethereum:00000000000000000007:000001",
+	}
+	if got := keys(t, s); !reflect.DeepEqual(got, want) {
+		t.Errorf("keys:\n%v\nwant:\n%v", got, want)
+	}
+
+	block, err := s.BlockByHash("ethereum", "a7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if block.Number != 7 || len(block.Transactions) != 2 || block.Transactions[1].Hash != "a7-1" {
+		t.Errorf("block by hash = %d with %d transactions", block.Number, len(block.Transactions))
+	}
+	if tx, err := s.Transaction("ethereum", "a7-1"); err != nil || tx.BlockNumber != 7 {
+		t.Errorf("transaction = %+v, %v", tx, err)
+	}
+	if _, err := s.Block("classic", 7); err != ErrNotFound {
+		t.Errorf("block of another network: %v, want ErrNotFound", err)
+	}
+}
+
+func TestReorg(t *testing.T) {
+	s := openTestStore(t, Options{})
+	put(t, s,
+		testBlock("ethereum", "a", 1, 1),
+		testBlock("ethereum", "a", 2, 1),
+		testBlock("ethereum", "a", 3, 1),
+		testBlock("ethereum", "a", 4, 1),
+		testBlock("classic", "a", 3, 1),
+	)
+	before := keys(t, s)
+
+	// Replaying a stored block changes nothing
+	put(t, s, testBlock("ethereum", "a", 3, 1))
+	if got := keys(t, s); !reflect.DeepEqual(got, before) {
+		t.Fatalf("replaying a block changed the keys:\n%v\nwant:\n%v", got, before)
+	}
+
+	// A shorter fork replaces 2 up
+	put(t, s, testBlock("ethereum", "b", 2, 1))
+	head, err := s.Head("ethereum")
+	if err != nil || head != 2 {
+		t.Fatalf("head = %d, %v after the reorg, want 2", head, err)
+	}
+	for _, hash := range []string{"a2", "a3", "a4"} {
+		if _, err := s.BlockByHash("ethereum", hash); err != ErrNotFound {
+			t.Errorf("reorged block %s: %v, want ErrNotFound", hash, err)
+		}
+		if _, err := s.Transaction("ethereum", hash+"-0"); err != ErrNotFound {
+			t.Errorf("transaction of reorged block %s: %v, want ErrNotFound", hash, err)
+		}
+	}
+	txs, err := s.AddressTransactions("ethereum", alice, Query{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var hashes []string
+	for _, tx := range txs {
+		hashes = append(hashes, tx.Hash)
+	}
+	if want := []string{"a1-0", "b2-0"}; !reflect.DeepEqual(hashes, want) {
+		t.Errorf("alice's transactions %v, want %v", hashes, want)
+	}
+
+	// Other networks are left alone
+	if head, err := s.Head("classic"); err != nil || head != 3 {
+		t.Errorf("classic head = %d, %v, want 3", head, err)
+	}
+}
+
+func TestAddressPagination(t *testing.T) {
+	s := openTestStore(t, Options{})
+	for n := int64(1); n <= 5; n++ {
+		put(t, s, testBlock("ethereum", "a", n, 3))
+	}
+
+	tests := []struct {
+		query Query
+		// first and count describe the page as positions in the 15
+		// transactions or actions, lowest first
+		first, count int
+	}{
+		{Query{}, 0, 15},
+		{Query{Limit: 4}, 0, 4},
+		{Query{Offset: 4, Limit: 4}, 4, 4},
+		{Query{Offset: 12, Limit: 4}, 12, 3},
+		{Query{Offset: 20}, 0, 0},
+		{Query{From: 2, To: 3}, 3, 6},
+		{Query{From: 4}, 9, 6},
+		{Query{From: 2, To: 3, Offset: 5}, 8, 1},
+	}
+
+	hash := func(i int) string {
+		return fmt.Sprintf("a%d-%d", i/3+1, i%3)
+	}
+	for _, tt := range tests {
+		txs, err := s.AddressTransactions("ethereum", bob, tt.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		actions, err := s.AddressActions("ethereum", bob, tt.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(txs) != tt.count || len(actions) != tt.count {
+			t.Errorf("%+v: %d transactions and %d actions, want %d", tt.query, len(txs), len(actions), tt.count)
+			continue
+		}
+		for i := range txs {
+			if want := hash(tt.first + i); txs[i].Hash != want || actions[i].TransactionHash != want {
+				t.Errorf("%+v: item %d is %s and %s, want %s", tt.query, i, txs[i].Hash, actions[i].TransactionHash, want)
+			}
+		}
+	}
+
+	// Addresses compare case insensitively
+	if txs, _ := s.AddressTransactions("ethereum", "0x6295EE1B4F6DD65047762F924ECD367C17EABF8F", Query{}); len(txs) != 15 {
+		t.Errorf("got %d transactions for the checksummed address, want 15", len(txs))
+	}
+}
+
+func TestRetention(t *testing.T) {
+	t.Run("max blocks", func(t *testing.T) {
+		s := openTestStore(t, Options{MaxBlocks: 3})
+		for n := int64(1); n <= 6; n++ {
+			put(t, s, testBlock("ethereum", "a", n, 1))
+		}
+		put(t, s, testBlock("classic", "a", 1, 1))
+
+		for n := int64(1); n <= 6; n++ {
+			_, err := s.Block("ethereum", n)
+			if kept := n > 3; kept != (err == nil) {
+				t.Errorf("block %d: %v, kept = %v", n, err, kept)
+			}
+		}
+		if _, err := s.Transaction("ethereum", "a2-0"); err != ErrNotFound {
+			t.Errorf("transaction of a dropped block: %v, want ErrNotFound", err)
+		}
+		if txs, _ := s.AddressTransactions("ethereum", alice, Query{}); len(txs) != 3 {
+			t.Errorf("%d of alice's transactions left, want 3", len(txs))
+		}
+		// The heights of other networks are counted on their own
+		if _, err := s.Block("classic", 1); err != nil {
+			t.Errorf("classic block 1: %v", err)
+		}
+	})
+
+	t.Run("max age", func(t *testing.T) {
+		s := openTestStore(t, Options{MaxAge: time.Hour})
+		old := testBlock("ethereum", "a", 1, 1)
+		old.Time = time.Now().Add(-2 * time.Hour).Unix()
+		put(t, s, old, testBlock("ethereum", "a", 2, 1))
+
+		if _, err := s.Block("ethereum", 1); err != ErrNotFound {
+			t.Errorf("old block: %v, want ErrNotFound", err)
+		}
+		if _, err := s.Block("ethereum", 2); err != nil {
+			t.Errorf("recent block: %v", err)
+		}
+	})
+}
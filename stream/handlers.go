@@ -0,0 +1,160 @@
+package stream
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// keepAlive is how often idle connections get a ping
+const keepAlive = 15 * time.Second
+
+// writeTimeout bounds how long a single websocket write may take
+const writeTimeout = 10 * time.Second
+
+// ServeSSE streams the subscription in the query string as Server-Sent
+// Events. The event name is the item kind and the data its unified json
+func (h *Hub) ServeSSE(w http.ResponseWriter, r *http.Request) {
+	sub, err := SubscriptionFromQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	c := h.Subscribe(sub)
+	defer h.Unsubscribe(c)
+
+	ping := time.NewTicker(keepAlive)
+	defer ping.Stop()
+
+	// The request context is done when the client goes away
+	gone := r.Context().Done()
+
+	for {
+		select {
+		case m := <-c.send:
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", m.Kind, m.Data); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-ping.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-c.done:
+			if c.Err() != nil {
+				fmt.Fprintf(w, "event: error\ndata: %q\n\n", c.Err().Error())
+				flusher.Flush()
+			}
+			return
+		case <-gone:
+			return
+		}
+	}
+}
+
+// ServeWebSocket streams the subscription in the query string over a
+// websocket. Each message is {"kind": ..., "data": ...}. Browsers can only
+// connect from origins, the same list the api allows with CORS
+func (h *Hub) ServeWebSocket(origins []string) http.Handler {
+	return websocket.Server{
+		Handshake: func(config *websocket.Config, r *http.Request) error {
+			origin, err := websocket.Origin(config, r)
+			if err != nil {
+				return err
+			}
+			// Clients other than browsers don't send an Origin
+			if origin != nil && !allowedOrigin(origins, origin.String()) {
+				return fmt.Errorf("origin %s not allowed", origin)
+			}
+			config.Origin = origin
+			return nil
+		},
+		Handler: h.serveWebSocket,
+	}
+}
+
+// allowedOrigin matches origin against origins, which can be * or hold a
+// * wildcard like https://*.example.com
+func allowedOrigin(origins []string, origin string) bool {
+	origin = strings.ToLower(origin)
+	for _, o := range origins {
+		o = strings.ToLower(o)
+		if o == "*" || o == origin {
+			return true
+		}
+		if i := strings.Index(o, "*"); i >= 0 {
+			prefix, suffix := o[:i], o[i+1:]
+			if len(origin) >= len(prefix)+len(suffix) && strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (h *Hub) serveWebSocket(ws *websocket.Conn) {
+	defer ws.Close()
+
+	sub, err := SubscriptionFromQuery(ws.Request().URL.Query())
+	if err != nil {
+		websocket.JSON.Send(ws, map[string]string{"kind": "error", "data": err.Error()})
+		return
+	}
+
+	c := h.Subscribe(sub)
+	defer h.Unsubscribe(c)
+
+	// Nothing is expected from the client, reading only notices when it
+	// closes the connection
+	gone := make(chan struct{})
+	go func() {
+		var discard []byte
+		for websocket.Message.Receive(ws, &discard) == nil {
+		}
+		close(gone)
+	}()
+
+	ping := time.NewTicker(keepAlive)
+	defer ping.Stop()
+
+	send := func(frame string) bool {
+		ws.SetWriteDeadline(time.Now().Add(writeTimeout))
+		return websocket.Message.Send(ws, frame) == nil
+	}
+
+	for {
+		select {
+		case m := <-c.send:
+			if !send(fmt.Sprintf(`{"kind":%q,"data":%s}`, m.Kind, m.Data)) {
+				return
+			}
+		case <-ping.C:
+			if !send(`{"kind":"ping"}`) {
+				return
+			}
+		case <-c.done:
+			if c.Err() != nil {
+				send(fmt.Sprintf(`{"kind":"error","data":%q}`, c.Err().Error()))
+			}
+			return
+		case <-gone:
+			return
+		}
+	}
+}
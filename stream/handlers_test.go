@@ -0,0 +1,53 @@
+package stream
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/websocket"
+)
+
+func TestAllowedOrigin(t *testing.T) {
+	tests := []struct {
+		origins []string
+		origin  string
+		allowed bool
+	}{
+		{[]string{"*"}, "https://evil.example", true},
+		{[]string{"https://app.example.com"}, "https://app.example.com", true},
+		{[]string{"https://app.example.com"}, "HTTPS://APP.EXAMPLE.COM", true},
+		{[]string{"https://app.example.com"}, "https://evil.example", false},
+		{[]string{"https://app.example.com"}, "http://app.example.com", false},
+		{[]string{"https://*.example.com"}, "https://app.example.com", true},
+		{[]string{"https://*.example.com"}, "https://example.com.evil", false},
+		{nil, "https://app.example.com", false},
+	}
+	for _, tt := range tests {
+		if got := allowedOrigin(tt.origins, tt.origin); got != tt.allowed {
+			t.Errorf("%s with %v allowed = %v, want %v", tt.origin, tt.origins, got, tt.allowed)
+		}
+	}
+}
+
+func TestWebSocketOrigin(t *testing.T) {
+	srv := httptest.NewServer(NewHub(16).ServeWebSocket([]string{"https://app.example.com"}))
+	defer srv.Close()
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/?kind=block"
+
+	for origin, allowed := range map[string]bool{
+		"https://app.example.com": true,
+		"https://evil.example":    false,
+	} {
+		ws, err := websocket.Dial(url, "", origin)
+		if allowed && err != nil {
+			t.Errorf("%s: %v", origin, err)
+		}
+		if !allowed && err == nil {
+			t.Errorf("%s: connected from a foreign origin", origin)
+		}
+		if ws != nil {
+			ws.Close()
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package stream
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/auser/bitping/types"
+	"github.com/codegangsta/cli"
+)
+
+// Kinds of items a client can subscribe to
+const (
+	KindBlock       = "block"
+	KindTransaction = "transaction"
+	KindAction      = "action"
+)
+
+// DefaultBufferSize is how many messages are buffered per client before it's
+// disconnected as a slow consumer
+const DefaultBufferSize = 256
+
+// ErrSlowConsumer is the reason given to clients that fell behind
+var ErrSlowConsumer = errors.New("slow consumer, the client fell behind")
+
+// Subscription selects what a client receives
+type Subscription struct {
+	// Networks are the network labels to receive, all of them when empty
+	Networks map[string]bool
+	// Kind is one of KindBlock, KindTransaction or KindAction
+	Kind string
+	// Addresses only lets through items touching one of them, when set
+	Addresses map[string]bool
+	// Raw delivers blocks as types.Block instead of json, for clients that
+	// encode them some other way. It only applies to KindBlock
+	Raw bool
+}
+
+// SubscriptionFromQuery reads a subscription from the networks, kind and
+// address query parameters, i.e. ?networks=ethereum,bitcoin&kind=action
+func SubscriptionFromQuery(q url.Values) (Subscription, error) {
+	sub := Subscription{
+		Networks:  make(map[string]bool),
+		Kind:      KindBlock,
+		Addresses: make(map[string]bool),
+	}
+
+	for _, network := range splitList(q["networks"]) {
+		sub.Networks[network] = true
+	}
+	for _, address := range splitList(q["address"]) {
+		sub.Addresses[types.NormalizeAddress(address)] = true
+	}
+
+	if kind := q.Get("kind"); kind != "" {
+		switch kind {
+		case KindBlock, KindTransaction, KindAction:
+			sub.Kind = kind
+		default:
+			return sub, errors.New("kind must be block, transaction or action")
+		}
+	}
+
+	return sub, nil
+}
+
+// splitList splits repeated and comma separated values
+func splitList(values []string) []string {
+	var out []string
+	for _, v := range values {
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				out = append(out, item)
+			}
+		}
+	}
+	return out
+}
+
+func (sub Subscription) matchesNetwork(network string) bool {
+	return len(sub.Networks) == 0 || sub.Networks[network]
+}
+
+func (sub Subscription) matchesTransaction(tx types.Transaction) bool {
+	if len(sub.Addresses) == 0 {
+		return true
+	}
+	for _, address := range tx.Addresses() {
+		if sub.Addresses[address] {
+			return true
+		}
+	}
+	return false
+}
+
+func (sub Subscription) matchesAction(action types.Action) bool {
+	if len(sub.Addresses) == 0 {
+		return true
+	}
+	for address := range sub.Addresses {
+		if action.Touches(address) {
+			return true
+		}
+	}
+	return false
+}
+
+func (sub Subscription) matchesBlock(block types.Block) bool {
+	if len(sub.Addresses) == 0 {
+		return true
+	}
+	for _, tx := range block.Transactions {
+		if sub.matchesTransaction(tx) {
+			return true
+		}
+	}
+	return false
+}
+
+// Message is an item waiting to be written to a client
+type Message struct {
+	Kind string
+	// Data is the json encoded item
+	Data []byte
+	// Block is set instead of Data for Raw subscriptions. It's shared
+	// between clients and must not be modified
+	Block *types.Block
+}
+
+// Client is a subscriber of the Hub
+type Client struct {
+	sub  Subscription
+	send chan Message
+
+	closeOnce sync.Once
+	done      chan struct{}
+	err       error
+}
+
+// Messages returns the client's queue
+func (c *Client) Messages() <-chan Message {
+	return c.send
+}
+
+// Done is closed once the client is unsubscribed or dropped
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err returns why the hub disconnected the client
+func (c *Client) Err() error {
+	return c.err
+}
+
+func (c *Client) close(err error) {
+	c.closeOnce.Do(func() {
+		c.err = err
+		close(c.done)
+	})
+}
+
+// Hub fans delivered blocks out to the streaming clients. Publishing never
+// blocks: a client whose buffer is full is disconnected
+type Hub struct {
+	sync.Mutex
+
+	BufferSize int
+	clients    map[*Client]bool
+}
+
+// AddCLIFlags adds the streaming flags
+func AddCLIFlags(fs []cli.Flag) []cli.Flag {
+	return append(fs,
+		cli.IntFlag{
+			Name:  "stream-buffer",
+			Usage: "messages buffered per streaming client before it's disconnected",
+			Value: DefaultBufferSize,
+		},
+	)
+}
+
+// NewHub creates a Hub buffering bufferSize messages per client
+func NewHub(bufferSize int) *Hub {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+	return &Hub{
+		BufferSize: bufferSize,
+		clients:    make(map[*Client]bool),
+	}
+}
+
+// Subscribe adds a client
+func (h *Hub) Subscribe(sub Subscription) *Client {
+	c := &Client{
+		sub:  sub,
+		send: make(chan Message, h.BufferSize),
+		done: make(chan struct{}),
+	}
+
+	h.Lock()
+	h.clients[c] = true
+	h.Unlock()
+
+	return c
+}
+
+// Unsubscribe removes a client
+func (h *Hub) Unsubscribe(c *Client) {
+	h.Lock()
+	delete(h.clients, c)
+	h.Unlock()
+
+	c.close(nil)
+}
+
+// PublishBlock sends block, or its transactions or actions, to every
+// matching client. Each item is only encoded once
+func (h *Hub) PublishBlock(block types.Block) {
+	h.Lock()
+	defer h.Unlock()
+
+	var (
+		blockData  []byte
+		txData     = make(map[int][]byte)
+		actionData = make(map[[2]int][]byte)
+		err        error
+	)
+
+	for c := range h.clients {
+		if !c.sub.matchesNetwork(block.Network) {
+			continue
+		}
+
+		switch c.sub.Kind {
+		case KindBlock:
+			if !c.sub.matchesBlock(block) {
+				continue
+			}
+			if c.sub.Raw {
+				h.deliver(c, Message{Kind: KindBlock, Block: &block})
+				continue
+			}
+			if blockData == nil {
+				if blockData, err = json.Marshal(block); err != nil {
+					log.Printf("Stream encode error: %v", err)
+					return
+				}
+			}
+			h.deliver(c, Message{Kind: KindBlock, Data: blockData})
+
+		case KindTransaction:
+			for i, tx := range block.Transactions {
+				if !c.sub.matchesTransaction(tx) {
+					continue
+				}
+				if txData[i] == nil {
+					if txData[i], err = json.Marshal(tx.Versioned()); err != nil {
+						log.Printf("Stream encode error: %v", err)
+						continue
+					}
+				}
+				if !h.deliver(c, Message{Kind: KindTransaction, Data: txData[i]}) {
+					break
+				}
+			}
+
+		case KindAction:
+		actions:
+			for i, tx := range block.Transactions {
+				for j, action := range tx.Actions {
+					if !c.sub.matchesAction(action) {
+						continue
+					}
+					key := [2]int{i, j}
+					if actionData[key] == nil {
+						if actionData[key], err = json.Marshal(action.Versioned()); err != nil {
+							log.Printf("Stream encode error: %v", err)
+							continue
+						}
+					}
+					if !h.deliver(c, Message{Kind: KindAction, Data: actionData[key]}) {
+						break actions
+					}
+				}
+			}
+		}
+	}
+}
+
+// deliver queues m for c, dropping c when its buffer is full. It returns
+// false when c was dropped
+func (h *Hub) deliver(c *Client, m Message) bool {
+	select {
+	case c.send <- m:
+		return true
+	default:
+		log.Printf("Stream dropping slow client")
+		delete(h.clients, c)
+		c.close(ErrSlowConsumer)
+		return false
+	}
+}
@@ -0,0 +1,52 @@
+package tokens
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+
+	"github.com/auser/bitping/types"
+)
+
+// Selectors of the ERC-20 functions that move tokens
+var (
+	// transfer(address,uint256)
+	transferSelector = []byte{0xa9, 0x05, 0x9c, 0xbb}
+	// transferFrom(address,address,uint256)
+	transferFromSelector = []byte{0x23, 0xb8, 0x72, 0xdd}
+)
+
+// decodeTransfer turns a call to the transfer or transferFrom function of
+// the token contract a.To into a transfer of the token: Address becomes the
+// contract, and From, To and Value come from the arguments. It returns
+// false, leaving a alone, for any other call
+func decodeTransfer(a *types.Action) bool {
+	input := a.EthereumCall.Input
+	if len(input) < 4 {
+		return false
+	}
+
+	var from, to, value []byte
+	switch args := input[4:]; {
+	case bytes.Equal(input[:4], transferSelector) && len(args) >= 64:
+		to, value = args[:32], args[32:64]
+	case bytes.Equal(input[:4], transferFromSelector) && len(args) >= 96:
+		from, to, value = args[:32], args[32:64], args[64:96]
+	default:
+		return false
+	}
+
+	a.Address = a.To
+	if from != nil {
+		a.From = abiAddress(from)
+	}
+	a.To = abiAddress(to)
+	a.Value = types.NewBigInt(new(big.Int).SetBytes(value))
+	return true
+}
+
+// abiAddress is the lower case hex of an address argument, which is right
+// aligned in its 32 bytes
+func abiAddress(word []byte) string {
+	return "0x" + hex.EncodeToString(word[12:])
+}
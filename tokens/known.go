@@ -0,0 +1,39 @@
+package tokens
+
+// Known tokens are in every registry. The natives are keyed by the network
+// labels of the chain profiles. Only mainnet tokens have a price, the coins
+// of testnets are worthless
+var Known = []Token{
+	{Network: "ethereum", Symbol: "ETH", Decimals: 18, Name: "Ether", PriceID: "ETH"},
+	{Network: "classic", Symbol: "ETC", Decimals: 18, Name: "Ether Classic", PriceID: "ETC"},
+	{Network: "ropsten", Symbol: "ETH", Decimals: 18, Name: "Ropsten Ether"},
+	{Network: "rinkeby", Symbol: "ETH", Decimals: 18, Name: "Rinkeby Ether"},
+	{Network: "goerli", Symbol: "ETH", Decimals: 18, Name: "Goerli Ether"},
+	{Network: "kovan", Symbol: "ETH", Decimals: 18, Name: "Kovan Ether"},
+	{Network: "xdai", Symbol: "XDAI", Decimals: 18, Name: "xDai", PriceID: "XDAI"},
+	{Network: "poa", Symbol: "POA", Decimals: 18, Name: "POA", PriceID: "POA"},
+	{Network: "dev", Symbol: "ETH", Decimals: 18, Name: "Ether"},
+
+	{Network: "bitcoin", Symbol: "BTC", Decimals: 8, Name: "Bitcoin", PriceID: "BTC"},
+	{Network: "testnet3", Symbol: "BTC", Decimals: 8, Name: "Testnet Bitcoin"},
+	{Network: "signet", Symbol: "BTC", Decimals: 8, Name: "Signet Bitcoin"},
+	{Network: "regtest", Symbol: "BTC", Decimals: 8, Name: "Regtest Bitcoin"},
+	{Network: "litecoin", Symbol: "LTC", Decimals: 8, Name: "Litecoin", PriceID: "LTC"},
+	{Network: "litecoin-testnet", Symbol: "LTC", Decimals: 8, Name: "Testnet Litecoin"},
+	{Network: "bitcoincash", Symbol: "BCH", Decimals: 8, Name: "Bitcoin Cash", PriceID: "BCH"},
+	{Network: "bitcoincash-testnet", Symbol: "BCH", Decimals: 8, Name: "Testnet Bitcoin Cash"},
+	{Network: "dogecoin", Symbol: "DOGE", Decimals: 8, Name: "Dogecoin", PriceID: "DOGE"},
+	{Network: "dogecoin-testnet", Symbol: "DOGE", Decimals: 8, Name: "Testnet Dogecoin"},
+
+	// EOS itself is a token of eosio.token
+	{Network: "eos", Address: "eosio.token", Symbol: "EOS", Decimals: 4, Name: "EOS", PriceID: "EOS"},
+
+	// The most transferred ERC-20s on mainnet. Wrapped coins are priced as
+	// the coin
+	{Network: "ethereum", Address: "0xdac17f958d2ee523a2206206994597c13d831ec7", Symbol: "USDT", Decimals: 6, Name: "Tether USD", PriceID: "USDT"},
+	{Network: "ethereum", Address: "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", Symbol: "USDC", Decimals: 6, Name: "USD Coin", PriceID: "USDC"},
+	{Network: "ethereum", Address: "0x6b175474e89094c44da98b954eedeac495271d0f", Symbol: "DAI", Decimals: 18, Name: "Dai Stablecoin", PriceID: "DAI"},
+	{Network: "ethereum", Address: "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", Symbol: "WETH", Decimals: 18, Name: "Wrapped Ether", PriceID: "ETH"},
+	{Network: "ethereum", Address: "0x2260fac5e5542a773aa44fbcfedf7c193bc2c599", Symbol: "WBTC", Decimals: 8, Name: "Wrapped BTC", PriceID: "BTC"},
+	{Network: "ethereum", Address: "0x514910771af9ca656af840dff83e8264ecf986ca", Symbol: "LINK", Decimals: 18, Name: "ChainLink Token", PriceID: "LINK"},
+}
@@ -0,0 +1,184 @@
+package tokens
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/auser/bitping/config"
+	"github.com/auser/bitping/types"
+)
+
+// Token describes a coin or token of a network
+type Token struct {
+	Network string
+	// Address of the token contract, the token account on EOS. It's empty
+	// for the native coin of the network
+	Address string
+	Symbol  string
+	// Decimals is how many digits of the smallest unit are after the point
+	// in a whole token
+	Decimals uint64
+	Name     string
+	// PriceID is the symbol prices of the token are looked up by. It's
+	// empty for tokens without a fiat value, like the coins of testnets
+	PriceID string
+}
+
+// Native is true for the coin of the network itself
+func (t Token) Native() bool {
+	return t.Address == ""
+}
+
+// Registry looks up tokens by network and address. It's safe for
+// concurrent use
+type Registry struct {
+	sync.RWMutex
+	byAddress map[string]Token
+	bySymbol  map[string]Token
+}
+
+// NewRegistry returns a registry holding tokens
+func NewRegistry(tokens ...Token) *Registry {
+	r := &Registry{
+		byAddress: make(map[string]Token),
+		bySymbol:  make(map[string]Token),
+	}
+	for _, t := range tokens {
+		r.Add(t)
+	}
+	return r
+}
+
+// Default returns a registry holding the Known tokens
+func Default() *Registry {
+	return NewRegistry(Known...)
+}
+
+// FromConfig returns the Default registry with the tokens of the config
+// file added, replacing known tokens at the same address
+func FromConfig(cfg *config.Config) *Registry {
+	r := Default()
+	for _, tc := range cfg.Tokens {
+		t := Token{
+			Network:  tc.Network,
+			Address:  tc.Address,
+			Symbol:   tc.Symbol,
+			Decimals: tc.Decimals,
+			Name:     tc.Name,
+			PriceID:  tc.PriceID,
+		}
+		// An override without a price_id keeps the price of the known token
+		if known, ok := r.LookupSymbol(t.Network, t.Address, t.Symbol); ok && t.PriceID == "" {
+			t.PriceID = known.PriceID
+		}
+		r.Add(t)
+	}
+	return r
+}
+
+// Add adds t, replacing the token with the same network, address and
+// symbol
+func (r *Registry) Add(t Token) {
+	r.Lock()
+	defer r.Unlock()
+	r.byAddress[addressKey(t.Network, t.Address)] = t
+	r.bySymbol[symbolKey(t.Network, t.Address, t.Symbol)] = t
+}
+
+// Lookup returns the token at address on network. Addresses are compared
+// case insensitively
+func (r *Registry) Lookup(network, address string) (Token, bool) {
+	r.RLock()
+	defer r.RUnlock()
+	t, ok := r.byAddress[addressKey(network, address)]
+	return t, ok
+}
+
+// LookupSymbol returns the token with symbol at address on network. EOS
+// accounts can hold several tokens, so they're told apart by symbol
+func (r *Registry) LookupSymbol(network, address, symbol string) (Token, bool) {
+	r.RLock()
+	defer r.RUnlock()
+	t, ok := r.bySymbol[symbolKey(network, address, symbol)]
+	return t, ok
+}
+
+// Moved returns the token a normalized action moves: the asset of an EOS
+// action, the ERC-20 of a decoded transfer or the coin of network
+func (r *Registry) Moved(network string, a *types.Action) (Token, bool) {
+	switch {
+	case a.EOSAction != nil:
+		return r.LookupSymbol(network, a.Account, a.Symbol)
+	case a.EthereumCall != nil && a.Address != "":
+		return r.Lookup(network, a.Address)
+	}
+	return r.NativeToken(network)
+}
+
+// NativeToken returns the coin of network
+func (r *Registry) NativeToken(network string) (Token, bool) {
+	return r.Lookup(network, "")
+}
+
+// Name returns the name of the pipeline stage
+func (r *Registry) Name() string {
+	return "tokens"
+}
+
+// Process normalizes block as a pipeline stage
+func (r *Registry) Process(block *types.Block) error {
+	r.Normalize(block)
+	return nil
+}
+
+// Normalize fills in the symbol, precision and amount of the actions of
+// block. Ethereum calls to the transfer functions of a known ERC-20 become
+// transfers of the token. Actions of unknown tokens are left alone
+func (r *Registry) Normalize(block *types.Block) {
+	for i := range block.Transactions {
+		actions := block.Transactions[i].Actions
+		for j := range actions {
+			r.normalizeAction(block.Network, &actions[j])
+		}
+	}
+}
+
+func (r *Registry) normalizeAction(network string, a *types.Action) {
+	// Only normalize once, a decoded ERC-20 transfer doesn't look like a
+	// call to the token anymore
+	if a.Value == nil || a.Amount != "" {
+		return
+	}
+
+	if a.EOSAction != nil {
+		// Assets carry their symbol and precision
+		if a.Symbol != "" {
+			a.Amount = types.FormatAmount(a.Value, a.Precision)
+		}
+		return
+	}
+
+	token, ok := r.NativeToken(network)
+	if a.EthereumCall != nil {
+		// Other calls to a token contract move no tokens, only the value
+		// sent along
+		if erc20, known := r.Lookup(network, a.To); known && !erc20.Native() && decodeTransfer(a) {
+			token, ok = erc20, true
+		}
+	}
+	if !ok {
+		return
+	}
+
+	a.Symbol = token.Symbol
+	a.Precision = token.Decimals
+	a.Amount = types.FormatAmount(a.Value, token.Decimals)
+}
+
+func addressKey(network, address string) string {
+	return network + "/" + strings.ToLower(address)
+}
+
+func symbolKey(network, address, symbol string) string {
+	return network + "/" + strings.ToLower(address) + "/" + symbol
+}
@@ -0,0 +1,49 @@
+package types
+
+import "strings"
+
+// NormalizeAddress lowercases hex addresses so checksummed and plain
+// ethereum addresses match. Other addresses are case sensitive
+func NormalizeAddress(address string) string {
+	if strings.HasPrefix(address, "0x") || strings.HasPrefix(address, "0X") {
+		return strings.ToLower(address)
+	}
+	return address
+}
+
+// Addresses returns the distinct normalized addresses a transaction touches
+func (tx Transaction) Addresses() []string {
+	seen := make(map[string]bool)
+	var out []string
+	add := func(address string) {
+		address = NormalizeAddress(address)
+		if address == "" || address == "unknown" || seen[address] {
+			return
+		}
+		seen[address] = true
+		out = append(out, address)
+	}
+
+	for _, action := range tx.Actions {
+		add(action.From)
+		add(action.To)
+		add(action.Address)
+	}
+	for _, input := range tx.Inputs {
+		if input.PrevOut != nil {
+			add(input.PrevOut.Address)
+		}
+	}
+	for _, output := range tx.Outputs {
+		add(output.Address)
+	}
+	return out
+}
+
+// Touches returns true when the action is from, to or on address
+func (a Action) Touches(address string) bool {
+	address = NormalizeAddress(address)
+	return NormalizeAddress(a.From) == address ||
+		NormalizeAddress(a.To) == address ||
+		NormalizeAddress(a.Address) == address
+}
@@ -0,0 +1,42 @@
+package types
+
+// Severities of alerts
+const (
+	SeverityInfo     = "info"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// Alert is raised by an alert rule when an action, or the statistics of
+// the actions before it, cross a threshold
+type Alert struct {
+	// ID identifies the alert, raising it again for the same rule, group
+	// and action gives the same ID
+	ID       string `json:"id"`
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Network  string `json:"network"`
+	// Group is the value of the field the rule groups actions by
+	Group string `json:"group,omitempty"`
+
+	// Stat of Field over Window seconds, its Value and the Threshold it
+	// crossed, with Condition > or <
+	Stat      string  `json:"stat"`
+	Field     string  `json:"field,omitempty"`
+	Window    int64   `json:"window,omitempty"`
+	Value     float64 `json:"value"`
+	Condition string  `json:"condition,omitempty"`
+	Threshold float64 `json:"threshold,omitempty"`
+	Message   string  `json:"message"`
+
+	BlockHash   string `json:"blockHash"`
+	BlockNumber int64  `json:"blockNumber"`
+	Time        int64  `json:"time"`
+	// Transaction and Action raised the alert
+	Transaction *Transaction `json:"transaction"`
+	Action      *Action      `json:"action"`
+
+	// Suppressed is how many alerts of the rule and group the cooldown held
+	// back since the previous one
+	Suppressed int `json:"suppressed,omitempty"`
+}
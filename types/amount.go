@@ -0,0 +1,31 @@
+package types
+
+import (
+	"math/big"
+	"strings"
+)
+
+// FormatAmount formats value, counted in the token's smallest unit, as a
+// decimal with exactly decimals digits after the point. 1500000 with 6
+// decimals is 1.500000, and -5 with 2 decimals is -0.05
+func FormatAmount(value *BigInt, decimals uint64) string {
+	if value == nil {
+		return ""
+	}
+	i := big.Int(*value)
+	digits := new(big.Int).Abs(&i).String()
+
+	sign := ""
+	if i.Sign() < 0 {
+		sign = "-"
+	}
+	if decimals == 0 {
+		return sign + digits
+	}
+
+	d := int(decimals)
+	if len(digits) <= d {
+		digits = strings.Repeat("0", d-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-d] + "." + digits[len(digits)-d:]
+}
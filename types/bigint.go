@@ -3,6 +3,8 @@ package types
 import (
 	"fmt"
 	"math/big"
+	"strconv"
+	"strings"
 )
 
 type BigInt big.Int
@@ -31,7 +33,44 @@ func BigIntFromString(s string) (*BigInt, bool) {
 	return nil, worked
 }
 
-func (i BigInt) MarshalJSON() ([]byte, error) {
+// String is the decimal form of i
+func (i BigInt) String() string {
 	i2 := big.Int(i)
-	return []byte(fmt.Sprintf(`%s`, i2.String())), nil
+	return i2.String()
+}
+
+// MarshalJSON encodes i as a quoted decimal so consumers whose numbers are
+// doubles don't lose precision
+func (i BigInt) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(i.String())), nil
+}
+
+// UnmarshalJSON decodes a decimal or 0x prefixed hex integer, quoted or not
+func (i *BigInt) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = s[1 : len(s)-1]
+	}
+
+	neg := strings.HasPrefix(s, "-")
+	if neg {
+		s = s[1:]
+	}
+	base := 10
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		s, base = s[2:], 16
+	}
+
+	i2, ok := new(big.Int).SetString(s, base)
+	if !ok || strings.HasPrefix(s, "+") || strings.HasPrefix(s, "-") {
+		return fmt.Errorf("invalid big integer %s", data)
+	}
+	if neg {
+		i2.Neg(i2)
+	}
+	*i = BigInt(*i2)
+	return nil
 }
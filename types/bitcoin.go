@@ -26,10 +26,34 @@ type BitcoinBlock struct {
 	Weight            uint64 `json:"weight"`
 	Version           string `json:"version"`
 	VersionHex        string `json:"versionHex"`
-	Merkleroot        string `json:"merkleroot"`
+	Merkleroot        string `json:"merkleRoot"`
 	MedianTime        uint64 `json:"medianTime"`
 	Bits              string `json:"bits"`
 	Chainwork         string `json:"chainWork"`
 	PreviousBlockHash string `json:"previousBlockHash"`
 	NextBlockHash     string `json:"nextBlockHash"`
 }
+
+// https://developer.bitcoin.org/reference/rpc/getrawtransaction.html
+// {
+//   "txid": "4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b",
+//   "hash": "4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b",
+//   "version": 1,
+//   "size": 204,
+//   "vsize": 204,
+//   "weight": 816,
+//   "locktime": 0,
+//   "vin": [],
+//   "vout": []
+// }
+
+type BitcoinTransaction struct {
+	WitnessHash string `json:"witnessHash"`
+	Version     uint32 `json:"version"`
+	Size        uint64 `json:"size"`
+	VSize       uint64 `json:"vsize"`
+	Weight      uint64 `json:"weight"`
+	LockTime    uint64 `json:"lockTime"`
+	// Fee in satoshis. It's only set when the value of every input is known
+	Fee *uint64 `json:"fee"`
+}
@@ -0,0 +1,59 @@
+package types
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Bytes is binary data that's encoded in json as a 0x prefixed hex string.
+// A nil Bytes is encoded as null and an empty one as "0x"
+type Bytes []byte
+
+// MarshalJSON encodes b as 0x prefixed hex
+func (b Bytes) MarshalJSON() ([]byte, error) {
+	if b == nil {
+		return []byte("null"), nil
+	}
+	out := make([]byte, 0, 4+2*len(b))
+	out = append(out, `"0x`...)
+	out = append(out, hex.EncodeToString(b)...)
+	return append(out, '"'), nil
+}
+
+// UnmarshalJSON decodes a 0x prefixed hex string. Strings without the
+// prefix are read as base64, which is how bytes used to be encoded, so
+// blocks stored by older versions can still be read
+func (b *Bytes) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*b = nil
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid bytes %s, expected a 0x prefixed hex string", data)
+	}
+
+	var (
+		decoded []byte
+		err     error
+	)
+	if strings.HasPrefix(s, "0x") {
+		decoded, err = hex.DecodeString(s[2:])
+	} else {
+		decoded, err = base64.StdEncoding.DecodeString(s)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid bytes %s: %v", data, err)
+	}
+	*b = Bytes(decoded)
+	return nil
+}
+
+// String returns b as 0x prefixed hex
+func (b Bytes) String() string {
+	return "0x" + hex.EncodeToString(b)
+}
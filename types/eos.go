@@ -28,7 +28,7 @@ type EOSBlock struct {
 	// HeaderExtensions   [] `json:"headerExtensions"`
 	ProducerSignature string `json:"producerSignature"`
 	RefBlockPrefix    uint64 `json:"refBlockPrefix"`
-	ChainID           string `json:"chainID"`
+	ChainID           string `json:"chainId"`
 }
 
 // {
@@ -87,6 +87,57 @@ type EOSExtension struct {
 type EOSPermissionLevel struct {
 	Actor       string `json:"actor"`
 	Permisssion string `json:"permission"`
+	// ActorLabel tags a known actor when labels are turned on
+	ActorLabel *Label `json:"actorLabel,omitempty"`
+	// ActorAccount is the metadata of the actor when metadata resolution
+	// is turned on
+	ActorAccount *EOSAccount `json:"actorAccount,omitempty"`
+}
+
+// EOSAccount is the metadata of an EOS account, as returned by get_account
+type EOSAccount struct {
+	// Creator is only known when a history api is configured
+	Creator    string `json:"creator,omitempty"`
+	Created    int64  `json:"created"`
+	Privileged bool   `json:"privileged"`
+	RAMQuota   int64  `json:"ramQuota"`
+	RAMUsage   int64  `json:"ramUsage"`
+	// CPUWeight and NetWeight are the staked amounts, in the core token's
+	// smallest unit
+	CPUWeight   int64                  `json:"cpuWeight"`
+	NetWeight   int64                  `json:"netWeight"`
+	CPU         EOSResource            `json:"cpu"`
+	Net         EOSResource            `json:"net"`
+	Permissions []EOSAccountPermission `json:"permissions"`
+}
+
+// EOSResource is the usage of a staked resource, in microseconds for CPU
+// and bytes for NET. Unlimited resources have a Max of -1
+type EOSResource struct {
+	Used      int64 `json:"used"`
+	Available int64 `json:"available"`
+	Max       int64 `json:"max"`
+}
+
+// EOSAccountPermission is a permission of an EOS account and the keys and
+// accounts that satisfy it
+type EOSAccountPermission struct {
+	Name      string                `json:"name"`
+	Parent    string                `json:"parent"`
+	Threshold uint32                `json:"threshold"`
+	Keys      []EOSKeyWeight        `json:"keys"`
+	Accounts  []EOSPermissionWeight `json:"accounts"`
+}
+
+type EOSKeyWeight struct {
+	Key    string `json:"key"`
+	Weight uint32 `json:"weight"`
+}
+
+type EOSPermissionWeight struct {
+	Actor      string `json:"actor"`
+	Permission string `json:"permission"`
+	Weight     uint32 `json:"weight"`
 }
 
 type EOSAction struct {
@@ -94,7 +145,9 @@ type EOSAction struct {
 	Name          string               `json:"name"`
 	Authorization []EOSPermissionLevel `json:"authorization"`
 	HexData       string               `json:"hexData"`
-	Data          string               `json:"data"`
+	// Data is the action data decoded to json. It isn't tagged data so it
+	// doesn't clash with Action.Data when embedded in an Action
+	Data string `json:"jsonData"`
 }
 
 type EOSUnpackedTransaction struct {
@@ -102,7 +155,7 @@ type EOSUnpackedTransaction struct {
 	RefBlockNum             uint64         `json:"refBlockNum"`
 	RefBlockPrefix          uint64         `json:"refBlockPrefix"`
 	MaxNetUsageWords        uint64         `json:"maxNetUsageWords"`
-	MaxCPUUsageMicroSeconds uint64         `json:"maxCPUUsageMS"`
+	MaxCPUUsageMicroSeconds uint64         `json:"maxCpuUsageMs"`
 	DelaySec                uint64         `json:"delaySec"`
 	Actions                 []EOSAction    `json:"actions"`
 	ContextFreeActions      []EOSAction    `json:"contextFreeActions"`
@@ -113,7 +166,7 @@ type EOSTransactionWithID struct {
 	ID                    string                 `json:"id"`
 	Signatures            []string               `json:"signatures"`
 	Compression           string                 `json:"compression"`
-	PackedTRX             string                 `json:"packedTRX"`
+	PackedTRX             string                 `json:"packedTrx"`
 	PackedContextFreeData string                 `json:"packedContextFreeData"`
 	ContextFreeData       []string               `json:"contextFreeData"`
 	Transaction           EOSUnpackedTransaction `json:"transaction"`
@@ -121,7 +174,7 @@ type EOSTransactionWithID struct {
 
 type EOSTransactionReceipt struct {
 	Status               string               `json:"status"`
-	CPUUsageMicroSeconds uint64               `json:"cpuUsageUS"`
+	CPUUsageMicroSeconds uint64               `json:"cpuUsageUs"`
 	NetUsageWords        uint64               `json:"netUsageWords"`
 	TRX                  EOSTransactionWithID `json:"trx"`
 }
@@ -6,7 +6,11 @@ import (
 
 type GethHeader = t.Header
 type GethBlock = t.Block
+type GethTransaction = t.Transaction
 type GethHomesteadSigner = t.HomesteadSigner
+type GethSigner = t.Signer
+
+var NewGethEIP155Signer = t.NewEIP155Signer
 
 // https://github.com/ethereum/wiki/wiki/JavaScript-API#web3ethgetblock
 // {
@@ -33,16 +37,18 @@ type GethHomesteadSigner = t.HomesteadSigner
 // }
 
 type EthereumBlock struct {
-	Sha3Uncles       string   `json:"sha3Uncles"`
-	LogsBloom        string   `json:"logsBloom"`
-	TransactionsRoot string   `json:"transactionsRoot"`
-	StateRoot        string   `json:"stateRoot"`
-	Miner            string   `json:"miner"`
-	TotalDifficulty  *BigInt  `json:"totalDifficulty"`
-	ExtraData        string   `json:"extraData"`
-	GasLimit         uint64   `json:"gasLimit"`
-	GasUsed          uint64   `json:"gasUsed"`
-	Uncles           []string `json:"uncles"`
+	Sha3Uncles       string `json:"sha3Uncles"`
+	LogsBloom        string `json:"logsBloom"`
+	TransactionsRoot string `json:"transactionsRoot"`
+	StateRoot        string `json:"stateRoot"`
+	// Miner is the block's author, which is the signer on PoA chains
+	Miner           string   `json:"miner"`
+	Coinbase        string   `json:"coinbase"`
+	TotalDifficulty *BigInt  `json:"totalDifficulty"`
+	ExtraData       string   `json:"extraData"`
+	GasLimit        uint64   `json:"gasLimit"`
+	GasUsed         uint64   `json:"gasUsed"`
+	Uncles          []string `json:"uncles"`
 }
 
 // {
@@ -60,16 +66,16 @@ type EthereumBlock struct {
 // }
 
 type EthereumCall struct {
-	Input []byte `json:"input"`
+	Input Bytes `json:"input"`
 }
 
 type EthereumEvent struct {
 	LogIndex         uint64   `json:"logIndex"`
 	TransactionIndex uint64   `json:"transactionIndex"`
 	Address          string   `json:"address"`
-	Data             []byte   `json:"data"`
+	Data             Bytes    `json:"data"`
 	Topics           []string `json:"topics"`
-	Removed          bool     `json:"bool"`
+	Removed          bool     `json:"removed"`
 }
 
 type EthereumTransaction struct {
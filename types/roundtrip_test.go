@@ -0,0 +1,305 @@
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+// generator fills the unified types with random values the way watchers
+// do: at most one chain is set, omitempty slices are nil or non-empty, and
+// json:"-" fields are left alone
+type generator struct {
+	r     *rand.Rand
+	depth int
+}
+
+var (
+	bigIntType = reflect.TypeOf(BigInt{})
+	bytesType  = reflect.TypeOf(Bytes{})
+)
+
+// maxDepth stops the nesting of slices and pointers
+const maxDepth = 6
+
+func (g *generator) value(t reflect.Type, omitempty bool) reflect.Value {
+	v := reflect.New(t).Elem()
+	switch t {
+	case bigIntType:
+		v.Set(reflect.ValueOf(*g.bigInt()))
+		return v
+	case bytesType:
+		v.Set(reflect.ValueOf(g.bytes(omitempty)))
+		return v
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		if g.depth < maxDepth && g.r.Intn(3) > 0 {
+			g.depth++
+			v.Set(g.value(t.Elem(), false).Addr())
+			g.depth--
+		}
+	case reflect.Slice:
+		n := g.r.Intn(4) - 1
+		if g.depth >= maxDepth || n < 0 || (n == 0 && omitempty) {
+			break
+		}
+		g.depth++
+		s := reflect.MakeSlice(t, n, n)
+		for i := 0; i < n; i++ {
+			s.Index(i).Set(g.value(t.Elem(), false))
+		}
+		g.depth--
+		v.Set(s)
+	case reflect.Struct:
+		g.fill(v)
+	case reflect.String:
+		v.SetString(g.string())
+	case reflect.Bool:
+		v.SetBool(g.r.Intn(2) == 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		bits := uint(t.Bits())
+		v.SetInt(g.r.Int63() >> (64 - bits) * int64(1-2*g.r.Intn(2)))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(g.r.Uint64() >> (64 - uint(t.Bits())))
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(float64(g.r.Int63n(1<<40)) / 64)
+	}
+	return v
+}
+
+// fill sets the fields of the struct v. Of the embedded chain structs only
+// one is set, like on the blocks the watchers emit
+func (g *generator) fill(v reflect.Value) {
+	t := v.Type()
+	var chains []int
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" || f.PkgPath != "" {
+			continue
+		}
+		if f.Anonymous && f.Type.Kind() == reflect.Ptr {
+			chains = append(chains, i)
+			continue
+		}
+		v.Field(i).Set(g.value(f.Type, strings.Contains(tag, "omitempty")))
+	}
+	if len(chains) > 0 {
+		if i := g.r.Intn(len(chains) + 1); i < len(chains) {
+			f := v.Field(chains[i])
+			f.Set(g.value(f.Type().Elem(), false).Addr())
+		}
+	}
+}
+
+// bigInt returns a positive or negative integer of up to 320 bits, past
+// the 256 bits of the EVM
+func (g *generator) bigInt() *BigInt {
+	i := new(big.Int).Rand(g.r, new(big.Int).Lsh(big.NewInt(1), uint(g.r.Intn(321))))
+	if g.r.Intn(2) == 0 {
+		i.Neg(i)
+	}
+	return parseBigInt(i.String())
+}
+
+// parseBigInt parses a decimal like UnmarshalJSON does. big.Int has more
+// than one representation of zero, DeepEqual only sees the same one
+func parseBigInt(s string) *BigInt {
+	i, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic("invalid big integer " + s)
+	}
+	return NewBigInt(i)
+}
+
+// bytes returns nil, empty or random bytes. Empty bytes are encoded as
+// "0x", so they aren't empty to omitempty
+func (g *generator) bytes(omitempty bool) Bytes {
+	switch g.r.Intn(3) {
+	case 0:
+		return nil
+	case 1:
+		return Bytes{}
+	}
+	b := make(Bytes, 1+g.r.Intn(64))
+	g.r.Read(b)
+	return b
+}
+
+// string returns valid utf-8, with some of the characters json escapes
+func (g *generator) string() string {
+	const chars = "abcdef0123456789 xyzABC\"\\/<>&\n\téü€😀"
+	runes := []rune(chars)
+	out := make([]rune, g.r.Intn(12))
+	for i := range out {
+		out[i] = runes[g.r.Intn(len(runes))]
+	}
+	return string(out)
+}
+
+// roundTrip encodes v, decodes the json into a new value of the same type
+// and compares it with v
+func roundTrip(t *testing.T, v interface{}) bool {
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Errorf("marshal %T: %v", v, err)
+		return false
+	}
+	out := reflect.New(reflect.TypeOf(v))
+	if err := json.Unmarshal(data, out.Interface()); err != nil {
+		t.Errorf("unmarshal %T: %v\n%s", v, err, data)
+		return false
+	}
+	if got := out.Elem().Interface(); !reflect.DeepEqual(got, v) {
+		t.Errorf("%T changed in a round trip: %s\n%s", v, difference("", reflect.ValueOf(v), out.Elem()), data)
+		return false
+	}
+	return true
+}
+
+// difference describes the first difference between a and b
+func difference(path string, a, b reflect.Value) string {
+	if a.Type() == bigIntType {
+		x, y := a.Addr().Interface().(*BigInt), b.Addr().Interface().(*BigInt)
+		if x.String() != y.String() {
+			return path + ": " + x.String() + " != " + y.String()
+		}
+		return ""
+	}
+	switch a.Kind() {
+	case reflect.Ptr:
+		if a.IsNil() != b.IsNil() {
+			return path + ": nil != non-nil"
+		}
+		if !a.IsNil() {
+			return difference(path, a.Elem(), b.Elem())
+		}
+	case reflect.Slice:
+		if a.IsNil() != b.IsNil() {
+			return path + ": nil != empty"
+		}
+		if a.Len() != b.Len() {
+			return path + ": lengths differ"
+		}
+		for i := 0; i < a.Len(); i++ {
+			if d := difference(path+"["+strconv.Itoa(i)+"]", a.Index(i), b.Index(i)); d != "" {
+				return d
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if d := difference(path+"."+a.Type().Field(i).Name, a.Field(i), b.Field(i)); d != "" {
+				return d
+			}
+		}
+	default:
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			return path + ": values differ"
+		}
+	}
+	return ""
+}
+
+// checkRoundTrip round-trips random values of the type of zero
+func checkRoundTrip(t *testing.T, zero interface{}) {
+	typ := reflect.TypeOf(zero)
+	config := &quick.Config{
+		MaxCount: 300,
+		Values: func(values []reflect.Value, r *rand.Rand) {
+			values[0] = (&generator{r: r}).value(typ, false)
+		},
+	}
+	f := reflect.MakeFunc(reflect.FuncOf([]reflect.Type{typ}, []reflect.Type{reflect.TypeOf(true)}, false),
+		func(args []reflect.Value) []reflect.Value {
+			return []reflect.Value{reflect.ValueOf(roundTrip(t, args[0].Interface()))}
+		})
+	if err := quick.Check(f.Interface(), config); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBlockRoundTrip(t *testing.T) {
+	checkRoundTrip(t, Block{})
+}
+
+func TestTransactionRoundTrip(t *testing.T) {
+	checkRoundTrip(t, Transaction{})
+	checkRoundTrip(t, VersionedTransaction{})
+}
+
+func TestActionRoundTrip(t *testing.T) {
+	checkRoundTrip(t, Action{})
+	checkRoundTrip(t, VersionedAction{})
+}
+
+func TestBigIntRoundTrip(t *testing.T) {
+	for _, s := range []string{
+		"0",
+		"-1",
+		// 2^256 and past it
+		"115792089237316195423570985008687907853269984665640564039457584007913129639936",
+		"-115792089237316195423570985008687907853269984665640564039457584007913129639936123",
+	} {
+		roundTrip(t, parseBigInt(s))
+	}
+
+	f := func(seed int64) bool {
+		return roundTrip(t, (&generator{r: rand.New(rand.NewSource(seed))}).bigInt())
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBytesRoundTrip(t *testing.T) {
+	for _, b := range []Bytes{nil, {}, {0}, {0xde, 0xad, 0xbe, 0xef}} {
+		roundTrip(t, b)
+	}
+
+	f := func(b []byte) bool {
+		return roundTrip(t, Bytes(b))
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBlockCopy(t *testing.T) {
+	g := &generator{r: rand.New(rand.NewSource(1))}
+	for i := 0; i < 100; i++ {
+		block := g.value(reflect.TypeOf(Block{}), false).Interface().(Block)
+		data, _ := json.Marshal(block)
+
+		copied := block.Copy()
+		if !reflect.DeepEqual(copied, block) {
+			t.Fatalf("copy differs: %s", difference("", reflect.ValueOf(block), reflect.ValueOf(copied)))
+		}
+		for i := range copied.Transactions {
+			tx := &copied.Transactions[i]
+			tx.Matches = append(tx.Matches[:0], "changed")
+			for j := range tx.Actions {
+				a := &tx.Actions[j]
+				a.Amount = "changed"
+				a.FromLabel = &Label{Entity: "changed"}
+				if a.EOSAction != nil {
+					for k := range a.EOSAction.Authorization {
+						a.EOSAction.Authorization[k].Actor = "changed"
+					}
+				}
+			}
+			for j := range tx.Events {
+				tx.Events[j] = Event{}
+			}
+		}
+		if after, _ := json.Marshal(block); string(after) != string(data) {
+			t.Fatal("changing the copy changed the block")
+		}
+	}
+}
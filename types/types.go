@@ -27,9 +27,9 @@ type Receipt struct {
 }
 
 type PercentageCalculations struct {
-	Percentage           float64
-	BlocksToGo           uint64
-	Bps                  int
-	EstimatedMinutesLeft int
-	CurrentBlock         uint64
+	Percentage           float64 `json:"percentage"`
+	BlocksToGo           uint64  `json:"blocksToGo"`
+	Bps                  int     `json:"bps"`
+	EstimatedMinutesLeft int     `json:"estimatedMinutesLeft"`
+	CurrentBlock         uint64  `json:"currentBlock"`
 }
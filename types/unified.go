@@ -1,17 +1,29 @@
 package types
 
+// Block statuses set when blocks are gated on confirmations
+const (
+	// BlockStatusPending blocks just arrived and may still be reorged away
+	BlockStatusPending = "pending"
+	// BlockStatusConfirmed blocks have the configured number of descendants
+	BlockStatusConfirmed = "confirmed"
+)
+
 type Block struct {
+	*BitcoinBlock
 	*EOSBlock
 	*EthereumBlock
 
 	Hash       string  `json:"hash"`
 	HeaderHash string  `json:"headerHash"`
 	Network    string  `json:"network"`
-	NetworkID  int64   `json:"networkID"`
+	NetworkID  int64   `json:"networkId"`
 	Number     int64   `json:"number"`
 	Size       float64 `json:"size"`
 	Time       int64   `json:"time"`
 
+	// Status is only set when blocks are gated on confirmations
+	Status string `json:"status,omitempty"`
+
 	// Only used for PoW coins
 	Nonce      string  `json:"nonce"`
 	Difficulty *BigInt `json:"difficulty"`
@@ -21,9 +33,42 @@ type Block struct {
 	ParentHash string `json:"parentHash"`
 
 	Transactions []Transaction `json:"transactions"`
+
+	// Raw is the block as the node returned it. It's never serialized with
+	// the unified block
+	Raw *RawBlock `json:"-"`
 }
 
+// Raw block formats
+const (
+	// RawFormatRLP is the consensus encoding of Ethereum blocks
+	RawFormatRLP = "rlp"
+	// RawFormatJSON is used for nodes that only speak JSON
+	RawFormatJSON = "json"
+)
+
+// RawBlock holds the native block payload a unified block was built from
+type RawBlock struct {
+	Format string
+	Data   []byte
+}
+
+// Mempool statuses set when watching pending transactions
+const (
+	// MempoolStatusPending transactions are in the pool and have no block
+	// fields
+	MempoolStatusPending = "pending"
+	// MempoolStatusMined transactions were pending and are now in a block
+	MempoolStatusMined = "mined"
+	// MempoolStatusDropped transactions left the pool without being mined
+	MempoolStatusDropped = "dropped"
+	// MempoolStatusReplaced transactions had their nonce reused by the
+	// transaction in ReplacedBy
+	MempoolStatusReplaced = "replaced"
+)
+
 type Transaction struct {
+	*BitcoinTransaction
 	*EOSTransactionReceipt
 	*EthereumTransaction
 
@@ -33,12 +78,50 @@ type Transaction struct {
 	Hash            string `json:"hash"`
 	Nonce           int64  `json:"nonce"`
 
+	// MempoolStatus is only set when watching pending transactions. It's
+	// kept apart from the EOS receipt status
+	MempoolStatus string `json:"mempoolStatus,omitempty"`
+	ReplacedBy    string `json:"replacedBy,omitempty"`
+
 	// Is this a tx that's split form
 	IsDerived    bool `json:"isSplit"`
 	DerivedIndex int  `json:"derivedIndex"`
 
+	// Only used for UTXO coins
+	Inputs  []TxInput  `json:"inputs,omitempty"`
+	Outputs []TxOutput `json:"outputs,omitempty"`
+
 	Actions []Action `json:"actions"`
 	Events  []Event  `json:"events"`
+
+	// Matches are the names of the filters an action of the transaction
+	// matched. It's only set when filters apply to the block
+	Matches []string `json:"matches,omitempty"`
+}
+
+// TxOutput is an output of a UTXO transaction
+type TxOutput struct {
+	Index uint32 `json:"index"`
+	// Value in the smallest unit of the coin, i.e. satoshis
+	Value      uint64 `json:"value"`
+	Script     string `json:"script"`
+	ScriptType string `json:"scriptType"`
+	// Address is empty for scripts that don't pay to an address, like
+	// OP_RETURN outputs or bare multisig
+	Address string `json:"address"`
+}
+
+// TxInput spends the output PrevIndex of the transaction PrevTxHash
+type TxInput struct {
+	PrevTxHash string `json:"prevTxHash"`
+	PrevIndex  uint32 `json:"prevIndex"`
+	Sequence   uint32 `json:"sequence"`
+	// Coinbase holds the coinbase data of a coinbase input, which doesn't
+	// spend anything
+	Coinbase string `json:"coinbase,omitempty"`
+	// PrevOut is the output being spent. It's nil when it couldn't be
+	// looked up
+	PrevOut *TxOutput `json:"prevOut"`
 }
 
 // Actions/Events/Function Calls
@@ -47,7 +130,6 @@ type Event struct {
 	*EthereumEvent
 }
 
-// UTXO is address
 type Action struct {
 	*EOSAction
 	*EthereumCall
@@ -56,7 +138,7 @@ type Action struct {
 	BlockNumber     int64  `json:"blockNumber"`
 	TransactionHash string `json:"transactionHash"`
 	Address         string `json:"address"`
-	Data            []byte `json:"data"`
+	Data            Bytes  `json:"data"`
 
 	From      string  `json:"from"`
 	To        string  `json:"to"`
@@ -64,6 +146,82 @@ type Action struct {
 	Symbol    string  `json:"symbol"`
 	Precision uint64  `json:"precision"`
 
-	In  uint64 `json:"in"`
-	Out uint64 `json:"out"`
+	// Amount is Value in whole tokens, with Precision decimals. It's only
+	// set when the token is known
+	Amount string `json:"amount,omitempty"`
+
+	// Fiat is the value of Amount at the block time. It's only set when
+	// prices are turned on and the token has a price
+	Fiat *FiatValue `json:"fiat,omitempty"`
+
+	// FromLabel and ToLabel tag known addresses when labels are turned on
+	FromLabel *Label `json:"fromLabel,omitempty"`
+	ToLabel   *Label `json:"toLabel,omitempty"`
+
+	// FromAccount and ToAccount describe the sender and recipient when
+	// metadata resolution is turned on
+	FromAccount *Account `json:"fromAccount,omitempty"`
+	ToAccount   *Account `json:"toAccount,omitempty"`
+}
+
+// Account is what's known of an address or account on its chain
+type Account struct {
+	// ENS is the primary ENS name of an ethereum address. It's only set
+	// when the name resolves back to the address
+	ENS string `json:"ens,omitempty"`
+	// EOS is the metadata of an EOS account
+	EOS *EOSAccount `json:"eos,omitempty"`
+}
+
+// Label tags an address or account with who it belongs to
+type Label struct {
+	// Entity owning the address, i.e. Binance
+	Entity string `json:"entity"`
+	// Category of the address, i.e. exchange
+	Category string `json:"category"`
+}
+
+// FiatValue is the value of an amount of tokens in a fiat currency
+type FiatValue struct {
+	// Currency is the ISO 4217 code, i.e. USD
+	Currency string `json:"currency"`
+	// Price of a whole token, as a decimal string
+	Price string `json:"price"`
+	// Value of the amount, as a decimal string
+	Value string `json:"value"`
+}
+
+// Copy returns a copy of the block whose transactions, actions and events
+// can be changed without touching the ones of b. Watchers can hand the same
+// block out twice, pending and confirmed
+func (b Block) Copy() Block {
+	if b.Transactions == nil {
+		return b
+	}
+	txs := make([]Transaction, len(b.Transactions))
+	for i, tx := range b.Transactions {
+		if tx.Actions != nil {
+			actions := make([]Action, len(tx.Actions))
+			for j, a := range tx.Actions {
+				if a.EOSAction != nil {
+					eos := *a.EOSAction
+					if eos.Authorization != nil {
+						eos.Authorization = append(make([]EOSPermissionLevel, 0, len(eos.Authorization)), eos.Authorization...)
+					}
+					a.EOSAction = &eos
+				}
+				actions[j] = a
+			}
+			tx.Actions = actions
+		}
+		if tx.Events != nil {
+			tx.Events = append(make([]Event, 0, len(tx.Events)), tx.Events...)
+		}
+		if tx.Matches != nil {
+			tx.Matches = append(make([]string, 0, len(tx.Matches)), tx.Matches...)
+		}
+		txs[i] = tx
+	}
+	b.Transactions = txs
+	return b
 }
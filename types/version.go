@@ -0,0 +1,44 @@
+package types
+
+import (
+	"encoding/json"
+)
+
+// SchemaVersion is the version of the json shape of Block, Transaction and
+// Action, published under schema/. Bump it for any change that would break
+// existing consumers, like removing or retyping a field; adding fields
+// doesn't need a bump. `make schema-check` fails until it's bumped
+const SchemaVersion = 1
+
+// MarshalJSON adds schemaVersion to the block
+func (b Block) MarshalJSON() ([]byte, error) {
+	type block Block
+	return json.Marshal(struct {
+		SchemaVersion int `json:"schemaVersion"`
+		block
+	}{SchemaVersion, block(b)})
+}
+
+// VersionedTransaction is a transaction sent on its own, outside of its
+// block, with the schemaVersion a block carries
+type VersionedTransaction struct {
+	SchemaVersion int `json:"schemaVersion"`
+	Transaction
+}
+
+// Versioned returns t to send on its own
+func (t Transaction) Versioned() VersionedTransaction {
+	return VersionedTransaction{SchemaVersion, t}
+}
+
+// VersionedAction is an action sent on its own, outside of its block, with
+// the schemaVersion a block carries
+type VersionedAction struct {
+	SchemaVersion int `json:"schemaVersion"`
+	Action
+}
+
+// Versioned returns a to send on its own
+func (a Action) Versioned() VersionedAction {
+	return VersionedAction{SchemaVersion, a}
+}